@@ -7,6 +7,75 @@ import (
 	"github.com/google/uuid"
 )
 
+// EventType identifies the kind of security event recorded by the service.
+type EventType string
+
+// Event types emitted by the service.
+const (
+	EventRegister                   EventType = "register"
+	EventLogin                      EventType = "login"
+	EventOAuthAccountLinked         EventType = "oauth_account_linked"
+	EventTokenRefreshed             EventType = "token_refreshed"
+	EventRefreshTokenReused         EventType = "refresh_token_reused"
+	EventSessionRevoked             EventType = "session_revoked"
+	EventSessionsRevoked            EventType = "sessions_revoked"
+	EventPasswordResetInitiated     EventType = "password_reset_initiated"
+	EventPasswordResetCompleted     EventType = "password_reset_completed"
+	EventEmailVerificationInitiated EventType = "email_verification_initiated"
+	EventEmailVerified              EventType = "email_verified"
+	EventPasswordChanged            EventType = "password_changed"
+	EventPasswordRehashed           EventType = "password_rehashed"
+	EventAccountLocked              EventType = "account_locked"
+	EventRateLimitExceeded          EventType = "rate_limit_exceeded"
+)
+
+// AuditEvent is a structured security event delivered to the AuditLogRepository and Config.AuditSink.
+type AuditEvent struct {
+	Type      EventType              `json:"type"`
+	UserID    string                 `json:"user_id,omitempty"`
+	IP        string                 `json:"ip,omitempty"`
+	UserAgent string                 `json:"user_agent,omitempty"`
+	Message   string                 `json:"message"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+}
+
+// AuditSink receives audit events, for example to forward them to a log pipeline.
+// Record errors are ignored by the service so a failing sink never blocks authentication.
+type AuditSink interface {
+	Record(ctx context.Context, event AuditEvent) error
+}
+
+// AuditSinkFunc adapts a function to the AuditSink interface.
+type AuditSinkFunc func(ctx context.Context, event AuditEvent) error
+
+// Record calls f(ctx, event).
+func (f AuditSinkFunc) Record(ctx context.Context, event AuditEvent) error {
+	return f(ctx, event)
+}
+
+type requestInfo struct {
+	ip        string
+	userAgent string
+}
+
+const requestInfoContextKey contextKey = "auth-request-info"
+
+// WithRequestInfo returns a context carrying the client IP and User-Agent attached to audit events.
+// The HTTP middleware sets these automatically; gRPC and other transports can call it directly.
+func WithRequestInfo(ctx context.Context, ip, userAgent string) context.Context {
+	return context.WithValue(ctx, requestInfoContextKey, requestInfo{ip: ip, userAgent: userAgent})
+}
+
+// RequestInfoFromContext returns the client IP and User-Agent stored by WithRequestInfo.
+func RequestInfoFromContext(ctx context.Context) (ip, userAgent string) {
+	if ctx == nil {
+		return "", ""
+	}
+	info, _ := ctx.Value(requestInfoContextKey).(requestInfo)
+	return info.ip, info.userAgent
+}
+
 // AuditLogger writes audit events to the configured repository.
 type AuditLogger struct {
 	repo AuditLogRepository
@@ -22,16 +91,32 @@ func NewAuditLogger(repo AuditLogRepository) *AuditLogger {
 
 // Log creates a new audit entry when repo is configured.
 func (l *AuditLogger) Log(ctx context.Context, userID, action, message string, metadata map[string]interface{}) error {
+	ip, userAgent := RequestInfoFromContext(ctx)
+	return l.Record(ctx, AuditEvent{
+		Type:      EventType(action),
+		UserID:    userID,
+		IP:        ip,
+		UserAgent: userAgent,
+		Message:   message,
+		Metadata:  metadata,
+		Timestamp: time.Now().UTC(),
+	})
+}
+
+// Record stores event as an AuditLog entry when repo is configured.
+func (l *AuditLogger) Record(ctx context.Context, event AuditEvent) error {
 	if l == nil || l.repo == nil {
 		return nil
 	}
 	entry := &AuditLog{
 		ID:        uuid.NewString(),
-		UserID:    userID,
-		Action:    action,
-		Message:   message,
-		Metadata:  metadata,
-		CreatedAt: time.Now().UTC(),
+		UserID:    event.UserID,
+		Action:    string(event.Type),
+		Message:   event.Message,
+		IPAddress: event.IP,
+		UserAgent: event.UserAgent,
+		Metadata:  event.Metadata,
+		CreatedAt: event.Timestamp,
 	}
 	return l.repo.Create(ctx, entry)
 }
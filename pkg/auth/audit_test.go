@@ -34,3 +34,18 @@ func TestAuditLogger_Log(t *testing.T) {
 		t.Fatalf("action = %s", repo.entry.Action)
 	}
 }
+
+func TestAuditLogger_LogCapturesRequestInfo(t *testing.T) {
+	repo := &fakeAuditRepo{}
+	logger := NewAuditLogger(repo)
+	ctx := WithRequestInfo(context.Background(), "198.51.100.1", "agent/1.0")
+	if err := logger.Log(ctx, "user-id", string(EventLogin), "user logged in", nil); err != nil {
+		t.Fatalf("Log() error = %v", err)
+	}
+	if repo.entry.IPAddress != "198.51.100.1" || repo.entry.UserAgent != "agent/1.0" {
+		t.Fatalf("request info = %q, %q", repo.entry.IPAddress, repo.entry.UserAgent)
+	}
+	if repo.entry.CreatedAt.IsZero() {
+		t.Fatal("expected CreatedAt set")
+	}
+}
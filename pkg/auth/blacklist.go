@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// TokenBlacklist records revoked JWTs by their jti claim so they fail validation before they expire.
+// Sharing one blacklist across replicas makes Logout take effect cluster-wide.
+type TokenBlacklist interface {
+	// Revoke blacklists jti until exp, the token's own expiry; later the token is rejected anyway.
+	Revoke(ctx context.Context, jti string, exp time.Time) error
+	// IsRevoked reports whether jti is blacklisted.
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+	// RevokeUser rejects every token issued to userID before issuedBefore; the cutoff may be dropped
+	// after until, by which time those tokens have expired.
+	RevokeUser(ctx context.Context, userID string, issuedBefore, until time.Time) error
+	// RevokedBefore returns the cutoff set by RevokeUser for userID, or the zero time if there is none.
+	RevokedBefore(ctx context.Context, userID string) (time.Time, error)
+}
+
+// MemoryTokenBlacklist is the default in-process TokenBlacklist. Entries are local to the process and
+// are dropped once the token they revoke has expired.
+type MemoryTokenBlacklist struct {
+	mu      sync.Mutex
+	entries map[string]time.Time
+	users   map[string]userRevocation
+	now     func() time.Time
+}
+
+type userRevocation struct {
+	issuedBefore time.Time
+	until        time.Time
+}
+
+// NewMemoryTokenBlacklist returns an empty in-process blacklist.
+func NewMemoryTokenBlacklist() *MemoryTokenBlacklist {
+	return &MemoryTokenBlacklist{
+		entries: make(map[string]time.Time),
+		users:   make(map[string]userRevocation),
+		now:     time.Now,
+	}
+}
+
+// Revoke blacklists jti until exp and drops entries whose tokens have expired.
+func (m *MemoryTokenBlacklist) Revoke(ctx context.Context, jti string, exp time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	for id, expires := range m.entries {
+		if !now.Before(expires) {
+			delete(m.entries, id)
+		}
+	}
+	if now.Before(exp) {
+		m.entries[jti] = exp
+	}
+	return nil
+}
+
+// IsRevoked reports whether jti is blacklisted and its token has not yet expired.
+func (m *MemoryTokenBlacklist) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expires, ok := m.entries[jti]
+	if !ok {
+		return false, nil
+	}
+	if !m.now().Before(expires) {
+		delete(m.entries, jti)
+		return false, nil
+	}
+	return true, nil
+}
+
+// RevokeUser records issuedBefore as userID's token cutoff until the given time.
+func (m *MemoryTokenBlacklist) RevokeUser(ctx context.Context, userID string, issuedBefore, until time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	for id, revocation := range m.users {
+		if !now.Before(revocation.until) {
+			delete(m.users, id)
+		}
+	}
+	if now.Before(until) {
+		m.users[userID] = userRevocation{issuedBefore: issuedBefore, until: until}
+	}
+	return nil
+}
+
+// RevokedBefore returns userID's token cutoff while it is still in effect.
+func (m *MemoryTokenBlacklist) RevokedBefore(ctx context.Context, userID string) (time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	revocation, ok := m.users[userID]
+	if !ok {
+		return time.Time{}, nil
+	}
+	if !m.now().Before(revocation.until) {
+		delete(m.users, userID)
+		return time.Time{}, nil
+	}
+	return revocation.issuedBefore, nil
+}
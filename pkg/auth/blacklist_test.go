@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryTokenBlacklist(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	now := base
+	bl := NewMemoryTokenBlacklist()
+	bl.now = func() time.Time { return now }
+
+	if err := bl.Revoke(ctx, "short", base.Add(time.Minute)); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if err := bl.Revoke(ctx, "long", base.Add(time.Hour)); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if err := bl.Revoke(ctx, "expired", base.Add(-time.Second)); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+
+	for jti, want := range map[string]bool{"short": true, "long": true, "expired": false, "unknown": false} {
+		if got, err := bl.IsRevoked(ctx, jti); err != nil || got != want {
+			t.Errorf("IsRevoked(%q) = %v, %v, want %v", jti, got, err, want)
+		}
+	}
+
+	now = base.Add(time.Minute)
+	if revoked, _ := bl.IsRevoked(ctx, "short"); revoked {
+		t.Error("entry should expire with its token")
+	}
+	if revoked, _ := bl.IsRevoked(ctx, "long"); !revoked {
+		t.Error("entry for an unexpired token should remain")
+	}
+
+	now = base.Add(2 * time.Hour)
+	if err := bl.Revoke(ctx, "later", now.Add(time.Hour)); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if len(bl.entries) != 1 {
+		t.Errorf("blacklist holds %d entries, want expired ones dropped", len(bl.entries))
+	}
+}
+
+func TestMemoryTokenBlacklist_RevokeUser(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	now := base
+	bl := NewMemoryTokenBlacklist()
+	bl.now = func() time.Time { return now }
+
+	if cutoff, err := bl.RevokedBefore(ctx, "user-1"); err != nil || !cutoff.IsZero() {
+		t.Fatalf("RevokedBefore() = %v, %v, want zero time", cutoff, err)
+	}
+	if err := bl.RevokeUser(ctx, "user-1", base, base.Add(time.Hour)); err != nil {
+		t.Fatalf("RevokeUser() error = %v", err)
+	}
+	if cutoff, err := bl.RevokedBefore(ctx, "user-1"); err != nil || !cutoff.Equal(base) {
+		t.Fatalf("RevokedBefore() = %v, %v, want %v", cutoff, err, base)
+	}
+	if cutoff, _ := bl.RevokedBefore(ctx, "user-2"); !cutoff.IsZero() {
+		t.Errorf("RevokedBefore() for another user = %v, want zero time", cutoff)
+	}
+
+	now = base.Add(time.Hour)
+	if cutoff, _ := bl.RevokedBefore(ctx, "user-1"); !cutoff.IsZero() {
+		t.Errorf("cutoff should be dropped once revoked tokens have expired, got %v", cutoff)
+	}
+	if len(bl.users) != 0 {
+		t.Errorf("blacklist holds %d user cutoffs, want 0", len(bl.users))
+	}
+}
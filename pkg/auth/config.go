@@ -12,7 +12,18 @@ import (
 type Config struct {
 	JWTSecret             string        `json:"jwt_secret"`
 	JWTExpirationDuration time.Duration `json:"jwt_expiration_duration"`
+	// JWTRememberMeDuration is the token lifetime for logins with RememberMe set.
+	// Zero uses JWTExpirationDuration.
+	JWTRememberMeDuration time.Duration `json:"jwt_remember_me_duration"`
 	JWTIssuer             string        `json:"jwt_issuer"`
+	// JWTAudience is written to the aud claim of issued tokens. When set, Validate rejects tokens
+	// whose aud claim contains none of these values.
+	JWTAudience []string `json:"jwt_audience"`
+	// JWTAlgorithm selects HS256 (JWTSecret), RS256, or ES256 (PEM keys). A service holding only
+	// JWTPublicKeyPEM can verify tokens but not issue them.
+	JWTAlgorithm     string `json:"jwt_algorithm"`
+	JWTPrivateKeyPEM string `json:"-"`
+	JWTPublicKeyPEM  string `json:"jwt_public_key_pem"`
 
 	PasswordMinLength      int  `json:"password_min_length"`
 	PasswordRequireUpper   bool `json:"password_require_upper"`
@@ -21,8 +32,21 @@ type Config struct {
 	PasswordRequireSpecial bool `json:"password_require_special"`
 	BcryptCost             int  `json:"bcrypt_cost"`
 
+	// PasswordPolicy, when set, runs after the rules above, e.g. a StrengthPolicy rejecting common passwords.
+	PasswordPolicy PasswordPolicy `json:"-"`
+
+	// PasswordHashAlgorithm selects the Hasher used for new hashes ("bcrypt" or "argon2id").
+	PasswordHashAlgorithm string `json:"password_hash_algorithm"`
+
+	// PasswordHistorySize is how many previous passwords, besides the current one, a password change or
+	// reset may not reuse. It needs Repositories.PasswordHistory; zero disables the check.
+	PasswordHistorySize int `json:"password_history_size"`
+
 	MaxFailedAttempts int           `json:"max_failed_attempts"`
 	LockoutDuration   time.Duration `json:"lockout_duration"`
+	// LockoutMultiplier scales LockoutDuration for each previous lockout; values <= 1 keep it fixed.
+	LockoutMultiplier  float64       `json:"lockout_multiplier"`
+	LockoutMaxDuration time.Duration `json:"lockout_max_duration"`
 
 	RateLimitWindow      time.Duration `json:"rate_limit_window"`
 	RateLimitMaxRequests int           `json:"rate_limit_max_requests"`
@@ -30,7 +54,32 @@ type Config struct {
 	ResetTokenLength     int           `json:"reset_token_length"`
 	ResetTokenExpiration time.Duration `json:"reset_token_expiration"`
 
+	// VerificationExpiration defaults to 24 hours when zero.
+	VerificationExpiration time.Duration `json:"verification_expiration"`
+	RequireVerifiedEmail   bool          `json:"require_verified_email"`
+
+	// RefreshTokenLength and RefreshTokenExpiration default to 64 and 30 days when zero.
+	RefreshTokenLength     int           `json:"refresh_token_length"`
+	RefreshTokenExpiration time.Duration `json:"refresh_token_expiration"`
+
 	DefaultLanguage string `json:"default_language"`
+
+	// ClaimsEnricher, when set, adds custom claims to every issued JWT.
+	ClaimsEnricher ClaimsEnricher `json:"-"`
+
+	// AuditSink, when set, receives every audit event alongside Repositories.AuditLogs.
+	AuditSink AuditSink `json:"-"`
+
+	// RateLimiterStore, when set, replaces the in-process rate limit counters, for example with a
+	// RedisRateLimiterStore shared by every replica.
+	RateLimiterStore RateLimiterStore `json:"-"`
+
+	// TokenBlacklist, when set, replaces the in-process list of JWTs revoked by Logout and password
+	// changes, for example with one backed by a store shared by every replica.
+	TokenBlacklist TokenBlacklist `json:"-"`
+
+	// OAuthProviders maps provider names accepted by LoginWithOAuth to their implementations.
+	OAuthProviders map[string]OAuthProvider `json:"-"`
 }
 
 // LoadConfig reads configuration from environment variables and validates it.
@@ -49,18 +98,25 @@ func defaultConfig() *Config {
 	return &Config{
 		JWTExpirationDuration:  24 * time.Hour,
 		JWTIssuer:              "rompi-auth",
+		JWTAlgorithm:           JWTAlgorithmHS256,
 		PasswordMinLength:      8,
 		PasswordRequireUpper:   true,
 		PasswordRequireLower:   true,
 		PasswordRequireNumber:  true,
 		PasswordRequireSpecial: true,
 		BcryptCost:             12,
+		PasswordHashAlgorithm:  HashAlgorithmBcrypt,
 		MaxFailedAttempts:      5,
 		LockoutDuration:        15 * time.Minute,
+		LockoutMultiplier:      2,
+		LockoutMaxDuration:     24 * time.Hour,
 		RateLimitWindow:        time.Minute,
 		RateLimitMaxRequests:   5,
 		ResetTokenLength:       32,
 		ResetTokenExpiration:   time.Hour,
+		VerificationExpiration: 24 * time.Hour,
+		RefreshTokenLength:     64,
+		RefreshTokenExpiration: 30 * 24 * time.Hour,
 		DefaultLanguage:        "en",
 	}
 }
@@ -72,11 +128,33 @@ func (c *Config) overrideFromEnv() error {
 	if v := strings.TrimSpace(os.Getenv("AUTH_JWT_ISSUER")); v != "" {
 		c.JWTIssuer = v
 	}
+	if v := strings.TrimSpace(os.Getenv("AUTH_JWT_AUDIENCE")); v != "" {
+		c.JWTAudience = nil
+		for _, aud := range strings.Split(v, ",") {
+			if aud = strings.TrimSpace(aud); aud != "" {
+				c.JWTAudience = append(c.JWTAudience, aud)
+			}
+		}
+	}
+	if v := strings.TrimSpace(os.Getenv("AUTH_JWT_ALGORITHM")); v != "" {
+		c.JWTAlgorithm = v
+	}
+	if v := strings.TrimSpace(os.Getenv("AUTH_JWT_PRIVATE_KEY")); v != "" {
+		c.JWTPrivateKeyPEM = v
+	}
+	if v := strings.TrimSpace(os.Getenv("AUTH_JWT_PUBLIC_KEY")); v != "" {
+		c.JWTPublicKeyPEM = v
+	}
 	if d, err := parseDurationEnv("AUTH_JWT_EXPIRATION"); err != nil {
 		return err
 	} else if d != nil {
 		c.JWTExpirationDuration = *d
 	}
+	if d, err := parseDurationEnv("AUTH_JWT_REMEMBER_ME_DURATION"); err != nil {
+		return err
+	} else if d != nil {
+		c.JWTRememberMeDuration = *d
+	}
 	if ints, err := parseIntEnv("AUTH_PASSWORD_MIN_LENGTH"); err != nil {
 		return err
 	} else if ints != nil {
@@ -107,6 +185,14 @@ func (c *Config) overrideFromEnv() error {
 	} else if ints != nil {
 		c.BcryptCost = *ints
 	}
+	if v := strings.TrimSpace(os.Getenv("AUTH_PASSWORD_HASH_ALGORITHM")); v != "" {
+		c.PasswordHashAlgorithm = v
+	}
+	if ints, err := parseIntEnv("AUTH_PASSWORD_HISTORY_SIZE"); err != nil {
+		return err
+	} else if ints != nil {
+		c.PasswordHistorySize = *ints
+	}
 	if ints, err := parseIntEnv("AUTH_MAX_FAILED_ATTEMPTS"); err != nil {
 		return err
 	} else if ints != nil {
@@ -117,6 +203,16 @@ func (c *Config) overrideFromEnv() error {
 	} else if d != nil {
 		c.LockoutDuration = *d
 	}
+	if f, err := parseFloatEnv("AUTH_LOCKOUT_MULTIPLIER"); err != nil {
+		return err
+	} else if f != nil {
+		c.LockoutMultiplier = *f
+	}
+	if d, err := parseDurationEnv("AUTH_LOCKOUT_MAX_DURATION"); err != nil {
+		return err
+	} else if d != nil {
+		c.LockoutMaxDuration = *d
+	}
 	if d, err := parseDurationEnv("AUTH_RATE_LIMIT_WINDOW"); err != nil {
 		return err
 	} else if d != nil {
@@ -137,6 +233,26 @@ func (c *Config) overrideFromEnv() error {
 	} else if d != nil {
 		c.ResetTokenExpiration = *d
 	}
+	if d, err := parseDurationEnv("AUTH_EMAIL_VERIFICATION_EXPIRATION"); err != nil {
+		return err
+	} else if d != nil {
+		c.VerificationExpiration = *d
+	}
+	if b, err := parseBoolEnv("AUTH_REQUIRE_VERIFIED_EMAIL"); err != nil {
+		return err
+	} else if b != nil {
+		c.RequireVerifiedEmail = *b
+	}
+	if ints, err := parseIntEnv("AUTH_REFRESH_TOKEN_LENGTH"); err != nil {
+		return err
+	} else if ints != nil {
+		c.RefreshTokenLength = *ints
+	}
+	if d, err := parseDurationEnv("AUTH_REFRESH_TOKEN_EXPIRATION"); err != nil {
+		return err
+	} else if d != nil {
+		c.RefreshTokenExpiration = *d
+	}
 	if v := strings.TrimSpace(os.Getenv("AUTH_DEFAULT_LANGUAGE")); v != "" {
 		c.DefaultLanguage = v
 	}
@@ -165,6 +281,17 @@ func parseIntEnv(key string) (*int, error) {
 	return nil, nil
 }
 
+func parseFloatEnv(key string) (*float64, error) {
+	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		return &parsed, nil
+	}
+	return nil, nil
+}
+
 func parseDurationEnv(key string) (*time.Duration, error) {
 	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
 		parsed, err := time.ParseDuration(v)
@@ -178,24 +305,53 @@ func parseDurationEnv(key string) (*time.Duration, error) {
 
 // Validate ensures the configuration contains valid and secure values.
 func (c *Config) Validate() error {
-	if strings.TrimSpace(c.JWTSecret) == "" {
-		return fmt.Errorf("AUTH_JWT_SECRET is required")
+	switch c.jwtAlgorithm() {
+	case JWTAlgorithmHS256:
+		if strings.TrimSpace(c.JWTSecret) == "" {
+			return fmt.Errorf("AUTH_JWT_SECRET is required")
+		}
+	case JWTAlgorithmRS256, JWTAlgorithmES256:
+		if strings.TrimSpace(c.JWTPrivateKeyPEM) == "" && strings.TrimSpace(c.JWTPublicKeyPEM) == "" {
+			return fmt.Errorf("AUTH_JWT_PRIVATE_KEY or AUTH_JWT_PUBLIC_KEY is required for %s", c.jwtAlgorithm())
+		}
+		if _, _, _, err := parseSigningKeys(c); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("AUTH_JWT_ALGORITHM must be one of %s, %s, %s", JWTAlgorithmHS256, JWTAlgorithmRS256, JWTAlgorithmES256)
 	}
 	if c.JWTExpirationDuration <= 0 {
 		return fmt.Errorf("AUTH_JWT_EXPIRATION must be positive")
 	}
+	if c.JWTRememberMeDuration != 0 && c.JWTRememberMeDuration < c.JWTExpirationDuration {
+		return fmt.Errorf("AUTH_JWT_REMEMBER_ME_DURATION must be at least AUTH_JWT_EXPIRATION")
+	}
 	if c.PasswordMinLength < 8 {
 		return fmt.Errorf("AUTH_PASSWORD_MIN_LENGTH must be at least 8")
 	}
 	if c.BcryptCost < 4 || c.BcryptCost > 31 {
 		return fmt.Errorf("AUTH_BCRYPT_COST must be between 4 and 31")
 	}
+	switch strings.ToLower(strings.TrimSpace(c.PasswordHashAlgorithm)) {
+	case "", HashAlgorithmBcrypt, HashAlgorithmArgon2id:
+	default:
+		return fmt.Errorf("AUTH_PASSWORD_HASH_ALGORITHM must be %q or %q", HashAlgorithmBcrypt, HashAlgorithmArgon2id)
+	}
+	if c.PasswordHistorySize < 0 {
+		return fmt.Errorf("AUTH_PASSWORD_HISTORY_SIZE must not be negative")
+	}
 	if c.MaxFailedAttempts < 1 {
 		return fmt.Errorf("AUTH_MAX_FAILED_ATTEMPTS must be at least 1")
 	}
 	if c.LockoutDuration < time.Minute {
 		return fmt.Errorf("AUTH_LOCKOUT_DURATION must be at least 1m")
 	}
+	if c.LockoutMultiplier < 0 {
+		return fmt.Errorf("AUTH_LOCKOUT_MULTIPLIER must not be negative")
+	}
+	if c.LockoutMaxDuration != 0 && c.LockoutMaxDuration < c.LockoutDuration {
+		return fmt.Errorf("AUTH_LOCKOUT_MAX_DURATION must be at least AUTH_LOCKOUT_DURATION")
+	}
 	if c.RateLimitWindow < time.Second {
 		return fmt.Errorf("AUTH_RATE_LIMIT_WINDOW must be at least 1s")
 	}
@@ -208,8 +364,70 @@ func (c *Config) Validate() error {
 	if c.ResetTokenExpiration < time.Minute {
 		return fmt.Errorf("AUTH_RESET_TOKEN_EXPIRATION must be at least 1m")
 	}
+	if c.VerificationExpiration != 0 && c.VerificationExpiration < time.Minute {
+		return fmt.Errorf("AUTH_EMAIL_VERIFICATION_EXPIRATION must be at least 1m")
+	}
+	if c.RefreshTokenLength != 0 && c.RefreshTokenLength < 32 {
+		return fmt.Errorf("AUTH_REFRESH_TOKEN_LENGTH must be at least 32")
+	}
+	if c.RefreshTokenExpiration != 0 && c.RefreshTokenExpiration < time.Minute {
+		return fmt.Errorf("AUTH_REFRESH_TOKEN_EXPIRATION must be at least 1m")
+	}
 	if strings.TrimSpace(c.DefaultLanguage) == "" {
 		return fmt.Errorf("AUTH_DEFAULT_LANGUAGE is required")
 	}
 	return nil
 }
+
+// lockoutDurationFor returns the lockout duration for an account that has already been locked
+// previousLockouts times: LockoutDuration * LockoutMultiplier^previousLockouts, capped at LockoutMaxDuration.
+func (c *Config) lockoutDurationFor(previousLockouts int) time.Duration {
+	duration := c.LockoutDuration
+	if c.LockoutMultiplier <= 1 {
+		return duration
+	}
+	for i := 0; i < previousLockouts; i++ {
+		next := time.Duration(float64(duration) * c.LockoutMultiplier)
+		if next < duration {
+			break
+		}
+		duration = next
+		if c.LockoutMaxDuration > 0 && duration >= c.LockoutMaxDuration {
+			return c.LockoutMaxDuration
+		}
+	}
+	return duration
+}
+
+// verificationExpiration returns VerificationExpiration, defaulting to 24 hours.
+func (c *Config) verificationExpiration() time.Duration {
+	if c.VerificationExpiration == 0 {
+		return 24 * time.Hour
+	}
+	return c.VerificationExpiration
+}
+
+// refreshTokenLength returns RefreshTokenLength, defaulting to 64.
+func (c *Config) refreshTokenLength() int {
+	if c.RefreshTokenLength == 0 {
+		return 64
+	}
+	return c.RefreshTokenLength
+}
+
+// refreshTokenExpiration returns RefreshTokenExpiration, defaulting to 30 days.
+func (c *Config) refreshTokenExpiration() time.Duration {
+	if c.RefreshTokenExpiration == 0 {
+		return 30 * 24 * time.Hour
+	}
+	return c.RefreshTokenExpiration
+}
+
+// jwtAlgorithm returns the normalized JWT algorithm, defaulting to HS256.
+func (c *Config) jwtAlgorithm() string {
+	alg := strings.ToUpper(strings.TrimSpace(c.JWTAlgorithm))
+	if alg == "" {
+		return JWTAlgorithmHS256
+	}
+	return alg
+}
@@ -48,6 +48,83 @@ func TestConfigValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "asymmetric algorithm without keys",
+			mutator: func(c *Config) {
+				c.JWTAlgorithm = JWTAlgorithmRS256
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported algorithm",
+			mutator: func(c *Config) {
+				c.JWTSecret = "secret"
+				c.JWTAlgorithm = "none"
+			},
+			wantErr: true,
+		},
+		{
+			name: "short refresh token expiration",
+			mutator: func(c *Config) {
+				c.JWTSecret = "secret"
+				c.RefreshTokenExpiration = time.Second
+			},
+			wantErr: true,
+		},
+		{
+			name: "unset refresh token settings use defaults",
+			mutator: func(c *Config) {
+				c.JWTSecret = "secret"
+				c.RefreshTokenLength = 0
+				c.RefreshTokenExpiration = 0
+			},
+		},
+		{
+			name: "unset verification expiration uses default",
+			mutator: func(c *Config) {
+				c.JWTSecret = "secret"
+				c.VerificationExpiration = 0
+			},
+		},
+		{
+			name: "short verification expiration",
+			mutator: func(c *Config) {
+				c.JWTSecret = "secret"
+				c.VerificationExpiration = time.Second
+			},
+			wantErr: true,
+		},
+		{
+			name: "short refresh token length",
+			mutator: func(c *Config) {
+				c.JWTSecret = "secret"
+				c.RefreshTokenLength = 16
+			},
+			wantErr: true,
+		},
+		{
+			name: "remember-me duration shorter than expiration",
+			mutator: func(c *Config) {
+				c.JWTSecret = "secret"
+				c.JWTRememberMeDuration = time.Minute
+			},
+			wantErr: true,
+		},
+		{
+			name: "long expiration without remember-me duration",
+			mutator: func(c *Config) {
+				c.JWTSecret = "secret"
+				c.JWTExpirationDuration = 60 * 24 * time.Hour
+			},
+		},
+		{
+			name: "negative password history size",
+			mutator: func(c *Config) {
+				c.JWTSecret = "secret"
+				c.PasswordHistorySize = -1
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -63,3 +140,22 @@ func TestConfigValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigLockoutDurationFor(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.LockoutDuration = time.Minute
+	cfg.LockoutMultiplier = 3
+	cfg.LockoutMaxDuration = 30 * time.Minute
+
+	want := []time.Duration{time.Minute, 3 * time.Minute, 9 * time.Minute, 27 * time.Minute, 30 * time.Minute, 30 * time.Minute}
+	for previous, expected := range want {
+		if got := cfg.lockoutDurationFor(previous); got != expected {
+			t.Fatalf("lockoutDurationFor(%d) = %v, want %v", previous, got, expected)
+		}
+	}
+
+	cfg.LockoutMultiplier = 0
+	if got := cfg.lockoutDurationFor(4); got != time.Minute {
+		t.Fatalf("fixed lockout = %v, want %v", got, time.Minute)
+	}
+}
@@ -13,10 +13,14 @@ const (
 	CodeAccountLocked      = "account_locked"
 	CodeInvalidToken       = "invalid_token"
 	CodeWeakPassword       = "weak_password"
+	CodePasswordReused     = "password_reused"
 	CodeRateLimitExceeded  = "rate_limit_exceeded"
 	CodePermissionDenied   = "permission_denied"
 	CodeSessionExpired     = "session_expired"
+	CodeTokenRevoked       = "token_revoked"
 	CodeInvalidResetToken  = "invalid_reset_token"
+	CodeEmailNotVerified   = "email_not_verified"
+	CodeInvalidEmailToken  = "invalid_email_token"
 )
 
 var (
@@ -26,11 +30,20 @@ var (
 	ErrAccountLocked      = errors.New("account is locked due to too many failed attempts")
 	ErrInvalidToken       = errors.New("invalid or expired token")
 	ErrWeakPassword       = errors.New("password does not meet complexity requirements")
+	ErrPasswordReused     = errors.New("password was used recently")
 	ErrRateLimitExceeded  = errors.New("rate limit exceeded")
 	ErrPermissionDenied   = errors.New("permission denied")
 	ErrSessionExpired     = errors.New("session has expired")
+	ErrTokenRevoked       = errors.New("token has been revoked")
 	ErrInvalidResetToken  = errors.New("invalid or expired reset token")
+	ErrRefreshTokenReused = errors.New("refresh token reuse detected")
+	ErrEmailNotVerified   = errors.New("email address has not been verified")
+	ErrInvalidEmailToken  = errors.New("invalid or expired verification token")
 	ErrNotImplemented     = errors.New("feature not implemented")
+
+	ErrOAuthProviderNotFound = errors.New("oauth provider not configured")
+	ErrOAuthAccountNotFound  = errors.New("oauth account not found")
+	ErrInvalidOAuthIdentity  = errors.New("invalid oauth identity")
 )
 
 // AuthError contains structured details for API error responses.
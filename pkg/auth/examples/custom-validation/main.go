@@ -67,5 +67,7 @@ func (noopUserRepo) Update(ctx context.Context, user *auth.User) error
 func (noopUserRepo) Delete(ctx context.Context, id string) error                      { return nil }
 func (noopUserRepo) IncrementFailedAttempts(ctx context.Context, userID string) error { return nil }
 func (noopUserRepo) ResetFailedAttempts(ctx context.Context, userID string) error     { return nil }
-func (noopUserRepo) LockAccount(ctx context.Context, userID string) error             { return nil }
-func (noopUserRepo) UnlockAccount(ctx context.Context, userID string) error           { return nil }
+func (noopUserRepo) LockAccount(ctx context.Context, userID string, until time.Time) error {
+	return nil
+}
+func (noopUserRepo) UnlockAccount(ctx context.Context, userID string) error { return nil }
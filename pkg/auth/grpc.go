@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor validates the JWT bearer token in the "authorization" metadata and injects
+// the user into the call context, so handlers use UserFromContext exactly as behind Middleware.
+// grpc-gateway forwards the HTTP Authorization header under that key, so one token serves both transports.
+func (s *service) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := s.authenticateGRPC(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor.
+func (s *service) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := s.authenticateGRPC(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticateGRPC returns ctx with the authenticated user, or an Unauthenticated status error.
+func (s *service) authenticateGRPC(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 || strings.TrimSpace(values[0]) == "" {
+		return nil, status.Error(codes.Unauthenticated, "authorization metadata is required")
+	}
+	parts := strings.Fields(values[0])
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization metadata")
+	}
+	ctx = grpcRequestInfoContext(ctx, md)
+	user, err := s.ValidateToken(ctx, parts[1])
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	return context.WithValue(ctx, userContextKey, user), nil
+}
+
+// grpcRequestInfoContext is the gRPC counterpart of requestInfoContext, using the peer address and
+// the user-agent metadata.
+func grpcRequestInfoContext(ctx context.Context, md metadata.MD) context.Context {
+	if ip, userAgent := RequestInfoFromContext(ctx); ip != "" || userAgent != "" {
+		return ctx
+	}
+	var ip string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+	var userAgent string
+	if values := md.Get("user-agent"); len(values) > 0 {
+		userAgent = values[0]
+	}
+	return WithRequestInfo(ctx, ip, userAgent)
+}
+
+// authenticatedStream overrides the context of a server stream.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
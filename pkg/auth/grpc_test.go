@@ -0,0 +1,102 @@
+package auth_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/rompi/core-backend/pkg/auth"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	svc, manager, user := buildMiddlewareService(t, nil)
+	token, _, err := manager.Generate(user)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	interceptor := svc.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/users.v1.UserService/GetUser"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		ctxUser := auth.UserFromContext(ctx)
+		if ctxUser == nil {
+			return nil, status.Error(codes.Internal, "missing user")
+		}
+		if ip, userAgent := auth.RequestInfoFromContext(ctx); ip != "10.0.0.7" || userAgent != "grpc-go/test" {
+			return nil, status.Errorf(codes.Internal, "request info = %q, %q", ip, userAgent)
+		}
+		return ctxUser.Email, nil
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantCode      codes.Code
+	}{
+		{name: "valid token", authorization: "Bearer " + token, wantCode: codes.OK},
+		{name: "missing token", wantCode: codes.Unauthenticated},
+		{name: "wrong scheme", authorization: "Basic " + token, wantCode: codes.Unauthenticated},
+		{name: "invalid token", authorization: "Bearer not-a-jwt", wantCode: codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := metadata.Pairs("user-agent", "grpc-go/test")
+			if tt.authorization != "" {
+				md.Set("authorization", tt.authorization)
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), md)
+			ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.7"), Port: 51234}})
+
+			resp, err := interceptor(ctx, nil, info, handler)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v, want %v (err = %v)", code, tt.wantCode, err)
+			}
+			if tt.wantCode == codes.OK && resp != user.Email {
+				t.Errorf("response = %v, want %q", resp, user.Email)
+			}
+		})
+	}
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context { return s.ctx }
+
+func TestStreamServerInterceptor(t *testing.T) {
+	svc, manager, user := buildMiddlewareService(t, nil)
+	token, _, err := manager.Generate(user)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	interceptor := svc.StreamServerInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: "/users.v1.UserService/WatchUsers"}
+	var gotUser *auth.User
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		gotUser = auth.UserFromContext(ss.Context())
+		return nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	if err := interceptor(nil, &fakeServerStream{ctx: ctx}, info, handler); err != nil {
+		t.Fatalf("interceptor() error = %v", err)
+	}
+	if gotUser == nil || gotUser.ID != user.ID {
+		t.Errorf("stream user = %+v, want %s", gotUser, user.ID)
+	}
+
+	err = interceptor(nil, &fakeServerStream{ctx: context.Background()}, info, handler)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("code without token = %v, want Unauthenticated", status.Code(err))
+	}
+}
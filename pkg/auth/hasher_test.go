@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestHashers_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		hasher Hasher
+	}{
+		{name: "bcrypt", hasher: &BcryptHasher{Cost: 4}},
+		{name: "argon2id", hasher: &Argon2idHasher{Time: 1, Memory: 1024, Threads: 1, KeyLength: 32, SaltLength: 16}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := tt.hasher.Hash("Str0ng!Pass")
+			if err != nil {
+				t.Fatalf("Hash() error = %v", err)
+			}
+			if err := tt.hasher.Compare(hash, "Str0ng!Pass"); err != nil {
+				t.Fatalf("Compare() error = %v", err)
+			}
+			if err := tt.hasher.Compare(hash, "Wr0ng!Pass"); err == nil {
+				t.Fatal("expected mismatch error")
+			}
+			if tt.hasher.NeedsRehash(hash) {
+				t.Fatal("fresh hash should not need rehash")
+			}
+		})
+	}
+}
+
+func TestHashers_NeedsRehash(t *testing.T) {
+	bcryptHash, err := HashPassword("Str0ng!Pass", 4)
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+	weak := &Argon2idHasher{Time: 1, Memory: 1024, Threads: 1, KeyLength: 32, SaltLength: 16}
+	argonHash, err := weak.Hash("Str0ng!Pass")
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	if !strings.HasPrefix(argonHash, "$argon2id$v=19$m=1024,t=1,p=1$") {
+		t.Fatalf("unexpected argon2id encoding %s", argonHash)
+	}
+
+	if !(&BcryptHasher{Cost: 5}).NeedsRehash(bcryptHash) {
+		t.Fatal("expected bcrypt cost change to need rehash")
+	}
+	if !(&BcryptHasher{Cost: 4}).NeedsRehash(argonHash) {
+		t.Fatal("expected argon2id hash to need rehash under bcrypt")
+	}
+	if !NewArgon2idHasher().NeedsRehash(bcryptHash) {
+		t.Fatal("expected bcrypt hash to need rehash under argon2id")
+	}
+	if !NewArgon2idHasher().NeedsRehash(argonHash) {
+		t.Fatal("expected weaker argon2id parameters to need rehash")
+	}
+	if err := NewArgon2idHasher().Compare(bcryptHash, "Str0ng!Pass"); err != nil {
+		t.Fatalf("argon2id hasher should verify legacy bcrypt hash: %v", err)
+	}
+}
+
+func TestComparePassword_RejectsInvalidArgon2idParameters(t *testing.T) {
+	const saltAndKey = "$c2FsdHNhbHRzYWx0c2FsdA$a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2U"
+	tests := []struct {
+		name   string
+		params string
+	}{
+		{name: "zero memory", params: "m=0,t=1,p=1"},
+		{name: "zero time", params: "m=1024,t=0,p=1"},
+		{name: "zero threads", params: "m=1024,t=1,p=0"},
+		{name: "memory below threads", params: "m=8,t=1,p=4"},
+		{name: "memory too large", params: "m=4294967295,t=1,p=1"},
+		{name: "time too large", params: "m=1024,t=4294967295,p=1"},
+		{name: "threads overflow", params: "m=1024,t=1,p=256"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash := "$argon2id$v=19$" + tt.params + saltAndKey
+			if err := ComparePassword(hash, "Str0ng!Pass"); !errors.Is(err, errInvalidArgon2idHash) {
+				t.Fatalf("ComparePassword() error = %v, want errInvalidArgon2idHash", err)
+			}
+			if !NewArgon2idHasher().NeedsRehash(hash) {
+				t.Fatal("expected invalid hash to need rehash")
+			}
+		})
+	}
+}
+
+func TestNewHasher(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.PasswordHashAlgorithm = HashAlgorithmArgon2id
+	if h, err := NewHasher(cfg); err != nil {
+		t.Fatalf("NewHasher() error = %v", err)
+	} else if _, ok := h.(*Argon2idHasher); !ok {
+		t.Fatalf("expected *Argon2idHasher, got %T", h)
+	}
+
+	cfg.PasswordHashAlgorithm = "md5"
+	if _, err := NewHasher(cfg); err == nil {
+		t.Fatal("expected unsupported algorithm error")
+	}
+}
@@ -16,10 +16,14 @@ var englishMessages = map[string]string{
 	"account_locked":      "Account is locked due to too many failed attempts",
 	"invalid_token":       "Token is invalid or expired",
 	"weak_password":       "Password does not meet complexity requirements",
+	"password_reused":     "Choose a password you have not used recently",
 	"rate_limit_exceeded": "Too many requests, please try again later",
 	"permission_denied":   "You do not have permission to perform this action",
 	"session_expired":     "Session has expired",
+	"token_revoked":       "Token has been revoked, please sign in again",
 	"invalid_reset_token": "Reset token is invalid or expired",
+	"email_not_verified":  "Please verify your email address before signing in",
+	"invalid_email_token": "Verification token is invalid or expired",
 }
 
 // DefaultTranslator is the shared translator used by auth errors and handlers.
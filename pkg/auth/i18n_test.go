@@ -20,6 +20,20 @@ func TestTranslator_Message(t *testing.T) {
 	}
 }
 
+func TestEnglishMessagesCoverCodes(t *testing.T) {
+	codes := []string{
+		CodeInvalidCredentials, CodeUserAlreadyExists, CodeUserNotFound, CodeAccountLocked,
+		CodeInvalidToken, CodeWeakPassword, CodePasswordReused, CodeRateLimitExceeded,
+		CodePermissionDenied, CodeSessionExpired, CodeTokenRevoked, CodeInvalidResetToken,
+		CodeEmailNotVerified, CodeInvalidEmailToken,
+	}
+	for _, code := range codes {
+		if englishMessages[code] == "" {
+			t.Errorf("no English message for %q", code)
+		}
+	}
+}
+
 func TestTranslator_LoadFromFile(t *testing.T) {
 	translator := NewTranslator("en")
 	dir, err := os.MkdirTemp(".", "translations_test_")
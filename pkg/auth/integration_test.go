@@ -89,6 +89,7 @@ func createTables(db *sql.DB) error {
             updated_at INTEGER,
             failed_attempts INTEGER,
             locked_until INTEGER,
+            lockout_count INTEGER DEFAULT 0,
             language TEXT,
             metadata TEXT
         )`,
@@ -147,18 +148,18 @@ func (r *sqliteUserRepo) Create(ctx context.Context, user *auth.User) error {
 	if err != nil {
 		return err
 	}
-	_, err = r.db.Exec(`INSERT INTO users (id, email, password_hash, created_at, updated_at, failed_attempts, locked_until, language, metadata) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
-		user.ID, user.Email, user.PasswordHash, user.CreatedAt.UnixNano(), user.UpdatedAt.UnixNano(), user.FailedAttempts, user.LockedUntil.UnixNano(), user.Language, meta)
+	_, err = r.db.Exec(`INSERT INTO users (id, email, password_hash, created_at, updated_at, failed_attempts, locked_until, lockout_count, language, metadata) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		user.ID, user.Email, user.PasswordHash, user.CreatedAt.UnixNano(), user.UpdatedAt.UnixNano(), user.FailedAttempts, user.LockedUntil.UnixNano(), user.LockoutCount, user.Language, meta)
 	return err
 }
 
 func (r *sqliteUserRepo) GetByID(ctx context.Context, id string) (*auth.User, error) {
-	row := r.db.QueryRow(`SELECT id, email, password_hash, created_at, updated_at, failed_attempts, locked_until, language, metadata FROM users WHERE id = ?`, id)
+	row := r.db.QueryRow(`SELECT id, email, password_hash, created_at, updated_at, failed_attempts, locked_until, lockout_count, language, metadata FROM users WHERE id = ?`, id)
 	return scanUser(row)
 }
 
 func (r *sqliteUserRepo) GetByEmail(ctx context.Context, email string) (*auth.User, error) {
-	row := r.db.QueryRow(`SELECT id, email, password_hash, created_at, updated_at, failed_attempts, locked_until, language, metadata FROM users WHERE email = ?`, email)
+	row := r.db.QueryRow(`SELECT id, email, password_hash, created_at, updated_at, failed_attempts, locked_until, lockout_count, language, metadata FROM users WHERE email = ?`, email)
 	return scanUser(row)
 }
 
@@ -167,8 +168,8 @@ func (r *sqliteUserRepo) Update(ctx context.Context, user *auth.User) error {
 	if err != nil {
 		return err
 	}
-	_, err = r.db.Exec(`UPDATE users SET password_hash=?, updated_at=?, failed_attempts=?, locked_until=?, language=?, metadata=? WHERE id=?`,
-		user.PasswordHash, user.UpdatedAt.UnixNano(), user.FailedAttempts, user.LockedUntil.UnixNano(), user.Language, meta, user.ID)
+	_, err = r.db.Exec(`UPDATE users SET password_hash=?, updated_at=?, failed_attempts=?, locked_until=?, lockout_count=?, language=?, metadata=? WHERE id=?`,
+		user.PasswordHash, user.UpdatedAt.UnixNano(), user.FailedAttempts, user.LockedUntil.UnixNano(), user.LockoutCount, user.Language, meta, user.ID)
 	return err
 }
 
@@ -183,12 +184,12 @@ func (r *sqliteUserRepo) IncrementFailedAttempts(ctx context.Context, userID str
 }
 
 func (r *sqliteUserRepo) ResetFailedAttempts(ctx context.Context, userID string) error {
-	_, err := r.db.Exec(`UPDATE users SET failed_attempts = 0, locked_until = 0 WHERE id = ?`, userID)
+	_, err := r.db.Exec(`UPDATE users SET failed_attempts = 0, locked_until = 0, lockout_count = 0 WHERE id = ?`, userID)
 	return err
 }
 
-func (r *sqliteUserRepo) LockAccount(ctx context.Context, userID string) error {
-	_, err := r.db.Exec(`UPDATE users SET locked_until = ? WHERE id = ?`, time.Now().Add(15*time.Minute).UnixNano(), userID)
+func (r *sqliteUserRepo) LockAccount(ctx context.Context, userID string, until time.Time) error {
+	_, err := r.db.Exec(`UPDATE users SET locked_until = ?, lockout_count = lockout_count + 1, failed_attempts = 0 WHERE id = ?`, until.UnixNano(), userID)
 	return err
 }
 
@@ -205,10 +206,11 @@ func scanUser(scanner interface{ Scan(...interface{}) error }) (*auth.User, erro
 		createdAt, updatedAt int64
 		failedAttempts       int
 		lockedUntil          int64
+		lockoutCount         int
 		language             string
 		metadata             sql.NullString
 	)
-	err := scanner.Scan(&id, &email, &hash, &createdAt, &updatedAt, &failedAttempts, &lockedUntil, &language, &metadata)
+	err := scanner.Scan(&id, &email, &hash, &createdAt, &updatedAt, &failedAttempts, &lockedUntil, &lockoutCount, &language, &metadata)
 	if err == sql.ErrNoRows {
 		return nil, auth.ErrUserNotFound
 	}
@@ -223,6 +225,7 @@ func scanUser(scanner interface{ Scan(...interface{}) error }) (*auth.User, erro
 		UpdatedAt:      time.Unix(0, updatedAt),
 		FailedAttempts: failedAttempts,
 		LockedUntil:    time.Unix(0, lockedUntil),
+		LockoutCount:   lockoutCount,
 		Language:       language,
 		Metadata:       decodeMetadata(metadata),
 	}, nil
@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"strings"
 )
@@ -11,6 +12,9 @@ type contextKey string
 
 const userContextKey contextKey = "auth-user"
 
+// DefaultAPIKeyHeader is the header APIKeyMiddleware reads when no header name is supplied.
+const DefaultAPIKeyHeader = "X-API-Key"
+
 // UserFromContext extracts the authenticated user stored by Middleware.
 func UserFromContext(ctx context.Context) *User {
 	if ctx == nil {
@@ -36,16 +40,50 @@ func (s *service) Middleware() func(http.Handler) http.Handler {
 				http.Error(w, "invalid authorization header", http.StatusUnauthorized)
 				return
 			}
-			user, err := s.ValidateToken(r.Context(), parts[1])
+			ctx := requestInfoContext(r)
+			user, err := s.ValidateToken(ctx, parts[1])
 			if err != nil {
 				http.Error(w, "invalid token", http.StatusUnauthorized)
 				return
 			}
-			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userContextKey, user)))
+			next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, userContextKey, user)))
+		})
+	}
+}
+
+// APIKeyMiddleware validates the API key carried in header and injects the owning user into the
+// request context, so UserFromContext works the same as with Middleware. Missing or invalid keys
+// receive a 401 with a message translated for the request's Accept-Language.
+func (s *service) APIKeyMiddleware(header string) func(http.Handler) http.Handler {
+	if strings.TrimSpace(header) == "" {
+		header = DefaultAPIKeyHeader
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := strings.TrimSpace(r.Header.Get(header))
+			if key == "" {
+				s.writeAuthError(w, r, CodeInvalidToken, http.StatusUnauthorized)
+				return
+			}
+			ctx := requestInfoContext(r)
+			user, err := s.ValidateAPIKey(ctx, key)
+			if err != nil {
+				s.writeAuthError(w, r, CodeInvalidToken, http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, userContextKey, user)))
 		})
 	}
 }
 
+// RequestInfoMiddleware stores the client IP and User-Agent in the request context so audit events
+// emitted by handlers (for example Login) carry them. Middleware and APIKeyMiddleware do this already.
+func RequestInfoMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(requestInfoContext(r)))
+	})
+}
+
 // RequireRole allows requests only for users that have any of the provided roles.
 func (s *service) RequireRole(roles ...string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -70,6 +108,7 @@ func (s *service) RequireRole(roles ...string) func(http.Handler) http.Handler {
 }
 
 // RequirePermission allows requests only if the authenticated user has every permission.
+// Roles are loaded once per request and checked with the same rules as CheckPermission.
 func (s *service) RequirePermission(permissions ...string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -78,14 +117,14 @@ func (s *service) RequirePermission(permissions ...string) func(http.Handler) ht
 				http.Error(w, "unauthorized", http.StatusUnauthorized)
 				return
 			}
+			userRoles, err := s.GetUserRoles(r.Context(), user.ID)
+			if err != nil {
+				http.Error(w, "failed to check permission", http.StatusInternalServerError)
+				return
+			}
 			for _, permission := range permissions {
-				has, err := s.CheckPermission(r.Context(), user.ID, permission)
-				if err != nil {
-					http.Error(w, "failed to check permission", http.StatusInternalServerError)
-					return
-				}
-				if !has {
-					http.Error(w, "forbidden", http.StatusForbidden)
+				if !hasPermission(userRoles, permission) {
+					s.writeAuthError(w, r, CodePermissionDenied, http.StatusForbidden)
 					return
 				}
 			}
@@ -107,7 +146,7 @@ func (s *service) RateLimitMiddleware() func(http.Handler) http.Handler {
 			if key == "" {
 				key = r.RemoteAddr
 			}
-			if err := s.rateLimit(r.Context(), fmt.Sprintf("middleware:%s", key)); err != nil {
+			if err := s.rateLimit(requestInfoContext(r), fmt.Sprintf("middleware:%s", key)); err != nil {
 				http.Error(w, ErrRateLimitExceeded.Error(), http.StatusTooManyRequests)
 				return
 			}
@@ -116,6 +155,49 @@ func (s *service) RateLimitMiddleware() func(http.Handler) http.Handler {
 	}
 }
 
+// requestInfoContext returns r's context with its client IP and User-Agent, keeping values set earlier.
+func requestInfoContext(r *http.Request) context.Context {
+	ctx := r.Context()
+	if ip, userAgent := RequestInfoFromContext(ctx); ip != "" || userAgent != "" {
+		return ctx
+	}
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	return WithRequestInfo(ctx, ip, r.UserAgent())
+}
+
+// writeAuthError responds with the localized message for code.
+func (s *service) writeAuthError(w http.ResponseWriter, r *http.Request, code string, status int) {
+	err := NewAuthError(code, status, s.requestLanguage(r), nil)
+	http.Error(w, err.Message, err.StatusCode)
+}
+
+// requestLanguage returns the primary language from Accept-Language, falling back to the configured default.
+func (s *service) requestLanguage(r *http.Request) string {
+	header := r.Header.Get("Accept-Language")
+	if header == "" {
+		return s.cfg.DefaultLanguage
+	}
+	tag := strings.TrimSpace(strings.SplitN(strings.SplitN(header, ",", 2)[0], ";", 2)[0])
+	if tag == "" || tag == "*" {
+		return s.cfg.DefaultLanguage
+	}
+	return strings.SplitN(tag, "-", 2)[0]
+}
+
+func hasPermission(userRoles []Role, permission string) bool {
+	for _, role := range userRoles {
+		for _, perm := range role.Permissions {
+			if perm == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func hasRole(userRoles []Role, allowed []string) bool {
 	if len(allowed) == 0 {
 		return false
@@ -187,3 +187,148 @@ func TestRateLimitMiddleware(t *testing.T) {
 		t.Fatalf("expected 429, got %d", rr.Code)
 	}
 }
+
+func TestAPIKeyMiddleware(t *testing.T) {
+	svc, _, user := buildMiddlewareService(t, func(cfg *auth.Config, repos *auth.Repositories) {
+		repos.APIKeys = &testutil.MockAPIKeyRepository{
+			GetByKeyFunc: func(ctx context.Context, key string) (*auth.APIKey, error) {
+				if key != "valid-key" {
+					return nil, nil
+				}
+				return &auth.APIKey{Key: key, UserID: "user-1"}, nil
+			},
+		}
+	})
+
+	prev := auth.DefaultTranslator
+	translator := auth.NewTranslator("en")
+	translator.Register("es", map[string]string{auth.CodeInvalidToken: "Token inválido"})
+	auth.DefaultTranslator = translator
+	t.Cleanup(func() { auth.DefaultTranslator = prev })
+
+	handler := svc.APIKeyMiddleware("X-Service-Key")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxUser := auth.UserFromContext(r.Context())
+		if ctxUser == nil {
+			http.Error(w, "missing user", http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(ctxUser.Email))
+	}))
+
+	tests := []struct {
+		name       string
+		key        string
+		language   string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "valid key", key: "valid-key", wantStatus: http.StatusOK, wantBody: user.Email},
+		{name: "invalid key", key: "bogus", wantStatus: http.StatusUnauthorized, wantBody: "Token is invalid or expired"},
+		{name: "missing key", wantStatus: http.StatusUnauthorized, wantBody: "Token is invalid or expired"},
+		{name: "translated error", key: "bogus", language: "es-MX,es;q=0.9", wantStatus: http.StatusUnauthorized, wantBody: "Token inválido"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.key != "" {
+				req.Header.Set("X-Service-Key", tt.key)
+			}
+			if tt.language != "" {
+				req.Header.Set("Accept-Language", tt.language)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rr.Code, tt.wantStatus)
+			}
+			if got := strings.TrimSpace(rr.Body.String()); got != tt.wantBody {
+				t.Fatalf("body = %q, want %q", got, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestRequirePermission_PerUser(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.RateLimitMaxRequests = 100
+
+	users := map[string]*auth.User{
+		"editor": {ID: "editor", Email: "editor@example.com"},
+		"viewer": {ID: "viewer", Email: "viewer@example.com"},
+	}
+	roleLookups := 0
+	svc, err := auth.NewService(cfg, auth.Repositories{
+		Users: &testutil.MockUserRepository{
+			GetByIDFunc: func(ctx context.Context, id string) (*auth.User, error) {
+				return users[id], nil
+			},
+		},
+		Roles: &testutil.MockRoleRepository{
+			GetByUserIDFunc: func(ctx context.Context, id string) ([]auth.Role, error) {
+				roleLookups++
+				if id == "editor" {
+					return []auth.Role{{Name: "editor", Permissions: []string{"articles:read", "articles:write"}}}, nil
+				}
+				return []auth.Role{{Name: "viewer", Permissions: []string{"articles:read"}}}, nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+	manager := auth.NewTokenManager(cfg)
+
+	handler := svc.Middleware()(svc.RequirePermission("articles:read", "articles:write")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})))
+
+	tests := []struct {
+		userID     string
+		wantStatus int
+	}{
+		{userID: "editor", wantStatus: http.StatusOK},
+		{userID: "viewer", wantStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.userID, func(t *testing.T) {
+			token, _, err := manager.Generate(users[tt.userID])
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			roleLookups = 0
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rr.Code, tt.wantStatus)
+			}
+			if roleLookups != 1 {
+				t.Fatalf("expected roles to be loaded once, got %d", roleLookups)
+			}
+		})
+	}
+}
+
+func TestRequestInfoMiddleware(t *testing.T) {
+	var ip, userAgent string
+	handler := auth.RequestInfoMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip, userAgent = auth.RequestInfoFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/login", nil)
+	req.RemoteAddr = "203.0.113.7:51234"
+	req.Header.Set("User-Agent", "client/2.0")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if ip != "203.0.113.7" {
+		t.Fatalf("ip = %q", ip)
+	}
+	if userAgent != "client/2.0" {
+		t.Fatalf("user agent = %q", userAgent)
+	}
+}
@@ -6,11 +6,13 @@ import "time"
 type User struct {
 	ID             string                 `json:"id"`
 	Email          string                 `json:"email"`
+	EmailVerified  bool                   `json:"email_verified"`
 	PasswordHash   string                 `json:"password_hash"`
 	CreatedAt      time.Time              `json:"created_at"`
 	UpdatedAt      time.Time              `json:"updated_at"`
 	FailedAttempts int                    `json:"failed_attempts"`
 	LockedUntil    time.Time              `json:"locked_until"`
+	LockoutCount   int                    `json:"lockout_count"`
 	Language       string                 `json:"language"`
 	Metadata       map[string]interface{} `json:"metadata,omitempty"`
 }
@@ -23,6 +25,8 @@ type Session struct {
 	ExpiresAt time.Time `json:"expires_at"`
 	Revoked   bool      `json:"revoked"`
 	Metadata  string    `json:"metadata"`
+	// RememberMe marks a long-lived session started with LoginRequest.RememberMe.
+	RememberMe bool `json:"remember_me"`
 }
 
 // Role defines permissions granted to a user or a group of users.
@@ -39,6 +43,8 @@ type AuditLog struct {
 	UserID    string                 `json:"user_id"`
 	Action    string                 `json:"action"`
 	Message   string                 `json:"message"`
+	IPAddress string                 `json:"ip_address,omitempty"`
+	UserAgent string                 `json:"user_agent,omitempty"`
 	Metadata  map[string]interface{} `json:"metadata"`
 	CreatedAt time.Time              `json:"created_at"`
 }
@@ -52,6 +58,44 @@ type PasswordResetToken struct {
 	Used      bool      `json:"used"`
 }
 
+// EmailVerificationToken represents a temporary token proving ownership of a user's email address.
+type EmailVerificationToken struct {
+	Token     string    `json:"token"`
+	UserID    string    `json:"user_id"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// RefreshToken represents an opaque, single-use credential exchanged for a new access token.
+// Tokens issued from the same login share a FamilyID so reuse of a rotated token can revoke the chain.
+type RefreshToken struct {
+	Token     string    `json:"token"`
+	UserID    string    `json:"user_id"`
+	FamilyID  string    `json:"family_id"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Used      bool      `json:"used"`
+	Revoked   bool      `json:"revoked"`
+	// RememberMe carries the login's remember-me choice to the sessions issued on refresh.
+	RememberMe bool `json:"remember_me"`
+}
+
+// PasswordHistory records a hash a user's password had before it was changed or reset.
+type PasswordHistory struct {
+	UserID       string    `json:"user_id"`
+	PasswordHash string    `json:"password_hash"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// OAuthAccount links an identity at an external OAuth/OIDC provider to a local user.
+type OAuthAccount struct {
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"subject"`
+	UserID    string    `json:"user_id"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 // APIKey represents a long-lived credential tied to a user with limited scope.
 type APIKey struct {
 	Key         string    `json:"key"`
@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// OAuthIdentity is the external identity returned by an OAuthProvider for an authorization code.
+type OAuthIdentity struct {
+	// Subject is the provider's stable identifier for the user (the OIDC "sub" claim).
+	Subject       string                 `json:"subject"`
+	Email         string                 `json:"email"`
+	EmailVerified bool                   `json:"email_verified"`
+	Name          string                 `json:"name,omitempty"`
+	Claims        map[string]interface{} `json:"claims,omitempty"`
+}
+
+// OAuthProvider exchanges an authorization code obtained from a provider's consent screen for the user's identity.
+type OAuthProvider interface {
+	Exchange(ctx context.Context, code string) (*OAuthIdentity, error)
+}
+
+// OAuthProviderFunc adapts a function to the OAuthProvider interface.
+type OAuthProviderFunc func(ctx context.Context, code string) (*OAuthIdentity, error)
+
+// Exchange calls f(ctx, code).
+func (f OAuthProviderFunc) Exchange(ctx context.Context, code string) (*OAuthIdentity, error) {
+	return f(ctx, code)
+}
+
+func (s *service) LoginWithOAuth(ctx context.Context, provider, code string) (*LoginResponse, error) {
+	p, ok := s.cfg.OAuthProviders[provider]
+	if !ok || p == nil {
+		return nil, fmt.Errorf("%w: %s", ErrOAuthProviderNotFound, provider)
+	}
+	if s.repos.OAuthAccounts == nil {
+		return nil, errors.New("oauth account repository is required")
+	}
+	if strings.TrimSpace(code) == "" {
+		return nil, fmt.Errorf("%w: authorization code is required", ErrInvalidCredentials)
+	}
+
+	identity, err := p.Exchange(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("oauth exchange: %w", err)
+	}
+	if identity == nil || identity.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidOAuthIdentity)
+	}
+
+	user, err := s.oauthUser(ctx, provider, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.LockedUntil.After(s.now()) {
+		return nil, ErrAccountLocked
+	}
+	if s.cfg.RequireVerifiedEmail && !user.EmailVerified {
+		return nil, ErrEmailNotVerified
+	}
+
+	resp, err := s.issueTokens(ctx, user, uuid.NewString(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logEvent(ctx, user.ID, EventLogin, "user logged in", map[string]interface{}{
+		"expires_at": resp.ExpiresAt,
+		"provider":   provider,
+	})
+	return resp, nil
+}
+
+// oauthUser returns the user linked to identity, linking or creating one on first login.
+// An existing account with the same email is only linked when the provider verified that email.
+func (s *service) oauthUser(ctx context.Context, provider string, identity *OAuthIdentity) (*User, error) {
+	account, err := s.repos.OAuthAccounts.GetByProviderSubject(ctx, provider, identity.Subject)
+	if err != nil && !errors.Is(err, ErrOAuthAccountNotFound) {
+		return nil, fmt.Errorf("fetch oauth account: %w", err)
+	}
+	if err == nil && account != nil {
+		user, err := s.repos.Users.GetByID(ctx, account.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("fetch user: %w", err)
+		}
+		return user, nil
+	}
+
+	email := strings.ToLower(strings.TrimSpace(identity.Email))
+	if err := ValidateEmail(email); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOAuthIdentity, err)
+	}
+
+	user, err := s.repos.Users.GetByEmail(ctx, email)
+	switch {
+	case err == nil && user != nil:
+		if !identity.EmailVerified {
+			return nil, ErrUserAlreadyExists
+		}
+	case err == nil || errors.Is(err, ErrUserNotFound):
+		now := s.now().UTC()
+		user = &User{
+			ID:            uuid.NewString(),
+			Email:         email,
+			EmailVerified: identity.EmailVerified,
+			Language:      s.cfg.DefaultLanguage,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		if err := s.repos.Users.Create(ctx, user); err != nil {
+			return nil, fmt.Errorf("create user: %w", err)
+		}
+		s.logEvent(ctx, user.ID, EventRegister, "user registered", map[string]interface{}{
+			"language": user.Language,
+			"provider": provider,
+		})
+	default:
+		return nil, fmt.Errorf("checking user existence: %w", err)
+	}
+
+	account = &OAuthAccount{
+		Provider:  provider,
+		Subject:   identity.Subject,
+		UserID:    user.ID,
+		Email:     email,
+		CreatedAt: s.now().UTC(),
+	}
+	if err := s.repos.OAuthAccounts.Create(ctx, account); err != nil {
+		return nil, fmt.Errorf("create oauth account: %w", err)
+	}
+	s.logEvent(ctx, user.ID, EventOAuthAccountLinked, "oauth account linked", map[string]interface{}{"provider": provider})
+	return user, nil
+}
@@ -0,0 +1,359 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// OIDCConfig configures a generic OpenID Connect provider.
+type OIDCConfig struct {
+	// Issuer is the provider's issuer URL; endpoints are discovered from Issuer/.well-known/openid-configuration.
+	Issuer       string `json:"issuer"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"-"`
+	RedirectURL  string `json:"redirect_url"`
+	// Scopes requested by AuthCodeURL. Defaults to openid, email, and profile.
+	Scopes []string `json:"scopes"`
+	// HTTPClient is used for discovery, token, and key requests. Defaults to a client with a 10s timeout.
+	HTTPClient *http.Client `json:"-"`
+}
+
+// OIDCProvider implements OAuthProvider for any OpenID Connect compliant identity provider. It exchanges the
+// authorization code at the token endpoint and verifies the returned ID token against the provider's JWKS.
+type OIDCProvider struct {
+	cfg    OIDCConfig
+	client *http.Client
+
+	mu       sync.Mutex
+	metadata *oidcMetadata
+	keys     map[string]interface{}
+}
+
+type oidcMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// NewOIDCProvider validates cfg and returns a provider. Discovery happens lazily on first use.
+func NewOIDCProvider(cfg OIDCConfig) (*OIDCProvider, error) {
+	cfg.Issuer = strings.TrimRight(strings.TrimSpace(cfg.Issuer), "/")
+	if cfg.Issuer == "" {
+		return nil, errors.New("oidc issuer is required")
+	}
+	if cfg.ClientID == "" {
+		return nil, errors.New("oidc client id is required")
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "email", "profile"}
+	}
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &OIDCProvider{cfg: cfg, client: client}, nil
+}
+
+// AuthCodeURL returns the URL of the provider's consent screen. state (and nonce, when not empty) are echoed
+// back to RedirectURL; callers must verify state before calling LoginWithOAuth with the returned code, and
+// pass the same nonce via WithOIDCNonce so Exchange can check it.
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, state, nonce string) (string, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	params := url.Values{
+		"response_type": {"code"},
+		"client_id":     {p.cfg.ClientID},
+		"scope":         {strings.Join(p.cfg.Scopes, " ")},
+		"state":         {state},
+	}
+	if p.cfg.RedirectURL != "" {
+		params.Set("redirect_uri", p.cfg.RedirectURL)
+	}
+	if nonce != "" {
+		params.Set("nonce", nonce)
+	}
+	separator := "?"
+	if strings.Contains(metadata.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return metadata.AuthorizationEndpoint + separator + params.Encode(), nil
+}
+
+const oidcNonceContextKey contextKey = "auth-oidc-nonce"
+
+// WithOIDCNonce returns a context carrying the nonce sent with AuthCodeURL, which OIDCProvider.Exchange
+// requires the ID token to echo.
+func WithOIDCNonce(ctx context.Context, nonce string) context.Context {
+	return context.WithValue(ctx, oidcNonceContextKey, nonce)
+}
+
+// Exchange redeems code at the token endpoint and returns the identity from the verified ID token.
+// The ID token's nonce must match the one set with WithOIDCNonce; a token carrying a nonce is rejected
+// when ctx has none. All ID token claims are available in OAuthIdentity.Claims.
+func (p *OIDCProvider) Exchange(ctx context.Context, code string) (*OAuthIdentity, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type": {"authorization_code"},
+		"code":       {code},
+	}
+	if p.cfg.RedirectURL != "" {
+		form.Set("redirect_uri", p.cfg.RedirectURL)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("oidc token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+
+	var token struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	status, err := p.doJSON(req, &token)
+	if err != nil {
+		return nil, fmt.Errorf("oidc token request: %w", err)
+	}
+	if status != http.StatusOK || token.Error != "" {
+		return nil, fmt.Errorf("%w: token endpoint returned %d %s %s", ErrInvalidCredentials, status, token.Error, token.ErrorDescription)
+	}
+	if token.IDToken == "" {
+		return nil, fmt.Errorf("%w: token response has no id_token", ErrInvalidOAuthIdentity)
+	}
+
+	identity, err := p.verifyIDToken(ctx, token.IDToken)
+	if err != nil {
+		return nil, err
+	}
+	expected, _ := ctx.Value(oidcNonceContextKey).(string)
+	got, _ := identity.Claims["nonce"].(string)
+	if subtle.ConstantTimeCompare([]byte(got), []byte(expected)) != 1 {
+		return nil, fmt.Errorf("%w: id token nonce does not match the authorization request", ErrInvalidOAuthIdentity)
+	}
+	return identity, nil
+}
+
+// verifyIDToken checks the ID token signature, issuer, audience, and expiry and maps its claims.
+func (p *OIDCProvider) verifyIDToken(ctx context.Context, rawToken string) (*OAuthIdentity, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(rawToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.key(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(p.cfg.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOAuthIdentity, err)
+	}
+
+	identity := &OAuthIdentity{Claims: claims}
+	identity.Subject, _ = claims["sub"].(string)
+	identity.Email, _ = claims["email"].(string)
+	identity.Name, _ = claims["name"].(string)
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		identity.EmailVerified = verified
+	case string:
+		// Some providers encode the flag as a string
+		identity.EmailVerified = verified == "true"
+	}
+	return identity, nil
+}
+
+// discover fetches and caches the provider metadata.
+func (p *OIDCProvider) discover(ctx context.Context) (*oidcMetadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.Issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, fmt.Errorf("oidc discovery: %w", err)
+	}
+	var metadata oidcMetadata
+	status, err := p.doJSON(req, &metadata)
+	if err != nil {
+		return nil, fmt.Errorf("oidc discovery: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("oidc discovery: unexpected status %d", status)
+	}
+	if strings.TrimRight(metadata.Issuer, "/") != p.cfg.Issuer {
+		return nil, fmt.Errorf("oidc discovery: issuer %q does not match %q", metadata.Issuer, p.cfg.Issuer)
+	}
+	if metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return nil, errors.New("oidc discovery: token_endpoint and jwks_uri are required")
+	}
+	p.metadata = &metadata
+	return p.metadata, nil
+}
+
+// key returns the verification key for kid, refetching the JWKS once when kid is unknown to follow key rotation.
+func (p *OIDCProvider) key(ctx context.Context, kid string) (interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	keys, err := p.fetchKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.keys = keys
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("oidc: unknown signing key %q", kid)
+}
+
+// lookupKey finds kid in the cached keys. Tokens without a kid match when the JWKS holds a single key.
+func (p *OIDCProvider) lookupKey(kid string) (interface{}, bool) {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	key, ok := p.keys[kid]
+	return key, ok
+}
+
+func (p *OIDCProvider) fetchKeys(ctx context.Context) (map[string]interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.metadata.JWKSURI, nil)
+	if err != nil {
+		return nil, fmt.Errorf("oidc jwks: %w", err)
+	}
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	status, err := p.doJSON(req, &set)
+	if err != nil {
+		return nil, fmt.Errorf("oidc jwks: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("oidc jwks: unexpected status %d", status)
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			// Skip key types we cannot use rather than failing the whole set
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, nil
+}
+
+// doJSON sends req and decodes a JSON response body into v, returning the status code.
+func (p *OIDCProvider) doJSON(req *http.Request, v interface{}) (int, error) {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return resp.StatusCode, err
+	}
+	if err := json.Unmarshal(body, v); err != nil && resp.StatusCode == http.StatusOK {
+		return resp.StatusCode, fmt.Errorf("decode response: %w", err)
+	}
+	return resp.StatusCode, nil
+}
+
+// jsonWebKey is the subset of RFC 7517 fields needed for RSA and EC signature keys.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeJWKInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeJWKInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() {
+			return nil, errors.New("jwk: rsa exponent too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("jwk: unsupported curve %q", k.Crv)
+		}
+		x, err := decodeJWKInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeJWKInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("jwk: unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeJWKInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("jwk: %w", err)
+	}
+	return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,167 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/rompi/core-backend/pkg/auth"
+)
+
+// newOIDCServer serves discovery, token, and JWKS endpoints issuing ID tokens with the given claims.
+func newOIDCServer(t *testing.T, claims func(issuer string) jwt.MapClaims) *httptest.Server {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 server.URL,
+			"authorization_endpoint": server.URL + "/authorize",
+			"token_endpoint":         server.URL + "/token",
+			"jwks_uri":               server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "key-1",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		clientID, secret, ok := r.BasicAuth()
+		if !ok || clientID != "client-1" || secret != "secret-1" || r.FormValue("code") != "good-code" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims(server.URL))
+		token.Header["kid"] = "key-1"
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Errorf("SignedString() error = %v", err)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "at", "id_token": signed})
+	})
+	return server
+}
+
+func newTestOIDCProvider(t *testing.T, issuer string) *auth.OIDCProvider {
+	t.Helper()
+	provider, err := auth.NewOIDCProvider(auth.OIDCConfig{
+		Issuer:       issuer,
+		ClientID:     "client-1",
+		ClientSecret: "secret-1",
+		RedirectURL:  "https://app.example.com/callback",
+	})
+	if err != nil {
+		t.Fatalf("NewOIDCProvider() error = %v", err)
+	}
+	return provider
+}
+
+func TestOIDCProvider_Exchange(t *testing.T) {
+	server := newOIDCServer(t, func(issuer string) jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":            issuer,
+			"aud":            "client-1",
+			"sub":            "sub-123",
+			"email":          "user@example.com",
+			"email_verified": true,
+			"name":           "Test User",
+			"exp":            time.Now().Add(time.Hour).Unix(),
+		}
+	})
+	provider := newTestOIDCProvider(t, server.URL)
+	ctx := context.Background()
+
+	identity, err := provider.Exchange(ctx, "good-code")
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	if identity.Subject != "sub-123" || identity.Email != "user@example.com" || !identity.EmailVerified || identity.Name != "Test User" {
+		t.Fatalf("unexpected identity %+v", identity)
+	}
+
+	if _, err := provider.Exchange(ctx, "bad-code"); !errors.Is(err, auth.ErrInvalidCredentials) {
+		t.Fatalf("expected ErrInvalidCredentials for rejected code, got %v", err)
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, "state-1", "")
+	if err != nil {
+		t.Fatalf("AuthCodeURL() error = %v", err)
+	}
+	if !strings.HasPrefix(authURL, server.URL+"/authorize?") || !strings.Contains(authURL, "state=state-1") {
+		t.Fatalf("unexpected auth URL %q", authURL)
+	}
+}
+
+func TestOIDCProvider_VerifiesNonce(t *testing.T) {
+	server := newOIDCServer(t, func(issuer string) jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":   issuer,
+			"aud":   "client-1",
+			"sub":   "sub-123",
+			"nonce": "nonce-1",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+		}
+	})
+	provider := newTestOIDCProvider(t, server.URL)
+
+	if _, err := provider.Exchange(auth.WithOIDCNonce(context.Background(), "nonce-1"), "good-code"); err != nil {
+		t.Fatalf("Exchange() with matching nonce error = %v", err)
+	}
+	if _, err := provider.Exchange(auth.WithOIDCNonce(context.Background(), "nonce-2"), "good-code"); !errors.Is(err, auth.ErrInvalidOAuthIdentity) {
+		t.Fatalf("expected ErrInvalidOAuthIdentity for mismatched nonce, got %v", err)
+	}
+	if _, err := provider.Exchange(context.Background(), "good-code"); !errors.Is(err, auth.ErrInvalidOAuthIdentity) {
+		t.Fatalf("expected ErrInvalidOAuthIdentity for unexpected nonce, got %v", err)
+	}
+}
+
+func TestOIDCProvider_RejectsWrongAudience(t *testing.T) {
+	server := newOIDCServer(t, func(issuer string) jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss": issuer,
+			"aud": "another-client",
+			"sub": "sub-123",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}
+	})
+	provider := newTestOIDCProvider(t, server.URL)
+
+	if _, err := provider.Exchange(context.Background(), "good-code"); !errors.Is(err, auth.ErrInvalidOAuthIdentity) {
+		t.Fatalf("expected ErrInvalidOAuthIdentity, got %v", err)
+	}
+}
+
+func TestNewOIDCProvider_Validation(t *testing.T) {
+	if _, err := auth.NewOIDCProvider(auth.OIDCConfig{ClientID: "client"}); err == nil {
+		t.Fatal("expected error for missing issuer")
+	}
+	if _, err := auth.NewOIDCProvider(auth.OIDCConfig{Issuer: "https://issuer.example.com"}); err == nil {
+		t.Fatal("expected error for missing client id")
+	}
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"fmt"
+	"strings"
 	"unicode"
 
 	"golang.org/x/crypto/bcrypt"
@@ -16,8 +17,12 @@ func HashPassword(password string, cost int) (string, error) {
 	return string(hashed), nil
 }
 
-// ComparePassword validates a password against a stored bcrypt hash.
+// ComparePassword validates a password against a stored bcrypt or argon2id hash.
+// The algorithm is detected from the hash prefix so stored hashes keep working after switching hashers.
 func ComparePassword(hash, password string) error {
+	if strings.HasPrefix(hash, argon2idPrefix) {
+		return compareArgon2id(hash, password)
+	}
 	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
 		return fmt.Errorf("compare password: %w", err)
 	}
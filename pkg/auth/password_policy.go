@@ -0,0 +1,226 @@
+package auth
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1" // #nosec G505 -- required by the Pwned Passwords range API, not used for hashing passwords.
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// PasswordPolicy applies checks beyond the character-class rules of ValidatePassword.
+// Set Config.PasswordPolicy to run it on registration, password change, and password reset.
+type PasswordPolicy interface {
+	// Check returns an error wrapping ErrWeakPassword when password is not acceptable.
+	Check(ctx context.Context, password string) error
+}
+
+// BreachChecker reports whether a password is known to be compromised.
+type BreachChecker interface {
+	Breached(ctx context.Context, password string) (bool, error)
+}
+
+// StrengthPolicy rejects passwords that score below MinScore or that BreachChecker reports as breached.
+type StrengthPolicy struct {
+	// MinScore is the lowest accepted PasswordStrength score, from 0 (accept all) to 4.
+	MinScore int
+	// BreachChecker defaults to CommonPasswordChecker, which works offline.
+	BreachChecker BreachChecker
+}
+
+// Check implements PasswordPolicy.
+func (p *StrengthPolicy) Check(ctx context.Context, password string) error {
+	checker := p.BreachChecker
+	if checker == nil {
+		checker = CommonPasswordChecker{}
+	}
+	breached, err := checker.Breached(ctx, password)
+	if err != nil {
+		return fmt.Errorf("breach check: %w", err)
+	}
+	if breached {
+		return fmt.Errorf("%w: password is too common or has appeared in a data breach", ErrWeakPassword)
+	}
+
+	if score := PasswordStrength(password); score < p.MinScore {
+		return fmt.Errorf("%w: strength score %d below minimum %d", ErrWeakPassword, score, p.MinScore)
+	}
+	return nil
+}
+
+// PasswordStrength estimates how hard password is to guess on a scale from 0 (trivial) to 4 (strong).
+// The estimate is based on length and character variety, discounting repeated and sequential
+// characters and passwords derived from a common password.
+func PasswordStrength(password string) int {
+	if isCommonPassword(password) {
+		return 0
+	}
+
+	var (
+		hasUpper, hasLower, hasNumber, hasOther bool
+		effective                               int
+		prev                                    rune
+		prevDelta                               rune
+	)
+	for i, r := range []rune(password) {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasNumber = true
+		default:
+			hasOther = true
+		}
+
+		// "aaaa", "abcd", and "4321" add little beyond their first characters
+		delta := r - prev
+		repeated := i > 0 && (delta == 0 || ((delta == 1 || delta == -1) && delta == prevDelta))
+		if !repeated {
+			effective++
+		}
+		prev, prevDelta = r, delta
+	}
+
+	charset := 0
+	if hasLower {
+		charset += 26
+	}
+	if hasUpper {
+		charset += 26
+	}
+	if hasNumber {
+		charset += 10
+	}
+	if hasOther {
+		charset += 33
+	}
+	if charset == 0 {
+		return 0
+	}
+
+	bits := float64(effective) * math.Log2(float64(charset))
+	switch {
+	case bits < 28:
+		return 0
+	case bits < 36:
+		return 1
+	case bits < 60:
+		return 2
+	case bits < 80:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// CommonPasswordChecker is an offline BreachChecker that matches passwords against a built-in list of
+// the most common passwords, ignoring case and trailing digits or symbols ("Password123!" matches).
+type CommonPasswordChecker struct{}
+
+// Breached implements BreachChecker.
+func (CommonPasswordChecker) Breached(_ context.Context, password string) (bool, error) {
+	return isCommonPassword(password), nil
+}
+
+func isCommonPassword(password string) bool {
+	normalized := strings.ToLower(password)
+	if commonPasswords[normalized] {
+		return true
+	}
+	stem := strings.TrimRightFunc(normalized, func(r rune) bool {
+		return unicode.IsDigit(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+	})
+	return stem != normalized && commonPasswords[stem]
+}
+
+// commonPasswords holds the most frequent passwords from public breach corpora.
+var commonPasswords = func() map[string]bool {
+	words := strings.Fields(`
+		123456 123456789 12345678 1234567890 12345 1234567 password password1 qwerty qwerty123
+		qwertyuiop 111111 123123 000000 1q2w3e4r 1q2w3e4r5t abc123 654321 666666 121212
+		iloveyou admin welcome welcome1 monkey dragon letmein football baseball sunshine
+		princess master shadow superman batman trustno1 passw0rd p@ssw0rd p@ssword hello
+		freedom whatever starwars login secret charlie michael jennifer jordan hunter hunter2
+		computer internet samsung google azerty soccer killer pokemon chocolate flower
+		cheese summer winter spring autumn changeme default guest root administrator test
+		testing qazwsx zaq12wsx asdfghjkl asdfgh zxcvbnm 987654321 112233 7777777 888888
+		999999 159753 mustang access ashley bailey buster daniel hockey loveme maggie
+		matrix michelle nicole pepper ranger robert thomas tigger yankees lovely family
+	`)
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}()
+
+// HIBPBreachChecker queries the Have I Been Pwned Pwned Passwords range API using k-anonymity:
+// only the first five hex characters of the password's SHA-1 hash leave the process.
+type HIBPBreachChecker struct {
+	// BaseURL defaults to https://api.pwnedpasswords.com.
+	BaseURL string
+	// HTTPClient defaults to a client with a 5s timeout.
+	HTTPClient *http.Client
+	// MinCount is the number of breach occurrences at which a password is rejected. Defaults to 1.
+	MinCount int
+}
+
+// Breached implements BreachChecker.
+func (c *HIBPBreachChecker) Breached(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password)) // #nosec G401 -- protocol requirement, see import comment.
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	baseURL := strings.TrimRight(c.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = "https://api.pwnedpasswords.com"
+	}
+	client := c.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+	minCount := c.MinCount
+	if minCount <= 0 {
+		minCount = 1
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/range/"+prefix, nil)
+	if err != nil {
+		return false, fmt.Errorf("pwned passwords request: %w", err)
+	}
+	// Padding hides the real number of matching suffixes from observers
+	req.Header.Set("Add-Padding", "true")
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("pwned passwords request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("pwned passwords request: unexpected status %d", resp.StatusCode)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		candidate, count, found := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !found || !strings.EqualFold(candidate, suffix) {
+			continue
+		}
+		n, err := strconv.Atoi(count)
+		if err != nil {
+			return false, fmt.Errorf("pwned passwords response: %w", err)
+		}
+		return n >= minCount, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("pwned passwords response: %w", err)
+	}
+	return false, nil
+}
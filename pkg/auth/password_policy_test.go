@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPasswordStrength(t *testing.T) {
+	tests := []struct {
+		password string
+		want     int
+	}{
+		{password: "password", want: 0},
+		{password: "Password123!", want: 0},
+		{password: "aaaaaaaaaaaa", want: 0},
+		{password: "abcdefgh", want: 0},
+		{password: "Str0ng!Pass", want: 3},
+		{password: "correct-Horse-battery-staple-9", want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			if got := PasswordStrength(tt.password); got != tt.want {
+				t.Fatalf("PasswordStrength(%q) = %d, want %d", tt.password, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStrengthPolicy(t *testing.T) {
+	policy := &StrengthPolicy{MinScore: 3}
+	ctx := context.Background()
+
+	if err := policy.Check(ctx, "Password123!"); !errors.Is(err, ErrWeakPassword) {
+		t.Fatalf("Check(common) error = %v, want ErrWeakPassword", err)
+	}
+	if err := policy.Check(ctx, "Qz7!rTbv"); !errors.Is(err, ErrWeakPassword) {
+		t.Fatalf("Check(low score) error = %v, want ErrWeakPassword", err)
+	}
+	if err := policy.Check(ctx, "correct-Horse-battery-staple-9"); err != nil {
+		t.Fatalf("Check(strong) error = %v", err)
+	}
+}
+
+type stubBreachChecker struct {
+	breached bool
+	err      error
+}
+
+func (c stubBreachChecker) Breached(context.Context, string) (bool, error) {
+	return c.breached, c.err
+}
+
+func TestStrengthPolicy_BreachChecker(t *testing.T) {
+	ctx := context.Background()
+	strong := "correct-Horse-battery-staple-9"
+
+	policy := &StrengthPolicy{BreachChecker: stubBreachChecker{breached: true}}
+	if err := policy.Check(ctx, strong); !errors.Is(err, ErrWeakPassword) {
+		t.Fatalf("Check(breached) error = %v, want ErrWeakPassword", err)
+	}
+
+	checkErr := errors.New("unavailable")
+	policy = &StrengthPolicy{BreachChecker: stubBreachChecker{err: checkErr}}
+	if err := policy.Check(ctx, strong); !errors.Is(err, checkErr) {
+		t.Fatalf("Check(checker error) error = %v, want %v", err, checkErr)
+	}
+}
+
+func TestHIBPBreachChecker(t *testing.T) {
+	// SHA-1("password") = 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+	var gotPath, gotPadding string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotPadding = r.Header.Get("Add-Padding")
+		fmt.Fprintln(w, "003D68EB55068C33ACE09247EE4C639306B:3")
+		fmt.Fprintln(w, "1E4C9B93F3F0682250B6CF8331B7EE68FD8:9659365")
+		fmt.Fprintln(w, "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF:0")
+	}))
+	defer server.Close()
+
+	checker := &HIBPBreachChecker{BaseURL: server.URL, HTTPClient: server.Client()}
+	breached, err := checker.Breached(context.Background(), "password")
+	if err != nil {
+		t.Fatalf("Breached() error = %v", err)
+	}
+	if !breached {
+		t.Fatal("expected password to be reported as breached")
+	}
+	if gotPath != "/range/5BAA6" {
+		t.Fatalf("path = %q, want /range/5BAA6", gotPath)
+	}
+	if gotPadding != "true" {
+		t.Fatalf("Add-Padding = %q, want true", gotPadding)
+	}
+
+	breached, err = checker.Breached(context.Background(), strings.Repeat("x", 40))
+	if err != nil {
+		t.Fatalf("Breached() error = %v", err)
+	}
+	if breached {
+		t.Fatal("expected unknown password not to be reported as breached")
+	}
+}
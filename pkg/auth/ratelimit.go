@@ -1,61 +1,119 @@
 package auth
 
 import (
+	"context"
+	"fmt"
 	"sync"
 	"time"
 )
 
-// RateLimiter enforces request counts per key within a sliding window.
+// RateLimiterStore keeps per-key request counters that expire after a window. Sharing one store
+// (for example RedisRateLimiterStore) across replicas makes the configured limit cluster-wide.
+type RateLimiterStore interface {
+	// Incr increments the counter for key and returns the new count. A counter created by Incr
+	// expires after ttl.
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
+	// Get returns the current count for key, or 0 if the counter does not exist or has expired.
+	Get(ctx context.Context, key string) (int64, error)
+}
+
+// RateLimiter enforces request counts per key within a fixed window.
 type RateLimiter struct {
 	window time.Duration
 	max    int
+	store  RateLimiterStore
+}
+
+// NewRateLimiter creates a rate limiter configured from cfg. It uses cfg.RateLimiterStore when set
+// and an in-process store otherwise.
+func NewRateLimiter(cfg *Config) *RateLimiter {
+	store := cfg.RateLimiterStore
+	if store == nil {
+		store = NewMemoryRateLimiterStore()
+	}
+	return &RateLimiter{
+		window: cfg.RateLimitWindow,
+		max:    cfg.RateLimitMaxRequests,
+		store:  store,
+	}
+}
+
+// Allow reports whether a key can proceed. Returns false when the limit is reached or the store fails.
+func (rl *RateLimiter) Allow(key string) bool {
+	allowed, err := rl.AllowContext(context.Background(), key)
+	return err == nil && allowed
+}
+
+// AllowContext reports whether a key can proceed, returning any store error.
+// Rejected requests are not counted against the window.
+func (rl *RateLimiter) AllowContext(ctx context.Context, key string) (bool, error) {
+	count, err := rl.store.Get(ctx, key)
+	if err != nil {
+		return false, fmt.Errorf("rate limit lookup: %w", err)
+	}
+	if count >= int64(rl.max) {
+		return false, nil
+	}
+	count, err = rl.store.Incr(ctx, key, rl.window)
+	if err != nil {
+		return false, fmt.Errorf("rate limit increment: %w", err)
+	}
+	return count <= int64(rl.max), nil
+}
+
+// setNow is a test hook that overrides the time source of the in-process store.
+func (rl *RateLimiter) setNow(fn func() time.Time) {
+	if store, ok := rl.store.(*MemoryRateLimiterStore); ok {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		store.now = fn
+	}
+}
 
+// MemoryRateLimiterStore is the default in-process RateLimiterStore. Counters are local to the process.
+type MemoryRateLimiterStore struct {
 	mu      sync.Mutex
 	buckets map[string]*rateBucket
 	now     func() time.Time
 }
 
 type rateBucket struct {
-	count int
-	start time.Time
+	count   int64
+	expires time.Time
 }
 
-// NewRateLimiter creates a rate limiter configured from cfg.
-func NewRateLimiter(cfg *Config) *RateLimiter {
-	return &RateLimiter{
-		window:  cfg.RateLimitWindow,
-		max:     cfg.RateLimitMaxRequests,
+// NewMemoryRateLimiterStore returns an empty in-process store.
+func NewMemoryRateLimiterStore() *MemoryRateLimiterStore {
+	return &MemoryRateLimiterStore{
 		buckets: make(map[string]*rateBucket),
 		now:     time.Now,
 	}
 }
 
-// Allow reports whether a key can proceed. Returns false when the limit is reached.
-func (rl *RateLimiter) Allow(key string) bool {
-	return rl.allowAt(key, rl.now())
-}
-
-func (rl *RateLimiter) allowAt(key string, now time.Time) bool {
-	rl.mu.Lock()
-	defer rl.mu.Unlock()
-
-	bucket, ok := rl.buckets[key]
-	if !ok || now.Sub(bucket.start) >= rl.window {
-		bucket = &rateBucket{start: now}
-		rl.buckets[key] = bucket
-	}
+// Incr increments the counter for key, starting a new window of ttl when none is active.
+func (m *MemoryRateLimiterStore) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	if bucket.count >= rl.max {
-		return false
+	now := m.now()
+	bucket, ok := m.buckets[key]
+	if !ok || !now.Before(bucket.expires) {
+		bucket = &rateBucket{expires: now.Add(ttl)}
+		m.buckets[key] = bucket
 	}
-
 	bucket.count++
-	return true
+	return bucket.count, nil
 }
 
-// setNow is a test hook that overrides the time source.
-func (rl *RateLimiter) setNow(fn func() time.Time) {
-	rl.mu.Lock()
-	defer rl.mu.Unlock()
-	rl.now = fn
+// Get returns the counter for key within its active window.
+func (m *MemoryRateLimiterStore) Get(ctx context.Context, key string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	bucket, ok := m.buckets[key]
+	if !ok || !m.now().Before(bucket.expires) {
+		delete(m.buckets, key)
+		return 0, nil
+	}
+	return bucket.count, nil
 }
@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+// RedisClient is the subset of Redis commands used by RedisRateLimiterStore. Adapt your Redis
+// client of choice to it; Eval must return the script's integer reply and Get must return 0 and a
+// nil error for missing keys.
+type RedisClient interface {
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) (int64, error)
+	Get(ctx context.Context, key string) (int64, error)
+}
+
+// redisIncrScript increments a counter and sets its expiry in one atomic step. It also sets the
+// expiry of a counter left without one, so a key can never outlive its window forever.
+const redisIncrScript = `local count = redis.call('INCR', KEYS[1])
+if count == 1 or redis.call('PTTL', KEYS[1]) == -1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return count`
+
+// RedisRateLimiterStore shares rate limit counters across replicas through Redis.
+type RedisRateLimiterStore struct {
+	client RedisClient
+	prefix string
+}
+
+// NewRedisRateLimiterStore returns a store that keeps counters in client under keys starting with prefix.
+func NewRedisRateLimiterStore(client RedisClient, prefix string) *RedisRateLimiterStore {
+	return &RedisRateLimiterStore{client: client, prefix: prefix}
+}
+
+// Incr increments the counter for key and sets its expiry when the counter is new, atomically.
+func (s *RedisRateLimiterStore) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	return s.client.Eval(ctx, redisIncrScript, []string{s.prefix + key}, ttl.Milliseconds())
+}
+
+// Get returns the counter for key.
+func (s *RedisRateLimiterStore) Get(ctx context.Context, key string) (int64, error) {
+	return s.client.Get(ctx, s.prefix+key)
+}
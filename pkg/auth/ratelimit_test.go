@@ -1,6 +1,9 @@
 package auth
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 )
@@ -29,3 +32,147 @@ func TestRateLimiter_Allow(t *testing.T) {
 		t.Fatalf("expected request after window reset")
 	}
 }
+
+// fakeStore is a RateLimiterStore driven by a manual clock.
+type fakeStore struct {
+	now      time.Time
+	counts   map[string]int64
+	expires  map[string]time.Time
+	incrs    int
+	lastTTL  time.Duration
+	failWith error
+}
+
+func newFakeStore(now time.Time) *fakeStore {
+	return &fakeStore{now: now, counts: map[string]int64{}, expires: map[string]time.Time{}}
+}
+
+func (f *fakeStore) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	if f.failWith != nil {
+		return 0, f.failWith
+	}
+	f.incrs++
+	if exp, ok := f.expires[key]; !ok || !f.now.Before(exp) {
+		f.counts[key] = 0
+		f.expires[key] = f.now.Add(ttl)
+		f.lastTTL = ttl
+	}
+	f.counts[key]++
+	return f.counts[key], nil
+}
+
+func (f *fakeStore) Get(ctx context.Context, key string) (int64, error) {
+	if f.failWith != nil {
+		return 0, f.failWith
+	}
+	if exp, ok := f.expires[key]; !ok || !f.now.Before(exp) {
+		return 0, nil
+	}
+	return f.counts[key], nil
+}
+
+func TestRateLimiter_UsesConfiguredStore(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.RateLimitWindow = time.Minute
+	cfg.RateLimitMaxRequests = 3
+	store := newFakeStore(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+	cfg.RateLimiterStore = store
+
+	rl := NewRateLimiter(cfg)
+	ctx := context.Background()
+	for i := 0; i < cfg.RateLimitMaxRequests; i++ {
+		allowed, err := rl.AllowContext(ctx, "key")
+		if err != nil || !allowed {
+			t.Fatalf("request %d: allowed = %v, err = %v", i+1, allowed, err)
+		}
+	}
+	if store.lastTTL != cfg.RateLimitWindow {
+		t.Fatalf("ttl = %v, want %v", store.lastTTL, cfg.RateLimitWindow)
+	}
+
+	allowed, err := rl.AllowContext(ctx, "key")
+	if err != nil || allowed {
+		t.Fatalf("expected rejection after limit, allowed = %v, err = %v", allowed, err)
+	}
+	if store.incrs != cfg.RateLimitMaxRequests {
+		t.Fatalf("rejected request should not be counted, incrs = %d", store.incrs)
+	}
+	if allowed, _ := rl.AllowContext(ctx, "other"); !allowed {
+		t.Fatal("expected independent key to be allowed")
+	}
+
+	store.now = store.now.Add(cfg.RateLimitWindow)
+	if allowed, err := rl.AllowContext(ctx, "key"); err != nil || !allowed {
+		t.Fatalf("expected request after window reset, allowed = %v, err = %v", allowed, err)
+	}
+
+	store.failWith = errors.New("store down")
+	if _, err := rl.AllowContext(ctx, "key"); !errors.Is(err, store.failWith) {
+		t.Fatalf("expected store error, got %v", err)
+	}
+	if rl.Allow("key") {
+		t.Fatal("expected Allow to reject when the store fails")
+	}
+}
+
+// fakeRedis emulates redisIncrScript: INCR, then PEXPIRE when the key is new or has no expiry.
+type fakeRedis struct {
+	values  map[string]int64
+	expires map[string]time.Duration
+}
+
+func (f *fakeRedis) Eval(ctx context.Context, script string, keys []string, args ...interface{}) (int64, error) {
+	if script != redisIncrScript {
+		return 0, fmt.Errorf("unexpected script %q", script)
+	}
+	key := keys[0]
+	f.values[key]++
+	if _, ok := f.expires[key]; f.values[key] == 1 || !ok {
+		f.expires[key] = time.Duration(args[0].(int64)) * time.Millisecond
+	}
+	return f.values[key], nil
+}
+
+func (f *fakeRedis) Get(ctx context.Context, key string) (int64, error) {
+	return f.values[key], nil
+}
+
+func TestRedisRateLimiterStore(t *testing.T) {
+	client := &fakeRedis{values: map[string]int64{}, expires: map[string]time.Duration{}}
+	store := NewRedisRateLimiterStore(client, "ratelimit:")
+	ctx := context.Background()
+
+	for i := int64(1); i <= 2; i++ {
+		count, err := store.Incr(ctx, "login:user@example.com", time.Minute)
+		if err != nil {
+			t.Fatalf("Incr() error = %v", err)
+		}
+		if count != i {
+			t.Fatalf("count = %d, want %d", count, i)
+		}
+	}
+	if ttl := client.expires["ratelimit:login:user@example.com"]; ttl != time.Minute {
+		t.Fatalf("expiry = %v, want %v", ttl, time.Minute)
+	}
+	count, err := store.Get(ctx, "login:user@example.com")
+	if err != nil || count != 2 {
+		t.Fatalf("Get() = %d, %v", count, err)
+	}
+}
+
+func TestRedisRateLimiterStore_SetsMissingExpiry(t *testing.T) {
+	// A counter left without an expiry, e.g. by an older non-atomic client, must not block forever.
+	client := &fakeRedis{values: map[string]int64{"ratelimit:login:user@example.com": 5}, expires: map[string]time.Duration{}}
+	store := NewRedisRateLimiterStore(client, "ratelimit:")
+
+	count, err := store.Incr(context.Background(), "login:user@example.com", time.Minute)
+	if err != nil {
+		t.Fatalf("Incr() error = %v", err)
+	}
+	if count != 6 {
+		t.Fatalf("count = %d, want 6", count)
+	}
+	if ttl := client.expires["ratelimit:login:user@example.com"]; ttl != time.Minute {
+		t.Fatalf("expiry = %v, want %v", ttl, time.Minute)
+	}
+}
@@ -1,6 +1,9 @@
 package auth
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // UserRepository defines persistence operations for users.
 type UserRepository interface {
@@ -10,8 +13,10 @@ type UserRepository interface {
 	Update(ctx context.Context, user *User) error
 	Delete(ctx context.Context, id string) error
 	IncrementFailedAttempts(ctx context.Context, userID string) error
+	// ResetFailedAttempts clears FailedAttempts, LockoutCount, and any active lock after a successful login.
 	ResetFailedAttempts(ctx context.Context, userID string) error
-	LockAccount(ctx context.Context, userID string) error
+	// LockAccount sets LockedUntil to until, increments LockoutCount, and clears FailedAttempts.
+	LockAccount(ctx context.Context, userID string, until time.Time) error
 	UnlockAccount(ctx context.Context, userID string) error
 }
 
@@ -48,6 +53,42 @@ type PasswordResetTokenRepository interface {
 	DeleteExpired(ctx context.Context) error
 }
 
+// EmailVerificationTokenRepository defines persistence for email verification tokens.
+type EmailVerificationTokenRepository interface {
+	Create(ctx context.Context, token *EmailVerificationToken) error
+	GetByToken(ctx context.Context, token string) (*EmailVerificationToken, error)
+	Delete(ctx context.Context, token string) error
+	DeleteExpired(ctx context.Context) error
+}
+
+// RefreshTokenRepository defines persistence for refresh tokens and their rotation chains.
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *RefreshToken) error
+	GetByToken(ctx context.Context, token string) (*RefreshToken, error)
+	// MarkUsed marks token used only if it is not used yet (e.g. UPDATE ... WHERE token = $1 AND
+	// used = false) and reports whether it did, so concurrent refreshes with one token cannot both win.
+	MarkUsed(ctx context.Context, token string) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeByUserID(ctx context.Context, userID string) error
+	DeleteExpired(ctx context.Context) error
+}
+
+// PasswordHistoryRepository defines persistence for previous password hashes.
+type PasswordHistoryRepository interface {
+	Create(ctx context.Context, entry *PasswordHistory) error
+	// GetByUserID returns up to limit of the user's entries, newest first.
+	GetByUserID(ctx context.Context, userID string, limit int) ([]*PasswordHistory, error)
+	// Trim deletes all but the user's newest keep entries.
+	Trim(ctx context.Context, userID string, keep int) error
+}
+
+// OAuthAccountRepository defines persistence for links between external identities and users.
+type OAuthAccountRepository interface {
+	Create(ctx context.Context, account *OAuthAccount) error
+	// GetByProviderSubject returns the linked account, or nil (or ErrOAuthAccountNotFound) when none exists.
+	GetByProviderSubject(ctx context.Context, provider, subject string) (*OAuthAccount, error)
+}
+
 // APIKeyRepository defines persistence for API keys.
 type APIKeyRepository interface {
 	GetByKey(ctx context.Context, key string) (*APIKey, error)
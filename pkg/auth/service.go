@@ -4,29 +4,57 @@ import (
 	"context"
 	"net/http"
 	"time"
+
+	"google.golang.org/grpc"
 )
 
 // Service defines the core authentication operations provided by the package.
 type Service interface {
 	Register(ctx context.Context, req RegisterRequest) (*User, error)
 	Login(ctx context.Context, req LoginRequest) (*LoginResponse, error)
+	// LoginWithOAuth exchanges an authorization code with the named provider, links the external identity to a
+	// local user (creating one on first login), and issues tokens like Login.
+	LoginWithOAuth(ctx context.Context, provider, code string) (*LoginResponse, error)
+	// Logout blacklists token until it expires and deletes its session when a SessionRepository is configured.
 	Logout(ctx context.Context, token string) error
+	// ValidateToken verifies token, rejects revoked sessions when a SessionRepository is configured, and loads the user.
+	// Tokens blacklisted by Logout fail with ErrTokenRevoked.
 	ValidateToken(ctx context.Context, token string) (*User, error)
-	RefreshToken(ctx context.Context, token string) (*LoginResponse, error)
+	// ValidateTokenClaims verifies token and returns its claims, including custom ones, without loading the user.
+	// Tokens blacklisted by Logout fail with ErrTokenRevoked.
+	ValidateTokenClaims(ctx context.Context, token string) (*Claims, error)
+	// RefreshToken exchanges a refresh token for a new access/refresh token pair, invalidating the old one.
+	RefreshToken(ctx context.Context, refreshToken string) (*LoginResponse, error)
+	// ListSessions returns the user's active (unrevoked, unexpired) sessions.
+	ListSessions(ctx context.Context, userID string) ([]*Session, error)
+	// RevokeSession revokes a single session so its token no longer validates.
+	RevokeSession(ctx context.Context, token string) error
+	// RevokeAllSessions revokes every session and refresh token of the user ("log out everywhere").
+	RevokeAllSessions(ctx context.Context, userID string) error
 	InitiatePasswordReset(ctx context.Context, email string) (*PasswordResetToken, error)
 	CompletePasswordReset(ctx context.Context, token, newPassword string) error
+	// InitiateEmailVerification issues a verification token the caller delivers to the user's email address.
+	InitiateEmailVerification(ctx context.Context, userID string) (*EmailVerificationToken, error)
+	// VerifyEmail consumes a verification token and marks the owner's email as verified.
+	VerifyEmail(ctx context.Context, token string) error
 	ChangePassword(ctx context.Context, userID string, oldPassword, newPassword string) error
 	ValidateAPIKey(ctx context.Context, apiKey string) (*User, error)
 	GetUserRoles(ctx context.Context, userID string) ([]Role, error)
 	CheckPermission(ctx context.Context, userID string, permission string) (bool, error)
 	// Middleware validates JWT bearer tokens and injects the user into the request context.
 	Middleware() func(http.Handler) http.Handler
+	// APIKeyMiddleware validates API keys read from header and injects the owning user into the request context.
+	APIKeyMiddleware(header string) func(http.Handler) http.Handler
 	// RequireRole only allows requests for users holding at least one of the requested roles.
 	RequireRole(roles ...string) func(http.Handler) http.Handler
 	// RequirePermission only allows requests for users owning all requested permissions.
 	RequirePermission(permissions ...string) func(http.Handler) http.Handler
 	// RateLimitMiddleware applies per-origin rate limiting to HTTP requests.
 	RateLimitMiddleware() func(http.Handler) http.Handler
+	// UnaryServerInterceptor validates JWT bearer tokens in gRPC metadata and injects the user into the call context.
+	UnaryServerInterceptor() grpc.UnaryServerInterceptor
+	// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor.
+	StreamServerInterceptor() grpc.StreamServerInterceptor
 }
 
 // RegisterRequest captures required data for creating a new user account.
@@ -40,11 +68,15 @@ type RegisterRequest struct {
 type LoginRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	// RememberMe issues a token valid for Config.JWTRememberMeDuration instead of JWTExpirationDuration.
+	RememberMe bool `json:"remember_me"`
 }
 
 // LoginResponse returns tokens and metadata after a successful login.
 type LoginResponse struct {
-	Token     string    `json:"token"`
-	ExpiresAt time.Time `json:"expires_at"`
-	User      *User     `json:"user"`
+	Token                 string    `json:"token"`
+	ExpiresAt             time.Time `json:"expires_at"`
+	RefreshToken          string    `json:"refresh_token,omitempty"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at,omitempty"`
+	User                  *User     `json:"user"`
 }
@@ -0,0 +1,102 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rompi/core-backend/pkg/auth"
+	"github.com/rompi/core-backend/pkg/auth/testutil"
+)
+
+type recordingSink struct {
+	events []auth.AuditEvent
+}
+
+func (r *recordingSink) Record(ctx context.Context, event auth.AuditEvent) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *recordingSink) types() []auth.EventType {
+	types := make([]auth.EventType, 0, len(r.events))
+	for _, event := range r.events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func TestService_AuditSinkReceivesTypedEvents(t *testing.T) {
+	sink := &recordingSink{}
+	var stored []*auth.AuditLog
+	cfg := newTestConfig()
+	cfg.RateLimitMaxRequests = 100
+	cfg.MaxFailedAttempts = 1
+	cfg.AuditSink = sink
+
+	hash, err := auth.HashPassword("Str0ng!Pass", cfg.BcryptCost)
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+	user := &auth.User{ID: "user-1", Email: "user@example.com", PasswordHash: hash}
+	users := &testutil.MockUserRepository{
+		GetByEmailFunc: func(ctx context.Context, email string) (*auth.User, error) {
+			copied := *user
+			return &copied, nil
+		},
+		GetByIDFunc: func(ctx context.Context, id string) (*auth.User, error) {
+			copied := *user
+			return &copied, nil
+		},
+	}
+	audit := &testutil.MockAuditLogRepository{
+		CreateFunc: func(ctx context.Context, log *auth.AuditLog) error {
+			stored = append(stored, log)
+			return nil
+		},
+	}
+
+	svc, err := auth.NewService(cfg, auth.Repositories{Users: users, AuditLogs: audit})
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+
+	ctx := auth.WithRequestInfo(context.Background(), "203.0.113.7", "audit-test/1.0")
+	if _, err := svc.Login(ctx, auth.LoginRequest{Email: user.Email, Password: "Str0ng!Pass"}); err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if _, err := svc.Login(ctx, auth.LoginRequest{Email: user.Email, Password: "Wr0ng!Pass"}); err == nil {
+		t.Fatal("expected failed login")
+	}
+	if err := svc.ChangePassword(ctx, user.ID, "Str0ng!Pass", "N3w!Passw0rd"); err != nil {
+		t.Fatalf("ChangePassword() error = %v", err)
+	}
+
+	want := []auth.EventType{auth.EventLogin, auth.EventAccountLocked, auth.EventPasswordChanged}
+	got := sink.types()
+	if len(got) != len(want) {
+		t.Fatalf("sink events = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("event %d = %s, want %s", i, got[i], want[i])
+		}
+	}
+	for _, event := range sink.events {
+		if event.UserID != user.ID {
+			t.Fatalf("event %s user = %q", event.Type, event.UserID)
+		}
+		if event.IP != "203.0.113.7" || event.UserAgent != "audit-test/1.0" {
+			t.Fatalf("event %s request info = %q, %q", event.Type, event.IP, event.UserAgent)
+		}
+		if event.Timestamp.IsZero() {
+			t.Fatalf("event %s has no timestamp", event.Type)
+		}
+	}
+
+	if len(stored) != len(want) {
+		t.Fatalf("expected %d repository entries, got %d", len(want), len(stored))
+	}
+	if stored[0].Action != string(auth.EventLogin) || stored[0].IPAddress != "203.0.113.7" {
+		t.Fatalf("unexpected repository entry %+v", stored[0])
+	}
+}
@@ -0,0 +1,123 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rompi/core-backend/pkg/auth"
+	"github.com/rompi/core-backend/pkg/auth/testutil"
+)
+
+func newEmailVerificationStore() *testutil.MockEmailVerificationTokenRepository {
+	store := make(map[string]*auth.EmailVerificationToken)
+	return &testutil.MockEmailVerificationTokenRepository{
+		CreateFunc: func(ctx context.Context, token *auth.EmailVerificationToken) error {
+			store[token.Token] = token
+			return nil
+		},
+		GetByTokenFunc: func(ctx context.Context, token string) (*auth.EmailVerificationToken, error) {
+			return store[token], nil
+		},
+		DeleteFunc: func(ctx context.Context, token string) error {
+			delete(store, token)
+			return nil
+		},
+	}
+}
+
+func TestService_EmailVerificationUnblocksLogin(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.RequireVerifiedEmail = true
+	cfg.RateLimitMaxRequests = 100
+
+	var stored *auth.User
+	users := &testutil.MockUserRepository{
+		GetByEmailFunc: func(ctx context.Context, email string) (*auth.User, error) {
+			if stored == nil {
+				return nil, auth.ErrUserNotFound
+			}
+			copied := *stored
+			return &copied, nil
+		},
+		GetByIDFunc: func(ctx context.Context, id string) (*auth.User, error) {
+			copied := *stored
+			return &copied, nil
+		},
+		CreateFunc: func(ctx context.Context, user *auth.User) error {
+			stored = user
+			return nil
+		},
+		UpdateFunc: func(ctx context.Context, user *auth.User) error {
+			stored = user
+			return nil
+		},
+	}
+
+	svc, err := auth.NewService(cfg, auth.Repositories{Users: users, EmailVerificationTokens: newEmailVerificationStore()})
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+
+	ctx := context.Background()
+	req := auth.RegisterRequest{Email: "verify@example.com", Password: "Str0ng!Pass"}
+	user, err := svc.Register(ctx, req)
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if user.EmailVerified {
+		t.Fatal("expected new user to be unverified")
+	}
+
+	login := auth.LoginRequest{Email: req.Email, Password: req.Password}
+	if _, err := svc.Login(ctx, login); !errors.Is(err, auth.ErrEmailNotVerified) {
+		t.Fatalf("expected ErrEmailNotVerified, got %v", err)
+	}
+
+	verification, err := svc.InitiateEmailVerification(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("InitiateEmailVerification() error = %v", err)
+	}
+	if err := svc.VerifyEmail(ctx, verification.Token); err != nil {
+		t.Fatalf("VerifyEmail() error = %v", err)
+	}
+	if !stored.EmailVerified {
+		t.Fatal("expected email to be verified")
+	}
+	if _, err := svc.Login(ctx, login); err != nil {
+		t.Fatalf("Login() after verification error = %v", err)
+	}
+	if err := svc.VerifyEmail(ctx, verification.Token); !errors.Is(err, auth.ErrInvalidEmailToken) {
+		t.Fatalf("expected consumed token to be rejected, got %v", err)
+	}
+}
+
+func TestService_VerifyEmailRejectsExpiredToken(t *testing.T) {
+	cfg := newTestConfig()
+
+	tokens := &testutil.MockEmailVerificationTokenRepository{
+		GetByTokenFunc: func(ctx context.Context, token string) (*auth.EmailVerificationToken, error) {
+			return &auth.EmailVerificationToken{
+				Token:     token,
+				UserID:    "user-1",
+				IssuedAt:  time.Now().Add(-2 * time.Hour),
+				ExpiresAt: time.Now().Add(-time.Hour),
+			}, nil
+		},
+	}
+	users := &testutil.MockUserRepository{
+		UpdateFunc: func(ctx context.Context, user *auth.User) error {
+			t.Fatal("user must not be updated for an expired token")
+			return nil
+		},
+	}
+
+	svc, err := auth.NewService(cfg, auth.Repositories{Users: users, EmailVerificationTokens: tokens})
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+	if err := svc.VerifyEmail(context.Background(), "expired"); !errors.Is(err, auth.ErrInvalidEmailToken) {
+		t.Fatalf("expected ErrInvalidEmailToken, got %v", err)
+	}
+}
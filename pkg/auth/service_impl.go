@@ -14,12 +14,16 @@ import (
 
 // Repositories groups the persistence contracts required by the auth service.
 type Repositories struct {
-	Users               UserRepository
-	Sessions            SessionRepository
-	Roles               RoleRepository
-	AuditLogs           AuditLogRepository
-	PasswordResetTokens PasswordResetTokenRepository
-	APIKeys             APIKeyRepository
+	Users                   UserRepository
+	Sessions                SessionRepository
+	Roles                   RoleRepository
+	AuditLogs               AuditLogRepository
+	PasswordResetTokens     PasswordResetTokenRepository
+	APIKeys                 APIKeyRepository
+	RefreshTokens           RefreshTokenRepository
+	EmailVerificationTokens EmailVerificationTokenRepository
+	OAuthAccounts           OAuthAccountRepository
+	PasswordHistory         PasswordHistoryRepository
 }
 
 func (r Repositories) validate() error {
@@ -34,7 +38,9 @@ type service struct {
 	cfg          *Config
 	repos        Repositories
 	tokenManager *TokenManager
+	hasher       Hasher
 	limiter      *RateLimiter
+	blacklist    TokenBlacklist
 	audit        *AuditLogger
 	now          func() time.Time
 }
@@ -50,11 +56,21 @@ func NewService(cfg *Config, repos Repositories) (Service, error) {
 	if err := repos.validate(); err != nil {
 		return nil, err
 	}
+	hasher, err := NewHasher(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	blacklist := cfg.TokenBlacklist
+	if blacklist == nil {
+		blacklist = NewMemoryTokenBlacklist()
+	}
 	return &service{
 		cfg:          cfg,
 		repos:        repos,
 		tokenManager: NewTokenManager(cfg),
+		hasher:       hasher,
 		limiter:      NewRateLimiter(cfg),
+		blacklist:    blacklist,
 		audit:        NewAuditLogger(repos.AuditLogs),
 		now:          time.Now,
 	}, nil
@@ -68,7 +84,7 @@ func (s *service) Register(ctx context.Context, req RegisterRequest) (*User, err
 	if err := s.rateLimit(ctx, fmt.Sprintf("register:%s", email)); err != nil {
 		return nil, err
 	}
-	if err := ValidatePassword(req.Password, s.cfg); err != nil {
+	if err := s.validatePassword(ctx, req.Password); err != nil {
 		return nil, err
 	}
 
@@ -80,7 +96,7 @@ func (s *service) Register(ctx context.Context, req RegisterRequest) (*User, err
 		return nil, fmt.Errorf("checking user existence: %w", err)
 	}
 
-	hash, err := HashPassword(req.Password, s.cfg.BcryptCost)
+	hash, err := s.hasher.Hash(req.Password)
 	if err != nil {
 		return nil, err
 	}
@@ -92,18 +108,19 @@ func (s *service) Register(ctx context.Context, req RegisterRequest) (*User, err
 	}
 
 	user := &User{
-		ID:           uuid.NewString(),
-		Email:        email,
-		PasswordHash: hash,
-		Language:     language,
-		CreatedAt:    now,
-		UpdatedAt:    now,
+		ID:            uuid.NewString(),
+		Email:         email,
+		EmailVerified: false,
+		PasswordHash:  hash,
+		Language:      language,
+		CreatedAt:     now,
+		UpdatedAt:     now,
 	}
 
 	if err := s.repos.Users.Create(ctx, user); err != nil {
 		return nil, fmt.Errorf("create user: %w", err)
 	}
-	s.logEvent(ctx, user.ID, "register", "user registered", map[string]interface{}{"language": language})
+	s.logEvent(ctx, user.ID, EventRegister, "user registered", map[string]interface{}{"language": language})
 	return user, nil
 }
 
@@ -122,47 +139,62 @@ func (s *service) Login(ctx context.Context, req LoginRequest) (*LoginResponse,
 		return nil, ErrAccountLocked
 	}
 
-	if err := ComparePassword(user.PasswordHash, req.Password); err != nil {
+	if err := s.hasher.Compare(user.PasswordHash, req.Password); err != nil {
 		s.handleFailedAttempt(ctx, user)
 		return nil, ErrInvalidCredentials
 	}
 
+	if s.cfg.RequireVerifiedEmail && !user.EmailVerified {
+		return nil, ErrEmailNotVerified
+	}
+
 	if err := s.repos.Users.ResetFailedAttempts(ctx, user.ID); err != nil {
 		return nil, fmt.Errorf("reset failed attempts: %w", err)
 	}
+	// Keep user in step with the reset so a rehash Update does not write the old counters back.
+	user.FailedAttempts = 0
+	user.LockoutCount = 0
+	user.LockedUntil = time.Time{}
+	s.upgradePasswordHash(ctx, user, req.Password)
 
-	token, expiresAt, err := s.tokenManager.Generate(user)
+	resp, err := s.issueTokens(ctx, user, uuid.NewString(), req.RememberMe)
 	if err != nil {
 		return nil, err
 	}
 
-	if s.repos.Sessions != nil {
-		session := &Session{
-			Token:     token,
-			UserID:    user.ID,
-			IssuedAt:  now,
-			ExpiresAt: expiresAt,
-		}
-		if err := s.repos.Sessions.Create(ctx, session); err != nil {
-			return nil, fmt.Errorf("create session: %w", err)
-		}
-	}
-
-	s.logEvent(ctx, user.ID, "login", "user logged in", map[string]interface{}{"expires_at": expiresAt})
-	return &LoginResponse{Token: token, ExpiresAt: expiresAt, User: user}, nil
+	s.logEvent(ctx, user.ID, EventLogin, "user logged in", map[string]interface{}{"expires_at": resp.ExpiresAt, "remember_me": req.RememberMe})
+	return resp, nil
 }
 
 func (s *service) Logout(ctx context.Context, token string) error {
-	if s.repos.Sessions == nil || token == "" {
+	if token == "" {
+		return nil
+	}
+	// Tokens that no longer validate are rejected anyway and need no blacklist entry.
+	if claims, err := s.tokenManager.Validate(token); err == nil && claims.ID != "" && claims.ExpiresAt != nil {
+		if err := s.blacklist.Revoke(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+			return fmt.Errorf("revoke token: %w", err)
+		}
+	}
+	if s.repos.Sessions == nil {
 		return nil
 	}
 	return s.repos.Sessions.Delete(ctx, token)
 }
 
 func (s *service) ValidateToken(ctx context.Context, token string) (*User, error) {
-	claims, err := s.tokenManager.Validate(token)
+	claims, err := s.ValidateTokenClaims(ctx, token)
 	if err != nil {
-		return nil, fmt.Errorf("validate token: %w", err)
+		return nil, err
+	}
+	if s.repos.Sessions != nil {
+		session, err := s.repos.Sessions.GetByToken(ctx, token)
+		if err != nil {
+			return nil, fmt.Errorf("fetch session: %w", err)
+		}
+		if session == nil || session.Revoked {
+			return nil, ErrSessionExpired
+		}
 	}
 	user, err := s.repos.Users.GetByID(ctx, claims.UserID)
 	if err != nil {
@@ -171,16 +203,139 @@ func (s *service) ValidateToken(ctx context.Context, token string) (*User, error
 	return user, nil
 }
 
-func (s *service) RefreshToken(ctx context.Context, token string) (*LoginResponse, error) {
-	user, err := s.ValidateToken(ctx, token)
+func (s *service) ValidateTokenClaims(ctx context.Context, token string) (*Claims, error) {
+	claims, err := s.tokenManager.Validate(token)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("validate token: %w", err)
+	}
+	if claims.ID != "" {
+		revoked, err := s.blacklist.IsRevoked(ctx, claims.ID)
+		if err != nil {
+			return nil, fmt.Errorf("check token blacklist: %w", err)
+		}
+		if revoked {
+			return nil, ErrTokenRevoked
+		}
+	}
+	if claims.IssuedAt != nil {
+		cutoff, err := s.blacklist.RevokedBefore(ctx, claims.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("check token blacklist: %w", err)
+		}
+		if claims.IssuedAt.Time.Before(cutoff) {
+			return nil, ErrTokenRevoked
+		}
+	}
+	return claims, nil
+}
+
+func (s *service) RefreshToken(ctx context.Context, refreshToken string) (*LoginResponse, error) {
+	if refreshToken == "" {
+		return nil, fmt.Errorf("%w: refresh token is required", ErrInvalidToken)
+	}
+	if s.repos.RefreshTokens == nil {
+		return nil, errors.New("refresh token repository is required")
 	}
-	newToken, expiresAt, err := s.tokenManager.Generate(user)
+
+	current, err := s.repos.RefreshTokens.GetByToken(ctx, refreshToken)
+	if err != nil {
+		return nil, fmt.Errorf("fetch refresh token: %w", err)
+	}
+	if current == nil || current.Revoked || !s.now().Before(current.ExpiresAt) {
+		return nil, ErrInvalidToken
+	}
+	if current.Used {
+		return nil, s.revokeReusedRefreshToken(ctx, current)
+	}
+
+	user, err := s.repos.Users.GetByID(ctx, current.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch user: %w", err)
+	}
+	marked, err := s.repos.RefreshTokens.MarkUsed(ctx, current.Token)
+	if err != nil {
+		return nil, fmt.Errorf("mark refresh token used: %w", err)
+	}
+	if !marked {
+		// Another refresh used the token between the lookup and now.
+		return nil, s.revokeReusedRefreshToken(ctx, current)
+	}
+
+	resp, err := s.issueTokens(ctx, user, current.FamilyID, current.RememberMe)
 	if err != nil {
 		return nil, err
 	}
-	return &LoginResponse{Token: newToken, ExpiresAt: expiresAt, User: user}, nil
+	s.logEvent(ctx, user.ID, EventTokenRefreshed, "refresh token rotated", map[string]interface{}{"family_id": current.FamilyID})
+	return resp, nil
+}
+
+// revokeReusedRefreshToken handles a rotated refresh token presented again: it assumes the token
+// leaked, revokes the whole chain, and returns ErrRefreshTokenReused.
+func (s *service) revokeReusedRefreshToken(ctx context.Context, token *RefreshToken) error {
+	if err := s.repos.RefreshTokens.RevokeFamily(ctx, token.FamilyID); err != nil {
+		return fmt.Errorf("revoke refresh token family: %w", err)
+	}
+	s.logEvent(ctx, token.UserID, EventRefreshTokenReused, "refresh token reuse detected; session revoked", map[string]interface{}{"family_id": token.FamilyID})
+	return ErrRefreshTokenReused
+}
+
+func (s *service) ListSessions(ctx context.Context, userID string) ([]*Session, error) {
+	if s.repos.Sessions == nil {
+		return nil, errors.New("session repository is required")
+	}
+	sessions, err := s.repos.Sessions.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch sessions: %w", err)
+	}
+	now := s.now()
+	active := make([]*Session, 0, len(sessions))
+	for _, session := range sessions {
+		if session.Revoked || !now.Before(session.ExpiresAt) {
+			continue
+		}
+		active = append(active, session)
+	}
+	return active, nil
+}
+
+func (s *service) RevokeSession(ctx context.Context, token string) error {
+	if s.repos.Sessions == nil {
+		return errors.New("session repository is required")
+	}
+	session, err := s.repos.Sessions.GetByToken(ctx, token)
+	if err != nil {
+		return fmt.Errorf("fetch session: %w", err)
+	}
+	if session == nil {
+		return nil
+	}
+	if err := s.repos.Sessions.Delete(ctx, token); err != nil {
+		return fmt.Errorf("revoke session: %w", err)
+	}
+	s.logEvent(ctx, session.UserID, EventSessionRevoked, "session revoked", nil)
+	return nil
+}
+
+func (s *service) RevokeAllSessions(ctx context.Context, userID string) error {
+	if s.repos.Sessions == nil {
+		return errors.New("session repository is required")
+	}
+	sessions, err := s.repos.Sessions.GetByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("fetch sessions: %w", err)
+	}
+	for _, session := range sessions {
+		if err := s.repos.Sessions.Delete(ctx, session.Token); err != nil {
+			return fmt.Errorf("revoke session: %w", err)
+		}
+	}
+	if s.repos.RefreshTokens != nil {
+		if err := s.repos.RefreshTokens.RevokeByUserID(ctx, userID); err != nil {
+			return fmt.Errorf("revoke refresh tokens: %w", err)
+		}
+	}
+	s.logEvent(ctx, userID, EventSessionsRevoked, "all sessions revoked", map[string]interface{}{"count": len(sessions)})
+	return nil
 }
 
 func (s *service) InitiatePasswordReset(ctx context.Context, email string) (*PasswordResetToken, error) {
@@ -216,7 +371,7 @@ func (s *service) InitiatePasswordReset(ctx context.Context, email string) (*Pas
 	if err := s.repos.PasswordResetTokens.Create(ctx, reset); err != nil {
 		return nil, fmt.Errorf("store reset token: %w", err)
 	}
-	s.logEvent(ctx, user.ID, "password_reset_initiated", "password reset requested", map[string]interface{}{"expires_at": reset.ExpiresAt})
+	s.logEvent(ctx, user.ID, EventPasswordResetInitiated, "password reset requested", map[string]interface{}{"expires_at": reset.ExpiresAt})
 	return reset, nil
 }
 
@@ -227,7 +382,7 @@ func (s *service) CompletePasswordReset(ctx context.Context, token, newPassword
 	if s.repos.PasswordResetTokens == nil {
 		return errors.New("password reset token repository is required")
 	}
-	if err := ValidatePassword(newPassword, s.cfg); err != nil {
+	if err := s.validatePassword(ctx, newPassword); err != nil {
 		return err
 	}
 
@@ -243,23 +398,96 @@ func (s *service) CompletePasswordReset(ctx context.Context, token, newPassword
 	if err != nil {
 		return fmt.Errorf("fetch user: %w", err)
 	}
+	if err := s.checkPasswordHistory(ctx, user, newPassword); err != nil {
+		return err
+	}
 
-	hash, err := HashPassword(newPassword, s.cfg.BcryptCost)
+	hash, err := s.hasher.Hash(newPassword)
 	if err != nil {
 		return err
 	}
+	previousHash := user.PasswordHash
 	user.PasswordHash = hash
 	user.UpdatedAt = s.now().UTC()
 
 	if err := s.repos.Users.Update(ctx, user); err != nil {
 		return fmt.Errorf("update user password: %w", err)
 	}
+	if err := s.recordPasswordHistory(ctx, user.ID, previousHash); err != nil {
+		return err
+	}
 	if err := s.repos.PasswordResetTokens.Delete(ctx, token); err != nil {
 		return fmt.Errorf("delete reset token: %w", err)
 	}
 	_ = s.repos.Users.ResetFailedAttempts(ctx, user.ID)
 	_ = s.repos.Users.UnlockAccount(ctx, user.ID)
-	s.logEvent(ctx, user.ID, "password_reset_completed", "password reset completed", nil)
+	if err := s.invalidateUserTokens(ctx, user.ID); err != nil {
+		return err
+	}
+	s.logEvent(ctx, user.ID, EventPasswordResetCompleted, "password reset completed", nil)
+	return nil
+}
+
+func (s *service) InitiateEmailVerification(ctx context.Context, userID string) (*EmailVerificationToken, error) {
+	if s.repos.EmailVerificationTokens == nil {
+		return nil, errors.New("email verification token repository is required")
+	}
+	user, err := s.repos.Users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch user: %w", err)
+	}
+	if err := s.rateLimit(ctx, fmt.Sprintf("email_verification:%s", user.ID)); err != nil {
+		return nil, err
+	}
+
+	token, err := generateRandomToken(s.cfg.ResetTokenLength)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	verification := &EmailVerificationToken{
+		Token:     token,
+		UserID:    user.ID,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(s.cfg.verificationExpiration()),
+	}
+	if err := s.repos.EmailVerificationTokens.Create(ctx, verification); err != nil {
+		return nil, fmt.Errorf("store verification token: %w", err)
+	}
+	s.logEvent(ctx, user.ID, EventEmailVerificationInitiated, "email verification requested", map[string]interface{}{"expires_at": verification.ExpiresAt})
+	return verification, nil
+}
+
+func (s *service) VerifyEmail(ctx context.Context, token string) error {
+	if token == "" {
+		return fmt.Errorf("%w: token is required", ErrInvalidEmailToken)
+	}
+	if s.repos.EmailVerificationTokens == nil {
+		return errors.New("email verification token repository is required")
+	}
+
+	verification, err := s.repos.EmailVerificationTokens.GetByToken(ctx, token)
+	if err != nil {
+		return fmt.Errorf("fetch verification token: %w", err)
+	}
+	if verification == nil || s.now().After(verification.ExpiresAt) {
+		return ErrInvalidEmailToken
+	}
+
+	user, err := s.repos.Users.GetByID(ctx, verification.UserID)
+	if err != nil {
+		return fmt.Errorf("fetch user: %w", err)
+	}
+	user.EmailVerified = true
+	user.UpdatedAt = s.now().UTC()
+	if err := s.repos.Users.Update(ctx, user); err != nil {
+		return fmt.Errorf("update user: %w", err)
+	}
+	if err := s.repos.EmailVerificationTokens.Delete(ctx, token); err != nil {
+		return fmt.Errorf("delete verification token: %w", err)
+	}
+	s.logEvent(ctx, user.ID, EventEmailVerified, "email address verified", nil)
 	return nil
 }
 
@@ -268,27 +496,37 @@ func (s *service) ChangePassword(ctx context.Context, userID string, oldPassword
 	if err != nil {
 		return fmt.Errorf("fetch user: %w", err)
 	}
-	if err := ComparePassword(user.PasswordHash, oldPassword); err != nil {
+	if err := s.hasher.Compare(user.PasswordHash, oldPassword); err != nil {
 		s.handleFailedAttempt(ctx, user)
 		return ErrInvalidCredentials
 	}
-	if err := ValidatePassword(newPassword, s.cfg); err != nil {
+	if err := s.validatePassword(ctx, newPassword); err != nil {
+		return err
+	}
+	if err := s.checkPasswordHistory(ctx, user, newPassword); err != nil {
 		return err
 	}
 
-	hash, err := HashPassword(newPassword, s.cfg.BcryptCost)
+	hash, err := s.hasher.Hash(newPassword)
 	if err != nil {
 		return err
 	}
+	previousHash := user.PasswordHash
 	user.PasswordHash = hash
 	user.UpdatedAt = s.now().UTC()
 
 	if err := s.repos.Users.Update(ctx, user); err != nil {
 		return fmt.Errorf("update user password: %w", err)
 	}
+	if err := s.recordPasswordHistory(ctx, user.ID, previousHash); err != nil {
+		return err
+	}
 	_ = s.repos.Users.ResetFailedAttempts(ctx, user.ID)
 	_ = s.repos.Users.UnlockAccount(ctx, user.ID)
-	s.logEvent(ctx, user.ID, "password_changed", "password changed", nil)
+	if err := s.invalidateUserTokens(ctx, user.ID); err != nil {
+		return err
+	}
+	s.logEvent(ctx, user.ID, EventPasswordChanged, "password changed", nil)
 	return nil
 }
 
@@ -323,14 +561,99 @@ func (s *service) CheckPermission(ctx context.Context, userID string, permission
 	if err != nil {
 		return false, err
 	}
-	for _, role := range roles {
-		for _, perm := range role.Permissions {
-			if perm == permission {
-				return true, nil
-			}
+	return hasPermission(roles, permission), nil
+}
+
+// issueTokens signs a new access token, records its session, and, when refresh tokens are
+// configured, issues a refresh token belonging to familyID. rememberMe selects the long-lived expiry.
+func (s *service) issueTokens(ctx context.Context, user *User, familyID string, rememberMe bool) (*LoginResponse, error) {
+	ttl := s.cfg.JWTExpirationDuration
+	if rememberMe && s.cfg.JWTRememberMeDuration > 0 {
+		ttl = s.cfg.JWTRememberMeDuration
+	}
+	token, expiresAt, err := s.tokenManager.GenerateWithExpiration(user, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	if s.repos.Sessions != nil {
+		session := &Session{
+			Token:      token,
+			UserID:     user.ID,
+			IssuedAt:   now,
+			ExpiresAt:  expiresAt,
+			RememberMe: rememberMe,
 		}
+		if err := s.repos.Sessions.Create(ctx, session); err != nil {
+			return nil, fmt.Errorf("create session: %w", err)
+		}
+	}
+
+	resp := &LoginResponse{Token: token, ExpiresAt: expiresAt, User: user}
+	if s.repos.RefreshTokens == nil {
+		return resp, nil
+	}
+
+	value, err := generateRandomToken(s.cfg.refreshTokenLength())
+	if err != nil {
+		return nil, err
+	}
+	refresh := &RefreshToken{
+		Token:      value,
+		UserID:     user.ID,
+		FamilyID:   familyID,
+		IssuedAt:   now,
+		ExpiresAt:  now.Add(s.cfg.refreshTokenExpiration()),
+		RememberMe: rememberMe,
+	}
+	if err := s.repos.RefreshTokens.Create(ctx, refresh); err != nil {
+		return nil, fmt.Errorf("store refresh token: %w", err)
 	}
-	return false, nil
+	resp.RefreshToken = refresh.Token
+	resp.RefreshTokenExpiresAt = refresh.ExpiresAt
+	return resp, nil
+}
+
+// invalidateUserTokens logs userID out everywhere after a credential change: JWTs issued so far are
+// rejected via the blacklist, and sessions and refresh tokens are revoked where those repositories exist.
+func (s *service) invalidateUserTokens(ctx context.Context, userID string) error {
+	// iat carries microseconds (see jwt.TimePrecision in token.go), so tokens issued earlier in the
+	// same second fall before the cutoff.
+	cutoff := s.now()
+	until := s.now().Add(max(s.cfg.JWTExpirationDuration, s.cfg.JWTRememberMeDuration))
+	if err := s.blacklist.RevokeUser(ctx, userID, cutoff, until); err != nil {
+		return fmt.Errorf("revoke tokens: %w", err)
+	}
+	if s.repos.Sessions != nil {
+		return s.RevokeAllSessions(ctx, userID)
+	}
+	if s.repos.RefreshTokens != nil {
+		if err := s.repos.RefreshTokens.RevokeByUserID(ctx, userID); err != nil {
+			return fmt.Errorf("revoke refresh tokens: %w", err)
+		}
+	}
+	return nil
+}
+
+// upgradePasswordHash re-hashes the password with the configured Hasher when the stored hash
+// uses another algorithm or outdated parameters. Failures are ignored so login still succeeds.
+func (s *service) upgradePasswordHash(ctx context.Context, user *User, password string) {
+	if !s.hasher.NeedsRehash(user.PasswordHash) {
+		return
+	}
+	hash, err := s.hasher.Hash(password)
+	if err != nil {
+		return
+	}
+	previous := user.PasswordHash
+	user.PasswordHash = hash
+	user.UpdatedAt = s.now().UTC()
+	if err := s.repos.Users.Update(ctx, user); err != nil {
+		user.PasswordHash = previous
+		return
+	}
+	s.logEvent(ctx, user.ID, EventPasswordRehashed, "password hash upgraded", nil)
 }
 
 func (s *service) handleFailedAttempt(ctx context.Context, user *User) {
@@ -339,28 +662,108 @@ func (s *service) handleFailedAttempt(ctx context.Context, user *User) {
 	}
 	user.FailedAttempts++
 	if user.FailedAttempts >= s.cfg.MaxFailedAttempts {
-		if err := s.repos.Users.LockAccount(ctx, user.ID); err == nil {
-			s.logEvent(ctx, user.ID, "account_locked", "account locked due to failed login attempts", nil)
+		duration := s.cfg.lockoutDurationFor(user.LockoutCount)
+		until := s.now().Add(duration)
+		if err := s.repos.Users.LockAccount(ctx, user.ID, until); err == nil {
+			user.LockedUntil = until
+			user.LockoutCount++
+			user.FailedAttempts = 0
+			s.logEvent(ctx, user.ID, EventAccountLocked, "account locked due to failed login attempts", map[string]interface{}{
+				"locked_until":  until,
+				"lockout_count": user.LockoutCount,
+			})
 		}
 	}
 }
 
+// passwordHistoryEnabled reports whether password changes are checked against previous passwords.
+func (s *service) passwordHistoryEnabled() bool {
+	return s.cfg.PasswordHistorySize > 0 && s.repos.PasswordHistory != nil
+}
+
+// checkPasswordHistory rejects password if it is the user's current password or one of the last
+// PasswordHistorySize ones.
+func (s *service) checkPasswordHistory(ctx context.Context, user *User, password string) error {
+	if !s.passwordHistoryEnabled() {
+		return nil
+	}
+	hashes := []string{user.PasswordHash}
+	history, err := s.repos.PasswordHistory.GetByUserID(ctx, user.ID, s.cfg.PasswordHistorySize)
+	if err != nil {
+		return fmt.Errorf("fetch password history: %w", err)
+	}
+	for _, entry := range history {
+		hashes = append(hashes, entry.PasswordHash)
+	}
+	for _, hash := range hashes {
+		if hash != "" && s.hasher.Compare(hash, password) == nil {
+			return ErrPasswordReused
+		}
+	}
+	return nil
+}
+
+// recordPasswordHistory stores the hash a password change replaced and trims the user's history.
+func (s *service) recordPasswordHistory(ctx context.Context, userID, previousHash string) error {
+	if !s.passwordHistoryEnabled() || previousHash == "" {
+		return nil
+	}
+	entry := &PasswordHistory{UserID: userID, PasswordHash: previousHash, CreatedAt: s.now().UTC()}
+	if err := s.repos.PasswordHistory.Create(ctx, entry); err != nil {
+		return fmt.Errorf("record password history: %w", err)
+	}
+	if err := s.repos.PasswordHistory.Trim(ctx, userID, s.cfg.PasswordHistorySize); err != nil {
+		return fmt.Errorf("trim password history: %w", err)
+	}
+	return nil
+}
+
+// validatePassword applies the complexity rules and then the configured PasswordPolicy.
+func (s *service) validatePassword(ctx context.Context, password string) error {
+	if err := ValidatePassword(password, s.cfg); err != nil {
+		return err
+	}
+	if s.cfg.PasswordPolicy != nil {
+		return s.cfg.PasswordPolicy.Check(ctx, password)
+	}
+	return nil
+}
+
 func (s *service) rateLimit(ctx context.Context, key string) error {
 	if s.limiter == nil || key == "" {
 		return nil
 	}
-	if !s.limiter.Allow(key) {
-		s.logEvent(ctx, "", "rate_limit_exceeded", "rate limit exceeded", map[string]interface{}{"key": key})
+	allowed, err := s.limiter.AllowContext(ctx, key)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		s.logEvent(ctx, "", EventRateLimitExceeded, "rate limit exceeded", map[string]interface{}{"key": key})
 		return ErrRateLimitExceeded
 	}
 	return nil
 }
 
-func (s *service) logEvent(ctx context.Context, userID, action, message string, metadata map[string]interface{}) {
-	if s.audit == nil {
+func (s *service) logEvent(ctx context.Context, userID string, eventType EventType, message string, metadata map[string]interface{}) {
+	if s.audit == nil && s.cfg.AuditSink == nil {
 		return
 	}
-	_ = s.audit.Log(ctx, userID, action, message, metadata)
+	ip, userAgent := RequestInfoFromContext(ctx)
+	event := AuditEvent{
+		Type:      eventType,
+		UserID:    userID,
+		IP:        ip,
+		UserAgent: userAgent,
+		Message:   message,
+		Metadata:  metadata,
+		Timestamp: s.now().UTC(),
+	}
+	if s.audit != nil {
+		_ = s.audit.Record(ctx, event)
+	}
+	if s.cfg.AuditSink != nil {
+		_ = s.cfg.AuditSink.Record(ctx, event)
+	}
 }
 
 func generateRandomToken(length int) (string, error) {
@@ -369,7 +772,7 @@ func generateRandomToken(length int) (string, error) {
 	}
 	buffer := make([]byte, length)
 	if _, err := rand.Read(buffer); err != nil {
-		return "", fmt.Errorf("generate reset token: %w", err)
+		return "", fmt.Errorf("generate random token: %w", err)
 	}
 	token := hex.EncodeToString(buffer)
 	if len(token) > length {
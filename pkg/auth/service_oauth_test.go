@@ -0,0 +1,159 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rompi/core-backend/pkg/auth"
+	"github.com/rompi/core-backend/pkg/auth/testutil"
+)
+
+// oauthFixture holds in-memory users and linked accounts for OAuth login tests.
+type oauthFixture struct {
+	users    map[string]*auth.User
+	accounts map[string]*auth.OAuthAccount
+	creates  int
+}
+
+// newOAuthTestService returns a service whose "stub" provider resolves the codes in identities.
+func newOAuthTestService(t *testing.T, identities map[string]*auth.OAuthIdentity) (auth.Service, *oauthFixture) {
+	t.Helper()
+	f := &oauthFixture{
+		users:    make(map[string]*auth.User),
+		accounts: make(map[string]*auth.OAuthAccount),
+	}
+
+	cfg := newTestConfig()
+	cfg.OAuthProviders = map[string]auth.OAuthProvider{
+		"stub": auth.OAuthProviderFunc(func(ctx context.Context, code string) (*auth.OAuthIdentity, error) {
+			identity, ok := identities[code]
+			if !ok {
+				return nil, errors.New("invalid_grant")
+			}
+			return identity, nil
+		}),
+	}
+
+	repos := auth.Repositories{
+		Users: &testutil.MockUserRepository{
+			CreateFunc: func(ctx context.Context, user *auth.User) error {
+				f.creates++
+				f.users[user.ID] = user
+				return nil
+			},
+			GetByIDFunc: func(ctx context.Context, id string) (*auth.User, error) {
+				if user, ok := f.users[id]; ok {
+					return user, nil
+				}
+				return nil, auth.ErrUserNotFound
+			},
+			GetByEmailFunc: func(ctx context.Context, email string) (*auth.User, error) {
+				for _, user := range f.users {
+					if user.Email == email {
+						return user, nil
+					}
+				}
+				return nil, auth.ErrUserNotFound
+			},
+		},
+		OAuthAccounts: &testutil.MockOAuthAccountRepository{
+			CreateFunc: func(ctx context.Context, account *auth.OAuthAccount) error {
+				f.accounts[account.Provider+"|"+account.Subject] = account
+				return nil
+			},
+			GetByProviderSubjectFunc: func(ctx context.Context, provider, subject string) (*auth.OAuthAccount, error) {
+				if account, ok := f.accounts[provider+"|"+subject]; ok {
+					return account, nil
+				}
+				return nil, auth.ErrOAuthAccountNotFound
+			},
+		},
+	}
+
+	return newTestService(t, cfg, repos), f
+}
+
+func TestService_LoginWithOAuth_FirstAndSubsequentLogin(t *testing.T) {
+	svc, f := newOAuthTestService(t, map[string]*auth.OAuthIdentity{
+		"code-1": {Subject: "sub-123", Email: "New.User@Example.com", EmailVerified: true},
+		"code-2": {Subject: "sub-123", Email: "changed@example.com", EmailVerified: true},
+	})
+	ctx := context.Background()
+
+	first, err := svc.LoginWithOAuth(ctx, "stub", "code-1")
+	if err != nil {
+		t.Fatalf("LoginWithOAuth() first error = %v", err)
+	}
+	if first.Token == "" {
+		t.Fatal("expected access token")
+	}
+	if f.creates != 1 {
+		t.Fatalf("expected 1 user created, got %d", f.creates)
+	}
+	if first.User.Email != "new.user@example.com" || !first.User.EmailVerified {
+		t.Fatalf("unexpected user %+v", first.User)
+	}
+	account := f.accounts["stub|sub-123"]
+	if account == nil || account.UserID != first.User.ID {
+		t.Fatalf("expected linked account for user %s, got %+v", first.User.ID, account)
+	}
+
+	// The subject, not the email, identifies the user on later logins
+	second, err := svc.LoginWithOAuth(ctx, "stub", "code-2")
+	if err != nil {
+		t.Fatalf("LoginWithOAuth() second error = %v", err)
+	}
+	if f.creates != 1 {
+		t.Fatalf("expected no new user, got %d creates", f.creates)
+	}
+	if second.User.ID != first.User.ID {
+		t.Fatalf("expected user %s, got %s", first.User.ID, second.User.ID)
+	}
+
+	claims, err := svc.ValidateTokenClaims(ctx, second.Token)
+	if err != nil {
+		t.Fatalf("ValidateTokenClaims() error = %v", err)
+	}
+	if claims.UserID != first.User.ID {
+		t.Fatalf("token user = %s, want %s", claims.UserID, first.User.ID)
+	}
+}
+
+func TestService_LoginWithOAuth_LinksExistingUser(t *testing.T) {
+	svc, f := newOAuthTestService(t, map[string]*auth.OAuthIdentity{
+		"verified":   {Subject: "sub-verified", Email: "existing@example.com", EmailVerified: true},
+		"unverified": {Subject: "sub-unverified", Email: "existing@example.com"},
+	})
+	f.users["user-1"] = &auth.User{ID: "user-1", Email: "existing@example.com"}
+	ctx := context.Background()
+
+	if _, err := svc.LoginWithOAuth(ctx, "stub", "unverified"); !errors.Is(err, auth.ErrUserAlreadyExists) {
+		t.Fatalf("expected ErrUserAlreadyExists for unverified email, got %v", err)
+	}
+
+	resp, err := svc.LoginWithOAuth(ctx, "stub", "verified")
+	if err != nil {
+		t.Fatalf("LoginWithOAuth() error = %v", err)
+	}
+	if resp.User.ID != "user-1" || f.creates != 0 {
+		t.Fatalf("expected existing user to be linked, got %s with %d creates", resp.User.ID, f.creates)
+	}
+}
+
+func TestService_LoginWithOAuth_Errors(t *testing.T) {
+	svc, _ := newOAuthTestService(t, map[string]*auth.OAuthIdentity{
+		"no-subject": {Email: "user@example.com"},
+	})
+	ctx := context.Background()
+
+	if _, err := svc.LoginWithOAuth(ctx, "unknown", "code"); !errors.Is(err, auth.ErrOAuthProviderNotFound) {
+		t.Fatalf("expected ErrOAuthProviderNotFound, got %v", err)
+	}
+	if _, err := svc.LoginWithOAuth(ctx, "stub", "bad-code"); err == nil {
+		t.Fatal("expected exchange error")
+	}
+	if _, err := svc.LoginWithOAuth(ctx, "stub", "no-subject"); !errors.Is(err, auth.ErrInvalidOAuthIdentity) {
+		t.Fatalf("expected ErrInvalidOAuthIdentity, got %v", err)
+	}
+}
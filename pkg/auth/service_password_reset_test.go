@@ -2,6 +2,7 @@ package auth_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -145,6 +146,99 @@ func TestService_ChangePassword(t *testing.T) {
 	}
 }
 
+// newPasswordHistoryStore returns an in-memory PasswordHistoryRepository, newest entry first.
+func newPasswordHistoryStore() *testutil.MockPasswordHistoryRepository {
+	var entries []*auth.PasswordHistory
+	return &testutil.MockPasswordHistoryRepository{
+		CreateFunc: func(ctx context.Context, entry *auth.PasswordHistory) error {
+			entries = append([]*auth.PasswordHistory{entry}, entries...)
+			return nil
+		},
+		GetByUserIDFunc: func(ctx context.Context, userID string, limit int) ([]*auth.PasswordHistory, error) {
+			return entries[:min(limit, len(entries))], nil
+		},
+		TrimFunc: func(ctx context.Context, userID string, keep int) error {
+			entries = entries[:min(keep, len(entries))]
+			return nil
+		},
+	}
+}
+
+func TestService_ChangePasswordHistory(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.PasswordHistorySize = 2
+
+	hashed, _ := auth.HashPassword("Passw0rd!0", cfg.BcryptCost)
+	user := &auth.User{ID: "user-1", PasswordHash: hashed}
+	users := &testutil.MockUserRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*auth.User, error) {
+			return user, nil
+		},
+	}
+	svc, err := auth.NewService(cfg, auth.Repositories{Users: users, PasswordHistory: newPasswordHistoryStore()})
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+	ctx := context.Background()
+
+	passwords := []string{"Passw0rd!0", "Passw0rd!1", "Passw0rd!2", "Passw0rd!3"}
+	for i := 1; i < len(passwords); i++ {
+		if err := svc.ChangePassword(ctx, user.ID, passwords[i-1], passwords[i]); err != nil {
+			t.Fatalf("ChangePassword(%s -> %s) error = %v", passwords[i-1], passwords[i], err)
+		}
+	}
+
+	// History now holds Passw0rd!2 and Passw0rd!1; Passw0rd!0 has been trimmed.
+	for _, reused := range []string{"Passw0rd!3", "Passw0rd!2", "Passw0rd!1"} {
+		if err := svc.ChangePassword(ctx, user.ID, "Passw0rd!3", reused); !errors.Is(err, auth.ErrPasswordReused) {
+			t.Errorf("ChangePassword() to %s error = %v, want ErrPasswordReused", reused, err)
+		}
+	}
+	if err := svc.ChangePassword(ctx, user.ID, "Passw0rd!3", "Passw0rd!0"); err != nil {
+		t.Fatalf("ChangePassword() to a password beyond the history window error = %v", err)
+	}
+}
+
+func TestService_CompletePasswordResetHistory(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.PasswordHistorySize = 3
+
+	hashed, _ := auth.HashPassword("OldPass1!", cfg.BcryptCost)
+	user := &auth.User{ID: "user-1", PasswordHash: hashed}
+	history := newPasswordHistoryStore()
+	svc, err := auth.NewService(cfg, auth.Repositories{
+		Users: &testutil.MockUserRepository{
+			GetByIDFunc: func(ctx context.Context, id string) (*auth.User, error) {
+				return user, nil
+			},
+		},
+		PasswordResetTokens: &testutil.MockPasswordResetTokenRepository{
+			GetByTokenFunc: func(ctx context.Context, token string) (*auth.PasswordResetToken, error) {
+				return &auth.PasswordResetToken{Token: token, UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)}, nil
+			},
+		},
+		PasswordHistory: history,
+	})
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+	ctx := context.Background()
+
+	if err := svc.CompletePasswordReset(ctx, "reset-token", "OldPass1!"); !errors.Is(err, auth.ErrPasswordReused) {
+		t.Fatalf("CompletePasswordReset() with the current password error = %v, want ErrPasswordReused", err)
+	}
+	if err := svc.CompletePasswordReset(ctx, "reset-token", "New!Pass1"); err != nil {
+		t.Fatalf("CompletePasswordReset() error = %v", err)
+	}
+	entries, _ := history.GetByUserID(ctx, user.ID, cfg.PasswordHistorySize)
+	if len(entries) != 1 || entries[0].PasswordHash != hashed {
+		t.Fatalf("password history = %v, want the replaced hash", entries)
+	}
+	if err := svc.CompletePasswordReset(ctx, "reset-token", "OldPass1!"); !errors.Is(err, auth.ErrPasswordReused) {
+		t.Fatalf("CompletePasswordReset() with a previous password error = %v, want ErrPasswordReused", err)
+	}
+}
+
 func TestService_ValidateAPIKeyAndPermissions(t *testing.T) {
 	cfg := newTestConfig()
 	cfg.JWTSecret = "secret"
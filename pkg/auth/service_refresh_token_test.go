@@ -0,0 +1,148 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rompi/core-backend/pkg/auth"
+	"github.com/rompi/core-backend/pkg/auth/testutil"
+)
+
+// newRefreshTokenStore returns a mock repository backed by an in-memory map.
+func newRefreshTokenStore() (*testutil.MockRefreshTokenRepository, map[string]*auth.RefreshToken) {
+	store := make(map[string]*auth.RefreshToken)
+	repo := &testutil.MockRefreshTokenRepository{
+		CreateFunc: func(ctx context.Context, token *auth.RefreshToken) error {
+			copied := *token
+			store[token.Token] = &copied
+			return nil
+		},
+		GetByTokenFunc: func(ctx context.Context, token string) (*auth.RefreshToken, error) {
+			stored, ok := store[token]
+			if !ok {
+				return nil, nil
+			}
+			copied := *stored
+			return &copied, nil
+		},
+		MarkUsedFunc: func(ctx context.Context, token string) (bool, error) {
+			stored, ok := store[token]
+			if !ok || stored.Used {
+				return false, nil
+			}
+			stored.Used = true
+			return true, nil
+		},
+		RevokeFamilyFunc: func(ctx context.Context, familyID string) error {
+			for _, stored := range store {
+				if stored.FamilyID == familyID {
+					stored.Revoked = true
+				}
+			}
+			return nil
+		},
+	}
+	return repo, store
+}
+
+func TestService_RefreshTokenRotation(t *testing.T) {
+	repo, store := newRefreshTokenStore()
+	svc := newTestService(t, nil, auth.Repositories{RefreshTokens: repo})
+	ctx := context.Background()
+
+	login, err := svc.Login(ctx, auth.LoginRequest{Email: "user@example.com", Password: "Str0ng!Pass"})
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if login.RefreshToken == "" {
+		t.Fatal("expected refresh token on login")
+	}
+
+	refreshed, err := svc.RefreshToken(ctx, login.RefreshToken)
+	if err != nil {
+		t.Fatalf("RefreshToken() error = %v", err)
+	}
+	if refreshed.Token == "" || refreshed.RefreshToken == "" {
+		t.Fatal("expected new access and refresh tokens")
+	}
+	if refreshed.RefreshToken == login.RefreshToken {
+		t.Fatal("expected refresh token to rotate")
+	}
+	if !store[login.RefreshToken].Used {
+		t.Fatal("expected old refresh token to be marked used")
+	}
+	if store[refreshed.RefreshToken].FamilyID != store[login.RefreshToken].FamilyID {
+		t.Fatal("expected rotated token to stay in the same family")
+	}
+
+	if _, err := svc.RefreshToken(ctx, refreshed.RefreshToken); err != nil {
+		t.Fatalf("RefreshToken() with rotated token error = %v", err)
+	}
+}
+
+func TestService_RefreshTokenReuseRevokesChain(t *testing.T) {
+	repo, _ := newRefreshTokenStore()
+	svc := newTestService(t, nil, auth.Repositories{RefreshTokens: repo})
+	ctx := context.Background()
+
+	login, err := svc.Login(ctx, auth.LoginRequest{Email: "user@example.com", Password: "Str0ng!Pass"})
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	refreshed, err := svc.RefreshToken(ctx, login.RefreshToken)
+	if err != nil {
+		t.Fatalf("RefreshToken() error = %v", err)
+	}
+
+	if _, err := svc.RefreshToken(ctx, login.RefreshToken); !errors.Is(err, auth.ErrRefreshTokenReused) {
+		t.Fatalf("expected ErrRefreshTokenReused, got %v", err)
+	}
+	if _, err := svc.RefreshToken(ctx, refreshed.RefreshToken); !errors.Is(err, auth.ErrInvalidToken) {
+		t.Fatalf("expected revoked chain to reject latest token, got %v", err)
+	}
+}
+
+func TestService_RefreshTokenRejectsUnknownToken(t *testing.T) {
+	repo, _ := newRefreshTokenStore()
+	svc := newTestService(t, nil, auth.Repositories{RefreshTokens: repo})
+
+	if _, err := svc.RefreshToken(context.Background(), "unknown"); !errors.Is(err, auth.ErrInvalidToken) {
+		t.Fatalf("expected ErrInvalidToken, got %v", err)
+	}
+}
+
+func TestService_RefreshTokenConcurrentReuse(t *testing.T) {
+	repo, _ := newRefreshTokenStore()
+	svc := newTestService(t, nil, auth.Repositories{RefreshTokens: repo})
+	ctx := context.Background()
+
+	login, err := svc.Login(ctx, auth.LoginRequest{Email: "user@example.com", Password: "Str0ng!Pass"})
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	// Let a second refresh with the same token win between the lookup and MarkUsed.
+	getByToken := repo.GetByTokenFunc
+	var raced *auth.LoginResponse
+	racing := false
+	repo.GetByTokenFunc = func(ctx context.Context, token string) (*auth.RefreshToken, error) {
+		current, err := getByToken(ctx, token)
+		if token == login.RefreshToken && !racing {
+			racing = true
+			var refreshErr error
+			raced, refreshErr = svc.RefreshToken(ctx, token)
+			if refreshErr != nil {
+				t.Fatalf("concurrent RefreshToken() error = %v", refreshErr)
+			}
+		}
+		return current, err
+	}
+
+	if _, err := svc.RefreshToken(ctx, login.RefreshToken); !errors.Is(err, auth.ErrRefreshTokenReused) {
+		t.Fatalf("expected ErrRefreshTokenReused, got %v", err)
+	}
+	if _, err := svc.RefreshToken(ctx, raced.RefreshToken); !errors.Is(err, auth.ErrInvalidToken) {
+		t.Fatalf("expected revoked chain to reject the concurrent winner's token, got %v", err)
+	}
+}
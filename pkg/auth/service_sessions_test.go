@@ -0,0 +1,250 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rompi/core-backend/pkg/auth"
+	"github.com/rompi/core-backend/pkg/auth/testutil"
+)
+
+func newSessionStore() *testutil.MockSessionRepository {
+	store := make(map[string]*auth.Session)
+	return &testutil.MockSessionRepository{
+		CreateFunc: func(ctx context.Context, session *auth.Session) error {
+			store[session.Token] = session
+			return nil
+		},
+		GetByTokenFunc: func(ctx context.Context, token string) (*auth.Session, error) {
+			return store[token], nil
+		},
+		GetByUserIDFunc: func(ctx context.Context, userID string) ([]*auth.Session, error) {
+			var sessions []*auth.Session
+			for _, session := range store {
+				if session.UserID == userID {
+					sessions = append(sessions, session)
+				}
+			}
+			return sessions, nil
+		},
+		DeleteFunc: func(ctx context.Context, token string) error {
+			delete(store, token)
+			return nil
+		},
+	}
+}
+
+func TestService_RevokeSession(t *testing.T) {
+	svc := newTestService(t, nil, auth.Repositories{Sessions: newSessionStore()})
+	ctx := context.Background()
+
+	var tokens []string
+	for i := 0; i < 3; i++ {
+		resp, err := svc.Login(ctx, auth.LoginRequest{Email: "user@example.com", Password: "Str0ng!Pass"})
+		if err != nil {
+			t.Fatalf("Login() error = %v", err)
+		}
+		tokens = append(tokens, resp.Token)
+	}
+
+	sessions, err := svc.ListSessions(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("ListSessions() error = %v", err)
+	}
+	if len(sessions) != 3 {
+		t.Fatalf("expected 3 sessions, got %d", len(sessions))
+	}
+
+	if err := svc.RevokeSession(ctx, tokens[1]); err != nil {
+		t.Fatalf("RevokeSession() error = %v", err)
+	}
+	if _, err := svc.ValidateToken(ctx, tokens[1]); !errors.Is(err, auth.ErrSessionExpired) {
+		t.Fatalf("expected revoked token to fail with ErrSessionExpired, got %v", err)
+	}
+	for _, token := range []string{tokens[0], tokens[2]} {
+		if _, err := svc.ValidateToken(ctx, token); err != nil {
+			t.Fatalf("ValidateToken() for unrevoked session error = %v", err)
+		}
+	}
+
+	sessions, err = svc.ListSessions(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("ListSessions() error = %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions after revocation, got %d", len(sessions))
+	}
+}
+
+func TestService_RevokeAllSessions(t *testing.T) {
+	refreshRevoked := ""
+	svc := newTestService(t, nil, auth.Repositories{
+		Sessions: newSessionStore(),
+		RefreshTokens: &testutil.MockRefreshTokenRepository{
+			RevokeByUserIDFunc: func(ctx context.Context, userID string) error {
+				refreshRevoked = userID
+				return nil
+			},
+		},
+	})
+	ctx := context.Background()
+
+	var tokens []string
+	for i := 0; i < 2; i++ {
+		resp, err := svc.Login(ctx, auth.LoginRequest{Email: "user@example.com", Password: "Str0ng!Pass"})
+		if err != nil {
+			t.Fatalf("Login() error = %v", err)
+		}
+		tokens = append(tokens, resp.Token)
+	}
+
+	if err := svc.RevokeAllSessions(ctx, "user-1"); err != nil {
+		t.Fatalf("RevokeAllSessions() error = %v", err)
+	}
+	for _, token := range tokens {
+		if _, err := svc.ValidateToken(ctx, token); !errors.Is(err, auth.ErrSessionExpired) {
+			t.Fatalf("expected ErrSessionExpired, got %v", err)
+		}
+	}
+	if refreshRevoked != "user-1" {
+		t.Fatalf("expected refresh tokens of user-1 to be revoked, got %q", refreshRevoked)
+	}
+}
+
+func TestService_LoginRememberMe(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.RateLimitMaxRequests = 100
+	cfg.JWTRememberMeDuration = 30 * 24 * time.Hour
+	sessions := newSessionStore()
+	svc := newTestService(t, cfg, auth.Repositories{Sessions: sessions})
+	ctx := context.Background()
+
+	short, err := svc.Login(ctx, auth.LoginRequest{Email: "user@example.com", Password: "Str0ng!Pass"})
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	long, err := svc.Login(ctx, auth.LoginRequest{Email: "user@example.com", Password: "Str0ng!Pass", RememberMe: true})
+	if err != nil {
+		t.Fatalf("Login(RememberMe) error = %v", err)
+	}
+
+	if got := time.Until(short.ExpiresAt); got > cfg.JWTExpirationDuration {
+		t.Errorf("regular login expires in %v, want at most %v", got, cfg.JWTExpirationDuration)
+	}
+	if got := time.Until(long.ExpiresAt); got <= cfg.JWTExpirationDuration || got > cfg.JWTRememberMeDuration {
+		t.Errorf("remember-me login expires in %v, want about %v", got, cfg.JWTRememberMeDuration)
+	}
+
+	stored, err := sessions.GetByToken(ctx, long.Token)
+	if err != nil || stored == nil {
+		t.Fatalf("GetByToken() = %v, %v", stored, err)
+	}
+	if !stored.RememberMe || !stored.ExpiresAt.Equal(long.ExpiresAt) {
+		t.Errorf("stored session = %+v, want RememberMe with the token's expiry", stored)
+	}
+	if _, err := svc.ValidateToken(ctx, long.Token); err != nil {
+		t.Errorf("ValidateToken() error = %v", err)
+	}
+}
+
+func TestService_LogoutRevokesToken(t *testing.T) {
+	svc := newTestService(t, nil, auth.Repositories{})
+	ctx := context.Background()
+
+	loggedOut, err := svc.Login(ctx, auth.LoginRequest{Email: "user@example.com", Password: "Str0ng!Pass"})
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	other, err := svc.Login(ctx, auth.LoginRequest{Email: "user@example.com", Password: "Str0ng!Pass"})
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if _, err := svc.ValidateToken(ctx, loggedOut.Token); err != nil {
+		t.Fatalf("ValidateToken() before logout error = %v", err)
+	}
+
+	if err := svc.Logout(ctx, loggedOut.Token); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+	if _, err := svc.ValidateToken(ctx, loggedOut.Token); !errors.Is(err, auth.ErrTokenRevoked) {
+		t.Fatalf("ValidateToken() after logout error = %v, want ErrTokenRevoked", err)
+	}
+	if _, err := svc.ValidateTokenClaims(ctx, loggedOut.Token); !errors.Is(err, auth.ErrTokenRevoked) {
+		t.Fatalf("ValidateTokenClaims() after logout error = %v, want ErrTokenRevoked", err)
+	}
+	if _, err := svc.ValidateToken(ctx, other.Token); err != nil {
+		t.Fatalf("ValidateToken() for another token error = %v", err)
+	}
+}
+
+func TestService_ChangePasswordRevokesTokens(t *testing.T) {
+	svc := newTestService(t, nil, auth.Repositories{})
+	ctx := context.Background()
+
+	// Start at the top of a second so the login and the password change share it.
+	time.Sleep(time.Until(time.Now().Truncate(time.Second).Add(time.Second)))
+	before, err := svc.Login(ctx, auth.LoginRequest{Email: "user@example.com", Password: "Str0ng!Pass"})
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if err := svc.ChangePassword(ctx, "user-1", "Str0ng!Pass", "N3w!Passw0rd"); err != nil {
+		t.Fatalf("ChangePassword() error = %v", err)
+	}
+	if _, err := svc.ValidateToken(ctx, before.Token); !errors.Is(err, auth.ErrTokenRevoked) {
+		t.Fatalf("ValidateToken() for token issued before change error = %v, want ErrTokenRevoked", err)
+	}
+	if _, err := svc.ValidateTokenClaims(ctx, before.Token); !errors.Is(err, auth.ErrTokenRevoked) {
+		t.Fatalf("ValidateTokenClaims() for token issued before change error = %v, want ErrTokenRevoked", err)
+	}
+
+	after, err := svc.Login(ctx, auth.LoginRequest{Email: "user@example.com", Password: "N3w!Passw0rd"})
+	if err != nil {
+		t.Fatalf("Login() with new password error = %v", err)
+	}
+	if _, err := svc.ValidateToken(ctx, after.Token); err != nil {
+		t.Fatalf("ValidateToken() for token issued after change error = %v", err)
+	}
+}
+
+func TestService_CompletePasswordResetRevokesSessions(t *testing.T) {
+	revokedRefresh := ""
+	svc := newTestService(t, nil, auth.Repositories{
+		Sessions: newSessionStore(),
+		PasswordResetTokens: &testutil.MockPasswordResetTokenRepository{
+			GetByTokenFunc: func(ctx context.Context, token string) (*auth.PasswordResetToken, error) {
+				return &auth.PasswordResetToken{Token: token, UserID: "user-1", ExpiresAt: time.Now().Add(time.Hour)}, nil
+			},
+		},
+		RefreshTokens: &testutil.MockRefreshTokenRepository{
+			RevokeByUserIDFunc: func(ctx context.Context, userID string) error {
+				revokedRefresh = userID
+				return nil
+			},
+		},
+	})
+	ctx := context.Background()
+
+	login, err := svc.Login(ctx, auth.LoginRequest{Email: "user@example.com", Password: "Str0ng!Pass"})
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if err := svc.CompletePasswordReset(ctx, "reset-token", "N3w!Passw0rd"); err != nil {
+		t.Fatalf("CompletePasswordReset() error = %v", err)
+	}
+
+	if _, err := svc.ValidateToken(ctx, login.Token); err == nil {
+		t.Fatal("ValidateToken() succeeded for a session started before the reset")
+	}
+	sessions, err := svc.ListSessions(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("ListSessions() error = %v", err)
+	}
+	if len(sessions) != 0 {
+		t.Fatalf("ListSessions() = %d sessions, want 0", len(sessions))
+	}
+	if revokedRefresh != "user-1" {
+		t.Fatalf("refresh tokens revoked for %q, want user-1", revokedRefresh)
+	}
+}
@@ -3,6 +3,7 @@ package auth_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -29,10 +30,69 @@ func newTestConfig() *auth.Config {
 		RateLimitMaxRequests:   5,
 		ResetTokenLength:       32,
 		ResetTokenExpiration:   time.Minute,
+		VerificationExpiration: time.Hour,
+		RefreshTokenLength:     64,
+		RefreshTokenExpiration: time.Hour,
 		DefaultLanguage:        "en",
 	}
 }
 
+// newTestService builds a service from cfg and repos. A nil cfg is newTestConfig with a rate limit
+// high enough for repeated logins. A nil repos.Users is a repository holding one user, "user-1"
+// (user@example.com, password "Str0ng!Pass").
+func newTestService(t *testing.T, cfg *auth.Config, repos auth.Repositories) auth.Service {
+	t.Helper()
+	if cfg == nil {
+		cfg = newTestConfig()
+		cfg.RateLimitMaxRequests = 100
+	}
+	if repos.Users == nil {
+		hash, err := auth.HashPassword("Str0ng!Pass", cfg.BcryptCost)
+		if err != nil {
+			t.Fatalf("HashPassword() error = %v", err)
+		}
+		user := &auth.User{ID: "user-1", Email: "user@example.com", PasswordHash: hash}
+		repos.Users = &testutil.MockUserRepository{
+			GetByEmailFunc: func(ctx context.Context, email string) (*auth.User, error) {
+				return user, nil
+			},
+			GetByIDFunc: func(ctx context.Context, id string) (*auth.User, error) {
+				return user, nil
+			},
+		}
+	}
+	svc, err := auth.NewService(cfg, repos)
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+	return svc
+}
+
+func TestNewService_AcceptsConfigWithoutTokenSettings(t *testing.T) {
+	// A config written before verification and refresh tokens existed must keep working.
+	cfg := &auth.Config{
+		JWTSecret:              "secret",
+		JWTExpirationDuration:  24 * time.Hour,
+		JWTIssuer:              "rompi-auth",
+		PasswordMinLength:      8,
+		PasswordRequireUpper:   true,
+		PasswordRequireLower:   true,
+		PasswordRequireNumber:  true,
+		PasswordRequireSpecial: true,
+		BcryptCost:             4,
+		MaxFailedAttempts:      5,
+		LockoutDuration:        15 * time.Minute,
+		RateLimitWindow:        time.Minute,
+		RateLimitMaxRequests:   20,
+		ResetTokenLength:       32,
+		ResetTokenExpiration:   time.Hour,
+		DefaultLanguage:        "en",
+	}
+	if _, err := auth.NewService(cfg, auth.Repositories{Users: &testutil.MockUserRepository{}}); err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+}
+
 func TestService_RegisterSuccess(t *testing.T) {
 	cfg := newTestConfig()
 
@@ -87,6 +147,32 @@ func TestService_RegisterConflict(t *testing.T) {
 	}
 }
 
+func TestService_RegisterPasswordPolicy(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.PasswordPolicy = &auth.StrengthPolicy{MinScore: 3}
+
+	users := &testutil.MockUserRepository{
+		GetByEmailFunc: func(ctx context.Context, email string) (*auth.User, error) {
+			return nil, auth.ErrUserNotFound
+		},
+	}
+
+	svc, err := auth.NewService(cfg, auth.Repositories{Users: users})
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+
+	// Passes the character-class rules but is a common password
+	_, err = svc.Register(context.Background(), auth.RegisterRequest{Email: "weak@example.com", Password: "Password1!"})
+	if !errors.Is(err, auth.ErrWeakPassword) {
+		t.Fatalf("expected ErrWeakPassword, got %v", err)
+	}
+
+	if _, err := svc.Register(context.Background(), auth.RegisterRequest{Email: "strong@example.com", Password: "correct-Horse-battery-staple-9"}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+}
+
 func TestService_LoginSuccess(t *testing.T) {
 	cfg := newTestConfig()
 	hash, err := auth.HashPassword("Str0ng!Pass", cfg.BcryptCost)
@@ -216,3 +302,217 @@ func TestService_RapidLoginProducesUniqueSessions(t *testing.T) {
 		t.Fatalf("expected 5 unique sessions, got %d", len(tokens))
 	}
 }
+
+func TestService_LoginUpgradesPasswordHash(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.PasswordHashAlgorithm = auth.HashAlgorithmArgon2id
+	legacy, err := auth.HashPassword("Str0ng!Pass", cfg.BcryptCost)
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+
+	var updatedHash string
+	users := &testutil.MockUserRepository{
+		GetByEmailFunc: func(ctx context.Context, email string) (*auth.User, error) {
+			return &auth.User{ID: "user-1", Email: email, PasswordHash: legacy}, nil
+		},
+		UpdateFunc: func(ctx context.Context, user *auth.User) error {
+			updatedHash = user.PasswordHash
+			return nil
+		},
+	}
+
+	svc, err := auth.NewService(cfg, auth.Repositories{Users: users})
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+	if _, err := svc.Login(context.Background(), auth.LoginRequest{Email: "user@example.com", Password: "Str0ng!Pass"}); err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if !strings.HasPrefix(updatedHash, "$argon2id$") {
+		t.Fatalf("expected stored hash to be upgraded to argon2id, got %q", updatedHash)
+	}
+	if err := auth.ComparePassword(updatedHash, "Str0ng!Pass"); err != nil {
+		t.Fatalf("upgraded hash does not verify: %v", err)
+	}
+}
+
+func TestService_LoginUpgradeKeepsFailedAttemptsReset(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.RateLimitMaxRequests = 100
+	cfg.PasswordHashAlgorithm = auth.HashAlgorithmArgon2id
+	legacy, err := auth.HashPassword("Str0ng!Pass", cfg.BcryptCost)
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+
+	stored := auth.User{ID: "user-1", Email: "user@example.com", PasswordHash: legacy, LockoutCount: 1}
+	users := &testutil.MockUserRepository{
+		GetByEmailFunc: func(ctx context.Context, email string) (*auth.User, error) {
+			copied := stored
+			return &copied, nil
+		},
+		IncrementFailedAttemptsFunc: func(ctx context.Context, userID string) error {
+			stored.FailedAttempts++
+			return nil
+		},
+		ResetFailedAttemptsFunc: func(ctx context.Context, userID string) error {
+			stored.FailedAttempts = 0
+			stored.LockoutCount = 0
+			stored.LockedUntil = time.Time{}
+			return nil
+		},
+		UpdateFunc: func(ctx context.Context, user *auth.User) error {
+			stored = *user
+			return nil
+		},
+	}
+
+	svc, err := auth.NewService(cfg, auth.Repositories{Users: users})
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+	ctx := context.Background()
+	if _, err := svc.Login(ctx, auth.LoginRequest{Email: stored.Email, Password: "Wr0ng!Pass"}); !errors.Is(err, auth.ErrInvalidCredentials) {
+		t.Fatalf("Login() with wrong password error = %v, want ErrInvalidCredentials", err)
+	}
+	if _, err := svc.Login(ctx, auth.LoginRequest{Email: stored.Email, Password: "Str0ng!Pass"}); err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	if !strings.HasPrefix(stored.PasswordHash, "$argon2id$") {
+		t.Fatalf("expected stored hash to be upgraded to argon2id, got %q", stored.PasswordHash)
+	}
+	if stored.FailedAttempts != 0 || stored.LockoutCount != 0 || !stored.LockedUntil.IsZero() {
+		t.Fatalf("rehash restored lockout state: failed=%d lockouts=%d locked_until=%v",
+			stored.FailedAttempts, stored.LockoutCount, stored.LockedUntil)
+	}
+}
+
+func TestService_LockoutBackoffEscalates(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.RateLimitMaxRequests = 100
+	cfg.MaxFailedAttempts = 2
+	cfg.LockoutDuration = time.Minute
+	cfg.LockoutMultiplier = 2
+	cfg.LockoutMaxDuration = 3 * time.Minute
+	hash, err := auth.HashPassword("Str0ng!Pass", cfg.BcryptCost)
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+
+	stored := auth.User{ID: "user-1", Email: "user@example.com", PasswordHash: hash}
+	var lockouts []time.Duration
+	users := &testutil.MockUserRepository{
+		GetByEmailFunc: func(ctx context.Context, email string) (*auth.User, error) {
+			copied := stored
+			return &copied, nil
+		},
+		IncrementFailedAttemptsFunc: func(ctx context.Context, userID string) error {
+			stored.FailedAttempts++
+			return nil
+		},
+		LockAccountFunc: func(ctx context.Context, userID string, until time.Time) error {
+			lockouts = append(lockouts, time.Until(until).Round(time.Minute))
+			// Leave LockedUntil unset so the next attempt simulates an expired lock.
+			stored.LockoutCount++
+			stored.FailedAttempts = 0
+			return nil
+		},
+		ResetFailedAttemptsFunc: func(ctx context.Context, userID string) error {
+			stored.FailedAttempts = 0
+			stored.LockoutCount = 0
+			return nil
+		},
+	}
+
+	svc, err := auth.NewService(cfg, auth.Repositories{Users: users})
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+
+	ctx := context.Background()
+	fail := func(times int) {
+		for i := 0; i < times; i++ {
+			if _, err := svc.Login(ctx, auth.LoginRequest{Email: stored.Email, Password: "Wr0ng!Pass"}); !errors.Is(err, auth.ErrInvalidCredentials) {
+				t.Fatalf("expected ErrInvalidCredentials, got %v", err)
+			}
+		}
+	}
+
+	fail(8)
+	want := []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute, 3 * time.Minute}
+	if len(lockouts) != len(want) {
+		t.Fatalf("lockouts = %v, want %v", lockouts, want)
+	}
+	for i := range want {
+		if lockouts[i] != want[i] {
+			t.Fatalf("lockout %d = %v, want %v", i+1, lockouts[i], want[i])
+		}
+	}
+
+	if _, err := svc.Login(ctx, auth.LoginRequest{Email: stored.Email, Password: "Str0ng!Pass"}); err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	fail(2)
+	if got := lockouts[len(lockouts)-1]; got != time.Minute {
+		t.Fatalf("lockout after successful login = %v, want %v", got, time.Minute)
+	}
+}
+
+// sharedCounterStore simulates a store shared by several service replicas.
+type sharedCounterStore struct {
+	counts map[string]int64
+	ttls   map[string]time.Duration
+}
+
+func (s *sharedCounterStore) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	if _, ok := s.ttls[key]; !ok {
+		s.ttls[key] = ttl
+	}
+	s.counts[key]++
+	return s.counts[key], nil
+}
+
+func (s *sharedCounterStore) Get(ctx context.Context, key string) (int64, error) {
+	return s.counts[key], nil
+}
+
+func TestService_RateLimitSharedStore(t *testing.T) {
+	store := &sharedCounterStore{counts: map[string]int64{}, ttls: map[string]time.Duration{}}
+	users := &testutil.MockUserRepository{
+		GetByEmailFunc: func(ctx context.Context, email string) (*auth.User, error) {
+			return &auth.User{ID: "user-1", Email: email, PasswordHash: "invalid"}, nil
+		},
+	}
+
+	var replicas []auth.Service
+	for i := 0; i < 2; i++ {
+		cfg := newTestConfig()
+		cfg.RateLimitMaxRequests = 3
+		cfg.RateLimiterStore = store
+		svc, err := auth.NewService(cfg, auth.Repositories{Users: users})
+		if err != nil {
+			t.Fatalf("NewService() error = %v", err)
+		}
+		replicas = append(replicas, svc)
+	}
+
+	ctx := context.Background()
+	req := auth.LoginRequest{Email: "user@example.com", Password: "wrong"}
+	for i := 0; i < 3; i++ {
+		if _, err := replicas[i%2].Login(ctx, req); !errors.Is(err, auth.ErrInvalidCredentials) {
+			t.Fatalf("attempt %d: expected ErrInvalidCredentials, got %v", i+1, err)
+		}
+	}
+	for _, svc := range replicas {
+		if _, err := svc.Login(ctx, req); !errors.Is(err, auth.ErrRateLimitExceeded) {
+			t.Fatalf("expected ErrRateLimitExceeded, got %v", err)
+		}
+	}
+	for key, ttl := range store.ttls {
+		if ttl != time.Second {
+			t.Fatalf("key %s ttl = %v, want the configured window", key, ttl)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package testutil
 
 import (
 	"context"
+	"time"
 
 	"github.com/rompi/core-backend/pkg/auth"
 )
@@ -15,7 +16,7 @@ type MockUserRepository struct {
 	DeleteFunc                  func(ctx context.Context, id string) error
 	IncrementFailedAttemptsFunc func(ctx context.Context, userID string) error
 	ResetFailedAttemptsFunc     func(ctx context.Context, userID string) error
-	LockAccountFunc             func(ctx context.Context, userID string) error
+	LockAccountFunc             func(ctx context.Context, userID string, until time.Time) error
 	UnlockAccountFunc           func(ctx context.Context, userID string) error
 }
 
@@ -76,9 +77,9 @@ func (m *MockUserRepository) ResetFailedAttempts(ctx context.Context, userID str
 }
 
 // LockAccount delegates to LockAccountFunc if provided.
-func (m *MockUserRepository) LockAccount(ctx context.Context, userID string) error {
+func (m *MockUserRepository) LockAccount(ctx context.Context, userID string, until time.Time) error {
 	if m.LockAccountFunc != nil {
-		return m.LockAccountFunc(ctx, userID)
+		return m.LockAccountFunc(ctx, userID, until)
 	}
 	return nil
 }
@@ -272,3 +273,154 @@ func (m *MockAPIKeyRepository) GetByKey(ctx context.Context, key string) (*auth.
 	}
 	return nil, nil
 }
+
+// MockRefreshTokenRepository provides stub implementations for refresh token persistence.
+type MockRefreshTokenRepository struct {
+	CreateFunc         func(ctx context.Context, token *auth.RefreshToken) error
+	GetByTokenFunc     func(ctx context.Context, token string) (*auth.RefreshToken, error)
+	MarkUsedFunc       func(ctx context.Context, token string) (bool, error)
+	RevokeFamilyFunc   func(ctx context.Context, familyID string) error
+	RevokeByUserIDFunc func(ctx context.Context, userID string) error
+	DeleteExpiredFunc  func(ctx context.Context) error
+}
+
+// Create delegates to CreateFunc if provided.
+func (m *MockRefreshTokenRepository) Create(ctx context.Context, token *auth.RefreshToken) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, token)
+	}
+	return nil
+}
+
+// GetByToken delegates to GetByTokenFunc if provided.
+func (m *MockRefreshTokenRepository) GetByToken(ctx context.Context, token string) (*auth.RefreshToken, error) {
+	if m.GetByTokenFunc != nil {
+		return m.GetByTokenFunc(ctx, token)
+	}
+	return nil, nil
+}
+
+// MarkUsed delegates to MarkUsedFunc if provided.
+func (m *MockRefreshTokenRepository) MarkUsed(ctx context.Context, token string) (bool, error) {
+	if m.MarkUsedFunc != nil {
+		return m.MarkUsedFunc(ctx, token)
+	}
+	return true, nil
+}
+
+// RevokeFamily delegates to RevokeFamilyFunc if provided.
+func (m *MockRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	if m.RevokeFamilyFunc != nil {
+		return m.RevokeFamilyFunc(ctx, familyID)
+	}
+	return nil
+}
+
+// RevokeByUserID delegates to RevokeByUserIDFunc if provided.
+func (m *MockRefreshTokenRepository) RevokeByUserID(ctx context.Context, userID string) error {
+	if m.RevokeByUserIDFunc != nil {
+		return m.RevokeByUserIDFunc(ctx, userID)
+	}
+	return nil
+}
+
+// DeleteExpired delegates to DeleteExpiredFunc if provided.
+func (m *MockRefreshTokenRepository) DeleteExpired(ctx context.Context) error {
+	if m.DeleteExpiredFunc != nil {
+		return m.DeleteExpiredFunc(ctx)
+	}
+	return nil
+}
+
+// MockEmailVerificationTokenRepository provides stub implementations for email verification tokens.
+type MockEmailVerificationTokenRepository struct {
+	CreateFunc        func(ctx context.Context, token *auth.EmailVerificationToken) error
+	GetByTokenFunc    func(ctx context.Context, token string) (*auth.EmailVerificationToken, error)
+	DeleteFunc        func(ctx context.Context, token string) error
+	DeleteExpiredFunc func(ctx context.Context) error
+}
+
+// Create delegates to CreateFunc if provided.
+func (m *MockEmailVerificationTokenRepository) Create(ctx context.Context, token *auth.EmailVerificationToken) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, token)
+	}
+	return nil
+}
+
+// GetByToken delegates to GetByTokenFunc if provided.
+func (m *MockEmailVerificationTokenRepository) GetByToken(ctx context.Context, token string) (*auth.EmailVerificationToken, error) {
+	if m.GetByTokenFunc != nil {
+		return m.GetByTokenFunc(ctx, token)
+	}
+	return nil, nil
+}
+
+// Delete delegates to DeleteFunc if provided.
+func (m *MockEmailVerificationTokenRepository) Delete(ctx context.Context, token string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, token)
+	}
+	return nil
+}
+
+// DeleteExpired delegates to DeleteExpiredFunc if provided.
+func (m *MockEmailVerificationTokenRepository) DeleteExpired(ctx context.Context) error {
+	if m.DeleteExpiredFunc != nil {
+		return m.DeleteExpiredFunc(ctx)
+	}
+	return nil
+}
+
+// MockOAuthAccountRepository provides stub implementations for linked OAuth accounts.
+type MockOAuthAccountRepository struct {
+	CreateFunc               func(ctx context.Context, account *auth.OAuthAccount) error
+	GetByProviderSubjectFunc func(ctx context.Context, provider, subject string) (*auth.OAuthAccount, error)
+}
+
+// Create delegates to CreateFunc if provided.
+func (m *MockOAuthAccountRepository) Create(ctx context.Context, account *auth.OAuthAccount) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, account)
+	}
+	return nil
+}
+
+// GetByProviderSubject delegates to GetByProviderSubjectFunc if provided.
+func (m *MockOAuthAccountRepository) GetByProviderSubject(ctx context.Context, provider, subject string) (*auth.OAuthAccount, error) {
+	if m.GetByProviderSubjectFunc != nil {
+		return m.GetByProviderSubjectFunc(ctx, provider, subject)
+	}
+	return nil, nil
+}
+
+// MockPasswordHistoryRepository provides stub implementations for previous password hashes.
+type MockPasswordHistoryRepository struct {
+	CreateFunc      func(ctx context.Context, entry *auth.PasswordHistory) error
+	GetByUserIDFunc func(ctx context.Context, userID string, limit int) ([]*auth.PasswordHistory, error)
+	TrimFunc        func(ctx context.Context, userID string, keep int) error
+}
+
+// Create delegates to CreateFunc if provided.
+func (m *MockPasswordHistoryRepository) Create(ctx context.Context, entry *auth.PasswordHistory) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, entry)
+	}
+	return nil
+}
+
+// GetByUserID delegates to GetByUserIDFunc if provided.
+func (m *MockPasswordHistoryRepository) GetByUserID(ctx context.Context, userID string, limit int) ([]*auth.PasswordHistory, error) {
+	if m.GetByUserIDFunc != nil {
+		return m.GetByUserIDFunc(ctx, userID, limit)
+	}
+	return nil, nil
+}
+
+// Trim delegates to TrimFunc if provided.
+func (m *MockPasswordHistoryRepository) Trim(ctx context.Context, userID string, keep int) error {
+	if m.TrimFunc != nil {
+		return m.TrimFunc(ctx, userID, keep)
+	}
+	return nil
+}
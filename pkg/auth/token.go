@@ -1,54 +1,218 @@
 package auth
 
 import (
+	"crypto"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
+// Supported values for Config.JWTAlgorithm.
+const (
+	JWTAlgorithmHS256 = "HS256"
+	JWTAlgorithmRS256 = "RS256"
+	JWTAlgorithmES256 = "ES256"
+)
+
+var errNoSigningKey = errors.New("token signing key is not configured")
+
+func init() {
+	// Issue iat with sub-second precision so a credential change revokes tokens issued earlier in
+	// the same second; see invalidateUserTokens. exp stays in whole seconds.
+	jwt.TimePrecision = time.Microsecond
+}
+
+// ClaimsEnricher returns additional claims to embed in a user's JWT (e.g. tenant ID or roles).
+type ClaimsEnricher func(user *User) map[string]interface{}
+
+// reservedClaims cannot be overridden by a ClaimsEnricher.
+var reservedClaims = map[string]struct{}{
+	"iss": {}, "sub": {}, "aud": {}, "exp": {}, "nbf": {}, "iat": {}, "jti": {},
+	"user_id": {}, "email": {}, "roles": {},
+}
+
 // Claims encapsulates JWT payload data emitted by the auth package.
 type Claims struct {
 	jwt.RegisteredClaims
 	UserID string   `json:"user_id"`
 	Email  string   `json:"email"`
 	Roles  []string `json:"roles,omitempty"`
+
+	custom map[string]interface{}
+}
+
+// CustomClaims returns the non-reserved claims added by a ClaimsEnricher.
+func (c *Claims) CustomClaims() map[string]interface{} {
+	custom := make(map[string]interface{}, len(c.custom))
+	for key, value := range c.custom {
+		custom[key] = value
+	}
+	return custom
+}
+
+// MarshalJSON flattens custom claims into the JWT payload alongside the standard ones.
+func (c Claims) MarshalJSON() ([]byte, error) {
+	type plain Claims
+	base, err := json.Marshal(plain(c))
+	if err != nil || len(c.custom) == 0 {
+		return base, err
+	}
+	merged := map[string]json.RawMessage{}
+	if err := json.Unmarshal(base, &merged); err != nil {
+		return nil, err
+	}
+	for key, value := range c.custom {
+		if _, exists := merged[key]; exists {
+			continue
+		}
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("encoding claim %q: %w", key, err)
+		}
+		merged[key] = raw
+	}
+	return json.Marshal(merged)
+}
+
+// UnmarshalJSON decodes the standard claims and keeps every other claim as a custom claim.
+func (c *Claims) UnmarshalJSON(data []byte) error {
+	type plain Claims
+	if err := json.Unmarshal(data, (*plain)(c)); err != nil {
+		return err
+	}
+	all := map[string]interface{}{}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	for key := range reservedClaims {
+		delete(all, key)
+	}
+	c.custom = nil
+	if len(all) > 0 {
+		c.custom = all
+	}
+	return nil
 }
 
 // TokenManager handles JWT creation and validation.
 type TokenManager struct {
-	secret     []byte
+	method     jwt.SigningMethod
+	signingKey interface{}
+	verifyKey  interface{}
+	keyErr     error
 	issuer     string
+	audience   []string
 	expiration time.Duration
+	enricher   ClaimsEnricher
 }
 
 // NewTokenManager returns a TokenManager configured for the provided settings.
+// Key errors are reported by Generate and Validate; Config.Validate surfaces them earlier.
 func NewTokenManager(cfg *Config) *TokenManager {
+	method, signingKey, verifyKey, err := parseSigningKeys(cfg)
 	return &TokenManager{
-		secret:     []byte(cfg.JWTSecret),
+		method:     method,
+		signingKey: signingKey,
+		verifyKey:  verifyKey,
+		keyErr:     err,
 		issuer:     cfg.JWTIssuer,
+		audience:   cfg.JWTAudience,
 		expiration: cfg.JWTExpirationDuration,
+		enricher:   cfg.ClaimsEnricher,
 	}
 }
 
+// parseSigningKeys resolves the signing method and keys for cfg. For RS256/ES256 the signing key is
+// nil when only a public key is configured, and the verify key is derived from the private key if needed.
+func parseSigningKeys(cfg *Config) (jwt.SigningMethod, interface{}, interface{}, error) {
+	alg := cfg.jwtAlgorithm()
+	switch alg {
+	case JWTAlgorithmHS256:
+		secret := []byte(cfg.JWTSecret)
+		return jwt.SigningMethodHS256, secret, secret, nil
+	case JWTAlgorithmRS256, JWTAlgorithmES256:
+	default:
+		return nil, nil, nil, fmt.Errorf("unsupported JWT algorithm %q", cfg.JWTAlgorithm)
+	}
+
+	method := jwt.SigningMethod(jwt.SigningMethodRS256)
+	if alg == JWTAlgorithmES256 {
+		method = jwt.SigningMethodES256
+	}
+
+	var (
+		signingKey crypto.Signer
+		verifyKey  crypto.PublicKey
+		err        error
+	)
+	if pem := strings.TrimSpace(cfg.JWTPrivateKeyPEM); pem != "" {
+		if signingKey, err = parsePrivateKeyPEM(alg, []byte(pem)); err != nil {
+			return nil, nil, nil, fmt.Errorf("AUTH_JWT_PRIVATE_KEY: %w", err)
+		}
+		verifyKey = signingKey.Public()
+	}
+	if pem := strings.TrimSpace(cfg.JWTPublicKeyPEM); pem != "" {
+		if verifyKey, err = parsePublicKeyPEM(alg, []byte(pem)); err != nil {
+			return nil, nil, nil, fmt.Errorf("AUTH_JWT_PUBLIC_KEY: %w", err)
+		}
+	}
+	if verifyKey == nil {
+		return nil, nil, nil, fmt.Errorf("AUTH_JWT_PRIVATE_KEY or AUTH_JWT_PUBLIC_KEY is required for %s", alg)
+	}
+	return method, signingKey, verifyKey, nil
+}
+
+func parsePrivateKeyPEM(alg string, data []byte) (crypto.Signer, error) {
+	if alg == JWTAlgorithmES256 {
+		return jwt.ParseECPrivateKeyFromPEM(data)
+	}
+	return jwt.ParseRSAPrivateKeyFromPEM(data)
+}
+
+func parsePublicKeyPEM(alg string, data []byte) (crypto.PublicKey, error) {
+	if alg == JWTAlgorithmES256 {
+		return jwt.ParseECPublicKeyFromPEM(data)
+	}
+	return jwt.ParseRSAPublicKeyFromPEM(data)
+}
+
 // Generate creates a signed token for the supplied user and returns the token plus expiration time.
 func (m *TokenManager) Generate(user *User) (string, time.Time, error) {
+	return m.GenerateWithExpiration(user, m.expiration)
+}
+
+// GenerateWithExpiration is like Generate but the token expires after ttl instead of the configured duration.
+func (m *TokenManager) GenerateWithExpiration(user *User, ttl time.Duration) (string, time.Time, error) {
+	if m.keyErr != nil {
+		return "", time.Time{}, fmt.Errorf("signing token: %w", m.keyErr)
+	}
+	if m.signingKey == nil {
+		return "", time.Time{}, fmt.Errorf("signing token: %w", errNoSigningKey)
+	}
 	now := time.Now().UTC()
-	expiration := now.Add(m.expiration)
+	expiration := now.Add(ttl)
 	claims := Claims{
 		RegisteredClaims: jwt.RegisteredClaims{
 			ID:        uuid.NewString(),
 			Issuer:    m.issuer,
+			Audience:  jwt.ClaimStrings(m.audience),
 			Subject:   user.ID,
 			IssuedAt:  jwt.NewNumericDate(now),
-			ExpiresAt: jwt.NewNumericDate(expiration),
+			ExpiresAt: jwt.NewNumericDate(expiration.Truncate(time.Second)),
 		},
 		UserID: user.ID,
 		Email:  user.Email,
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, err := token.SignedString(m.secret)
+	if m.enricher != nil {
+		claims.applyCustom(m.enricher(user))
+	}
+	token := jwt.NewWithClaims(m.method, claims)
+	signed, err := token.SignedString(m.signingKey)
 	if err != nil {
 		return "", time.Time{}, fmt.Errorf("signing token: %w", err)
 	}
@@ -57,12 +221,20 @@ func (m *TokenManager) Generate(user *User) (string, time.Time, error) {
 
 // Validate parses and verifies a JWT token, returning the embedded claims.
 func (m *TokenManager) Validate(token string) (*Claims, error) {
+	if m.keyErr != nil {
+		return nil, fmt.Errorf("parsing token: %w", m.keyErr)
+	}
+	var opts []jwt.ParserOption
+	if len(m.audience) > 0 {
+		// Accepts tokens whose aud claim contains any configured audience; a missing aud is rejected
+		opts = append(opts, jwt.WithAudience(m.audience...))
+	}
 	parsed, err := jwt.ParseWithClaims(token, &Claims{}, func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %T", t.Method)
+		if t.Method.Alg() != m.method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %s", t.Method.Alg())
 		}
-		return m.secret, nil
-	})
+		return m.verifyKey, nil
+	}, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("parsing token: %w", err)
 	}
@@ -72,3 +244,23 @@ func (m *TokenManager) Validate(token string) (*Claims, error) {
 	}
 	return claims, nil
 }
+
+// applyCustom merges enricher output into the claims. A "roles" entry of type []string fills Roles;
+// every other reserved claim is ignored so enrichers cannot override expiry, issuer, or subject.
+func (c *Claims) applyCustom(extra map[string]interface{}) {
+	for key, value := range extra {
+		if key == "roles" {
+			if roles, ok := value.([]string); ok {
+				c.Roles = roles
+			}
+			continue
+		}
+		if _, reserved := reservedClaims[key]; reserved {
+			continue
+		}
+		if c.custom == nil {
+			c.custom = make(map[string]interface{})
+		}
+		c.custom[key] = value
+	}
+}
@@ -1,6 +1,14 @@
 package auth
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
@@ -118,3 +126,193 @@ func TestTokenManager_Generate_ContainsJTI(t *testing.T) {
 		t.Fatal("expected jti claim to be set")
 	}
 }
+
+func TestTokenManager_ClaimsEnricher(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.JWTSecret = "super-secret"
+	cfg.JWTExpirationDuration = time.Minute
+	cfg.ClaimsEnricher = func(user *User) map[string]interface{} {
+		return map[string]interface{}{
+			"tenant_id": "tenant-42",
+			"roles":     []string{"admin"},
+			"sub":       "attacker",
+			"iss":       "evil",
+			"exp":       time.Now().Add(100 * 24 * time.Hour).Unix(),
+		}
+	}
+
+	manager := NewTokenManager(cfg)
+	user := &User{ID: "user-1", Email: "test@rompi.com"}
+
+	token, expiresAt, err := manager.Generate(user)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	claims, err := manager.Validate(token)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	custom := claims.CustomClaims()
+	if custom["tenant_id"] != "tenant-42" {
+		t.Fatalf("tenant_id = %v, want tenant-42", custom["tenant_id"])
+	}
+	if len(claims.Roles) != 1 || claims.Roles[0] != "admin" {
+		t.Fatalf("roles = %v, want [admin]", claims.Roles)
+	}
+	if claims.Subject != user.ID || claims.Issuer != cfg.JWTIssuer {
+		t.Fatalf("reserved claims overridden: sub=%s iss=%s", claims.Subject, claims.Issuer)
+	}
+	if !claims.ExpiresAt.Time.Equal(expiresAt.Truncate(time.Second)) {
+		t.Fatalf("exp = %v, want %v", claims.ExpiresAt.Time, expiresAt)
+	}
+	for _, key := range []string{"sub", "iss", "exp", "roles"} {
+		if _, ok := custom[key]; ok {
+			t.Fatalf("reserved claim %q leaked into custom claims", key)
+		}
+	}
+}
+
+func generateKeyPEMs(t *testing.T, alg string) (string, string) {
+	t.Helper()
+	var (
+		signer crypto.Signer
+		err    error
+	)
+	if alg == JWTAlgorithmES256 {
+		signer, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	} else {
+		signer, err = rsa.GenerateKey(rand.Reader, 2048)
+	}
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	privDER, err := x509.MarshalPKCS8PrivateKey(signer)
+	if err != nil {
+		t.Fatalf("marshal private key: %v", err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		t.Fatalf("marshal public key: %v", err)
+	}
+	privPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER})
+	pubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})
+	return string(privPEM), string(pubPEM)
+}
+
+func TestTokenManager_AsymmetricAlgorithms(t *testing.T) {
+	for _, alg := range []string{JWTAlgorithmRS256, JWTAlgorithmES256} {
+		t.Run(alg, func(t *testing.T) {
+			privPEM, pubPEM := generateKeyPEMs(t, alg)
+			otherPriv, _ := generateKeyPEMs(t, alg)
+
+			cfg := defaultConfig()
+			cfg.JWTAlgorithm = alg
+			cfg.JWTPrivateKeyPEM = privPEM
+			if err := cfg.Validate(); err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			signer := NewTokenManager(cfg)
+			user := &User{ID: "user-1", Email: "test@rompi.com"}
+			token, _, err := signer.Generate(user)
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			verifyCfg := defaultConfig()
+			verifyCfg.JWTAlgorithm = alg
+			verifyCfg.JWTPublicKeyPEM = pubPEM
+			if err := verifyCfg.Validate(); err != nil {
+				t.Fatalf("verify-only Validate() error = %v", err)
+			}
+			verifier := NewTokenManager(verifyCfg)
+			claims, err := verifier.Validate(token)
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if claims.UserID != user.ID {
+				t.Fatalf("claims user id = %s, want %s", claims.UserID, user.ID)
+			}
+			if _, _, err := verifier.Generate(user); !errors.Is(err, errNoSigningKey) {
+				t.Fatalf("expected verify-only manager to refuse signing, got %v", err)
+			}
+
+			otherCfg := defaultConfig()
+			otherCfg.JWTAlgorithm = alg
+			otherCfg.JWTPrivateKeyPEM = otherPriv
+			forged, _, err := NewTokenManager(otherCfg).Generate(user)
+			if err != nil {
+				t.Fatalf("Generate() with other key error = %v", err)
+			}
+			if _, err := verifier.Validate(forged); err == nil {
+				t.Fatal("expected token signed with the wrong key to be rejected")
+			}
+		})
+	}
+}
+
+func TestTokenManager_RejectsAlgorithmMismatch(t *testing.T) {
+	_, pubPEM := generateKeyPEMs(t, JWTAlgorithmRS256)
+
+	hmacCfg := defaultConfig()
+	hmacCfg.JWTSecret = "super-secret"
+	token, _, err := NewTokenManager(hmacCfg).Generate(&User{ID: "user-1"})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	cfg := defaultConfig()
+	cfg.JWTAlgorithm = JWTAlgorithmRS256
+	cfg.JWTPublicKeyPEM = pubPEM
+	if _, err := NewTokenManager(cfg).Validate(token); err == nil {
+		t.Fatal("expected HS256 token to be rejected by an RS256 manager")
+	}
+}
+
+func TestTokenManager_Audience(t *testing.T) {
+	newManager := func(audience ...string) *TokenManager {
+		cfg := defaultConfig()
+		cfg.JWTSecret = "super-secret"
+		cfg.JWTAudience = audience
+		return NewTokenManager(cfg)
+	}
+	user := &User{ID: "user-1", Email: "test@rompi.com"}
+
+	issuer := newManager("orders-api", "billing-api")
+	token, _, err := issuer.Generate(user)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	claims, err := issuer.Validate(token)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if got := []string(claims.Audience); len(got) != 2 || got[0] != "orders-api" || got[1] != "billing-api" {
+		t.Fatalf("aud = %v, want [orders-api billing-api]", got)
+	}
+
+	unscoped, _, err := newManager().Generate(user)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		audience []string
+		token    string
+		wantErr  bool
+	}{
+		{name: "matching audience", audience: []string{"billing-api", "reports-api"}, token: token},
+		{name: "non-matching audience", audience: []string{"reports-api"}, token: token, wantErr: true},
+		{name: "missing audience", audience: []string{"orders-api"}, token: unscoped, wantErr: true},
+		{name: "audience not configured", token: token},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newManager(tt.audience...).Validate(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
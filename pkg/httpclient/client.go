@@ -3,6 +3,7 @@ package httpclient
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 )
@@ -16,6 +17,7 @@ type Client struct {
 	retryPolicy    *RetryPolicy
 	circuitBreaker *CircuitBreaker
 	logger         Logger
+	clientTrace    bool
 }
 
 // Config holds configuration options for creating a new HTTP client.
@@ -23,7 +25,8 @@ type Config struct {
 	// BaseURL is the base URL for all requests (e.g., "https://api.example.com").
 	BaseURL string
 
-	// Timeout is the maximum duration for a request (default: 30s).
+	// Timeout is the maximum duration of each attempt, including reading the response body
+	// (default: 30s).
 	Timeout time.Duration
 
 	// MaxRetries is the maximum number of retry attempts (default: 3).
@@ -35,6 +38,11 @@ type Config struct {
 	// RetryWaitMax is the maximum wait time between retries (default: 30s).
 	RetryWaitMax time.Duration
 
+	// MaxElapsedTime bounds the total time spent on a request across all attempts and the waits
+	// between them (default: unlimited). No retry is started that would begin after the budget runs
+	// out, and an attempt in flight is cut off when the budget ends.
+	MaxElapsedTime time.Duration
+
 	// CircuitBreaker is the circuit breaker configuration (optional).
 	CircuitBreaker *CircuitBreakerConfig
 
@@ -44,8 +52,25 @@ type Config struct {
 	// Transport is the HTTP transport to use (default: http.DefaultTransport).
 	Transport http.RoundTripper
 
+	// MaxIdleConns limits idle keep-alive connections across all hosts (default: 100).
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost limits idle keep-alive connections per host (default: 2).
+	// Raise it for high-throughput clients that talk to few hosts.
+	MaxIdleConnsPerHost int
+
+	// MaxConnsPerHost limits all connections per host, including active ones (default: unlimited).
+	MaxConnsPerHost int
+
+	// IdleConnTimeout is how long an idle keep-alive connection stays open (default: 90s).
+	IdleConnTimeout time.Duration
+
 	// FollowRedirects controls whether to follow redirects (default: true).
 	FollowRedirects bool
+
+	// ClientTrace records DNS, connect, TLS, and time-to-first-byte durations of each request in
+	// Response.Timing (default: false).
+	ClientTrace bool
 }
 
 // New creates a new HTTP client with the provided configuration.
@@ -77,9 +102,10 @@ func New(cfg Config) (*Client, error) {
 
 	// Create retry policy
 	retryPolicy := &RetryPolicy{
-		MaxRetries:   cfg.MaxRetries,
-		RetryWaitMin: cfg.RetryWaitMin,
-		RetryWaitMax: cfg.RetryWaitMax,
+		MaxRetries:     cfg.MaxRetries,
+		RetryWaitMin:   cfg.RetryWaitMin,
+		RetryWaitMax:   cfg.RetryWaitMax,
+		MaxElapsedTime: cfg.MaxElapsedTime,
 	}
 
 	// Create circuit breaker if configured
@@ -95,6 +121,7 @@ func New(cfg Config) (*Client, error) {
 		retryPolicy:    retryPolicy,
 		circuitBreaker: cb,
 		logger:         cfg.Logger,
+		clientTrace:    cfg.ClientTrace,
 	}
 
 	return client, nil
@@ -134,6 +161,16 @@ func (c *Client) Delete(ctx context.Context, path string) *RequestBuilder {
 	return newRequestBuilder(c, ctx, http.MethodDelete, path)
 }
 
+// Head creates a HEAD request builder.
+func (c *Client) Head(ctx context.Context, path string) *RequestBuilder {
+	return newRequestBuilder(c, ctx, http.MethodHead, path)
+}
+
+// Options creates an OPTIONS request builder.
+func (c *Client) Options(ctx context.Context, path string) *RequestBuilder {
+	return newRequestBuilder(c, ctx, http.MethodOptions, path)
+}
+
 // Use adds a middleware to the client's middleware chain.
 // Middleware are executed in the order they are added.
 func (c *Client) Use(mw Middleware) {
@@ -161,10 +198,12 @@ func (c *Client) do(req *http.Request) (*http.Response, error) {
 // executeWithRetry executes the request with retry logic.
 func (c *Client) executeWithRetry(req *http.Request) (*http.Response, error) {
 	var lastErr error
+	start := time.Now()
 
 	for attempt := 0; attempt <= c.retryPolicy.MaxRetries; attempt++ {
-		// Clone the request for retry
-		reqClone := req.Clone(req.Context())
+		// Clone the request for retry, bounding this attempt by Timeout and the retry budget
+		ctx, cancel := c.attemptContext(req.Context(), time.Since(start))
+		reqClone := req.Clone(ctx)
 
 		// Build middleware chain
 		handler := c.buildMiddlewareChain()
@@ -175,16 +214,35 @@ func (c *Client) executeWithRetry(req *http.Request) (*http.Response, error) {
 		// Check if we should retry
 		if !c.retryPolicy.ShouldRetry(resp, err) {
 			if err != nil {
+				cancel()
 				return nil, err
 			}
+			if resp != nil && resp.Body != nil {
+				// The timeout covers reading the body, as with http.Client.Timeout
+				resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+			} else {
+				cancel()
+			}
 			return resp, nil
 		}
 
+		if resp != nil && resp.Body != nil {
+			resp.Body.Close()
+		}
+		cancel()
 		lastErr = err
 
 		// Don't wait after the last attempt
 		if attempt < c.retryPolicy.MaxRetries {
 			waitDuration := c.retryPolicy.Backoff(attempt)
+			if elapsed := time.Since(start); !c.retryPolicy.withinBudget(elapsed + waitDuration) {
+				c.logger.Debug("retry budget exhausted",
+					"attempt", attempt+1,
+					"elapsed", elapsed,
+					"url", req.URL.String(),
+				)
+				break
+			}
 			c.logger.Debug("retrying request",
 				"attempt", attempt+1,
 				"wait", waitDuration,
@@ -207,6 +265,33 @@ func (c *Client) executeWithRetry(req *http.Request) (*http.Response, error) {
 	return nil, ErrMaxRetriesExceeded
 }
 
+// attemptContext returns the context for one attempt, which ends after Timeout or when the rest of
+// MaxElapsedTime runs out, whichever comes first.
+func (c *Client) attemptContext(ctx context.Context, elapsed time.Duration) (context.Context, context.CancelFunc) {
+	timeout := c.httpClient.Timeout
+	if budget := c.retryPolicy.MaxElapsedTime; budget > 0 {
+		if remaining := budget - elapsed; timeout <= 0 || remaining < timeout {
+			timeout = remaining
+		}
+	} else if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// cancelOnCloseBody releases an attempt's context once the response body is closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body and cancels the attempt's context.
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
 // buildMiddlewareChain builds the middleware chain with the base transport.
 func (c *Client) buildMiddlewareChain() http.RoundTripper {
 	// Start with the base HTTP client
@@ -249,6 +334,32 @@ func (cfg *Config) validate() error {
 		return fmt.Errorf("retry wait min cannot be greater than retry wait max")
 	}
 
+	if cfg.MaxElapsedTime < 0 {
+		return fmt.Errorf("max elapsed time cannot be negative")
+	}
+
+	if cfg.MaxIdleConns < 0 {
+		return fmt.Errorf("max idle conns cannot be negative")
+	}
+
+	if cfg.MaxIdleConnsPerHost < 0 {
+		return fmt.Errorf("max idle conns per host cannot be negative")
+	}
+
+	if cfg.MaxConnsPerHost < 0 {
+		return fmt.Errorf("max conns per host cannot be negative")
+	}
+
+	if cfg.IdleConnTimeout < 0 {
+		return fmt.Errorf("idle conn timeout cannot be negative")
+	}
+
+	if cfg.hasPoolSettings() && cfg.Transport != nil {
+		if _, ok := cfg.Transport.(*http.Transport); !ok {
+			return fmt.Errorf("connection pool settings require Transport to be an *http.Transport")
+		}
+	}
+
 	return nil
 }
 
@@ -278,6 +389,39 @@ func (cfg *Config) applyDefaults() {
 		cfg.Transport = http.DefaultTransport
 	}
 
+	if cfg.hasPoolSettings() {
+		cfg.Transport = cfg.pooledTransport()
+	}
+
 	// FollowRedirects defaults to true (zero value is false, but we want default true)
 	// This is handled in the main New() function logic
 }
+
+// hasPoolSettings reports whether any connection pool field is set.
+func (cfg *Config) hasPoolSettings() bool {
+	return cfg.MaxIdleConns > 0 || cfg.MaxIdleConnsPerHost > 0 || cfg.MaxConnsPerHost > 0 || cfg.IdleConnTimeout > 0
+}
+
+// pooledTransport returns a copy of cfg.Transport, which must be an *http.Transport, with the
+// connection pool settings applied. The copy keeps the shared default transport untouched.
+func (cfg *Config) pooledTransport() *http.Transport {
+	transport := cfg.Transport.(*http.Transport).Clone()
+
+	if cfg.MaxIdleConns > 0 {
+		transport.MaxIdleConns = cfg.MaxIdleConns
+	}
+
+	if cfg.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	}
+
+	if cfg.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = cfg.MaxConnsPerHost
+	}
+
+	if cfg.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = cfg.IdleConnTimeout
+	}
+
+	return transport
+}
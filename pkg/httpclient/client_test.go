@@ -77,6 +77,35 @@ func TestNew_InvalidConfig(t *testing.T) {
 				RetryWaitMax: 10 * time.Second,
 			},
 		},
+		{
+			name: "negative max elapsed time",
+			config: Config{
+				BaseURL:        "https://api.example.com",
+				MaxElapsedTime: -1 * time.Second,
+			},
+		},
+		{
+			name: "negative max idle conns per host",
+			config: Config{
+				BaseURL:             "https://api.example.com",
+				MaxIdleConnsPerHost: -1,
+			},
+		},
+		{
+			name: "negative idle conn timeout",
+			config: Config{
+				BaseURL:         "https://api.example.com",
+				IdleConnTimeout: -1 * time.Second,
+			},
+		},
+		{
+			name: "pool settings with custom round tripper",
+			config: Config{
+				BaseURL:      "https://api.example.com",
+				Transport:    RoundTripperFunc(func(*http.Request) (*http.Response, error) { return nil, nil }),
+				MaxIdleConns: 10,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -120,6 +149,65 @@ func TestConfig_ApplyDefaults(t *testing.T) {
 	}
 }
 
+func TestNew_ConnectionPool(t *testing.T) {
+	client, err := New(Config{
+		BaseURL:             "https://api.example.com",
+		MaxIdleConns:        200,
+		MaxIdleConnsPerHost: 50,
+		MaxConnsPerHost:     64,
+		IdleConnTimeout:     2 * time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("transport = %T, want *http.Transport", client.httpClient.Transport)
+	}
+	if transport == http.DefaultTransport {
+		t.Fatal("pool settings must not modify http.DefaultTransport")
+	}
+	if transport.MaxIdleConns != 200 {
+		t.Errorf("MaxIdleConns = %d, want 200", transport.MaxIdleConns)
+	}
+	if transport.MaxIdleConnsPerHost != 50 {
+		t.Errorf("MaxIdleConnsPerHost = %d, want 50", transport.MaxIdleConnsPerHost)
+	}
+	if transport.MaxConnsPerHost != 64 {
+		t.Errorf("MaxConnsPerHost = %d, want 64", transport.MaxConnsPerHost)
+	}
+	if transport.IdleConnTimeout != 2*time.Minute {
+		t.Errorf("IdleConnTimeout = %v, want %v", transport.IdleConnTimeout, 2*time.Minute)
+	}
+}
+
+func TestNew_ConnectionPoolKeepsTransportDefaults(t *testing.T) {
+	base := &http.Transport{MaxIdleConns: 10, IdleConnTimeout: 30 * time.Second}
+	client, err := New(Config{
+		BaseURL:             "https://api.example.com",
+		Transport:           base,
+		MaxIdleConnsPerHost: 10,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	transport := client.httpClient.Transport.(*http.Transport)
+	if transport == base {
+		t.Fatal("pool settings must be applied to a copy of Transport")
+	}
+	if transport.MaxIdleConns != 10 || transport.IdleConnTimeout != 30*time.Second {
+		t.Errorf("unset fields changed: MaxIdleConns = %d, IdleConnTimeout = %v", transport.MaxIdleConns, transport.IdleConnTimeout)
+	}
+	if transport.MaxIdleConnsPerHost != 10 {
+		t.Errorf("MaxIdleConnsPerHost = %d, want 10", transport.MaxIdleConnsPerHost)
+	}
+	if base.MaxIdleConnsPerHost != 0 {
+		t.Errorf("base transport modified: MaxIdleConnsPerHost = %d", base.MaxIdleConnsPerHost)
+	}
+}
+
 func TestNewDefault(t *testing.T) {
 	baseURL := "https://api.example.com"
 	client := NewDefault(baseURL)
@@ -0,0 +1,112 @@
+package httpclient
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option overrides a setting of a client derived with Clone.
+type Option func(*cloneConfig)
+
+// cloneConfig collects the overrides applied by Clone.
+type cloneConfig struct {
+	client               *Client
+	sharedCircuitBreaker bool
+}
+
+// WithBaseURL sets the base URL of the cloned client.
+func WithBaseURL(baseURL string) Option {
+	return func(cfg *cloneConfig) {
+		cfg.client.baseURL = baseURL
+	}
+}
+
+// WithTimeout sets the request timeout of the cloned client.
+func WithTimeout(timeout time.Duration) Option {
+	return func(cfg *cloneConfig) {
+		cfg.client.httpClient.Timeout = timeout
+	}
+}
+
+// WithTransport sets the transport of the cloned client, for example a RoundTripperFunc returning
+// canned responses in tests. Retries, the circuit breaker, and middleware still apply.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(cfg *cloneConfig) {
+		if rt == nil {
+			rt = http.DefaultTransport
+		}
+		cfg.client.httpClient.Transport = rt
+	}
+}
+
+// WithMiddleware appends middleware to the cloned client, after the middleware it inherits.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(cfg *cloneConfig) {
+		cfg.client.middleware = append(cfg.client.middleware, mw...)
+	}
+}
+
+// WithLogger sets the logger of the cloned client.
+func WithLogger(logger Logger) Option {
+	return func(cfg *cloneConfig) {
+		if logger == nil {
+			logger = NewNoopLogger()
+		}
+		cfg.client.logger = logger
+	}
+}
+
+// WithClientTrace turns recording of Response.Timing on or off for the cloned client.
+func WithClientTrace(enabled bool) Option {
+	return func(cfg *cloneConfig) {
+		cfg.client.clientTrace = enabled
+	}
+}
+
+// WithSharedCircuitBreaker makes the cloned client use the same circuit breaker as its parent, so
+// failures through either client open the circuit for both. By default a clone gets its own breaker
+// with the parent's configuration.
+func WithSharedCircuitBreaker() Option {
+	return func(cfg *cloneConfig) {
+		cfg.sharedCircuitBreaker = true
+	}
+}
+
+// Clone returns a new client with the same configuration and middleware as c, then applies opts.
+// The clone shares c's transport, and with it the connection pool, unless WithTransport replaces it.
+// Middleware added to either client afterwards with Use does not affect the other.
+//
+// Example:
+//
+//	users := base.Clone(
+//		httpclient.WithBaseURL("https://users.internal"),
+//		httpclient.WithMiddleware(httpclient.AuthBearerMiddleware(token)),
+//	)
+func (c *Client) Clone(opts ...Option) *Client {
+	httpClient := *c.httpClient
+	retryPolicy := *c.retryPolicy
+
+	clone := &Client{
+		baseURL:     c.baseURL,
+		httpClient:  &httpClient,
+		middleware:  append([]Middleware(nil), c.middleware...),
+		retryPolicy: &retryPolicy,
+		logger:      c.logger,
+		clientTrace: c.clientTrace,
+	}
+
+	cfg := &cloneConfig{client: clone}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if c.circuitBreaker != nil {
+		if cfg.sharedCircuitBreaker {
+			clone.circuitBreaker = c.circuitBreaker
+		} else {
+			clone.circuitBreaker = NewCircuitBreaker(c.circuitBreaker.config)
+		}
+	}
+
+	return clone
+}
@@ -0,0 +1,148 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_Clone(t *testing.T) {
+	var gotPath, gotUserAgent, gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotUserAgent = r.Header.Get("User-Agent")
+		gotAuth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	base, err := New(Config{BaseURL: "https://base.invalid", Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	base.Use(UserAgentMiddleware("core-backend/1.0"))
+
+	users := base.Clone(
+		WithBaseURL(server.URL+"/users"),
+		WithTimeout(2*time.Second),
+		WithMiddleware(AuthBearerMiddleware("users-token")),
+	)
+
+	resp, err := users.Get(context.Background(), "/42").Do()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if gotPath != "/users/42" {
+		t.Errorf("path = %q, want /users/42", gotPath)
+	}
+	if gotUserAgent != "core-backend/1.0" {
+		t.Errorf("User-Agent = %q, want the inherited middleware to set it", gotUserAgent)
+	}
+	if gotAuth != "Bearer users-token" {
+		t.Errorf("Authorization = %q, want the added middleware to set it", gotAuth)
+	}
+	if users.httpClient.Transport != base.httpClient.Transport {
+		t.Error("clone should share the parent's transport")
+	}
+
+	// The parent is unchanged
+	if base.baseURL != "https://base.invalid" {
+		t.Errorf("parent changed: baseURL = %q", base.baseURL)
+	}
+	if len(base.middleware) != 1 {
+		t.Errorf("parent has %d middleware, want 1", len(base.middleware))
+	}
+	users.Use(HeaderMiddleware(map[string]string{"X-Team": "users"}))
+	if len(base.middleware) != 1 {
+		t.Errorf("Use on the clone added middleware to the parent")
+	}
+}
+
+func TestClient_CloneWithTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	base, err := New(Config{BaseURL: server.URL, Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fast := base.Clone(WithTimeout(50 * time.Millisecond))
+	fast.retryPolicy.MaxRetries = 0 // a single attempt
+
+	start := time.Now()
+	_, err = fast.Get(context.Background(), "/slow").Do()
+	if err == nil {
+		t.Fatal("expected the request to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("request took %v, want it cut off by the 50ms clone timeout", elapsed)
+	}
+}
+
+func TestClient_CloneCircuitBreaker(t *testing.T) {
+	base, err := New(Config{
+		BaseURL:        "https://api.example.com",
+		CircuitBreaker: &CircuitBreakerConfig{Timeout: time.Minute},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fresh := base.Clone()
+	if fresh.circuitBreaker == nil || fresh.circuitBreaker == base.circuitBreaker {
+		t.Fatal("clone should get its own circuit breaker by default")
+	}
+	if fresh.circuitBreaker.config.Timeout != time.Minute {
+		t.Errorf("clone breaker timeout = %v, want the parent's 1m", fresh.circuitBreaker.config.Timeout)
+	}
+
+	shared := base.Clone(WithSharedCircuitBreaker())
+	if shared.circuitBreaker != base.circuitBreaker {
+		t.Error("WithSharedCircuitBreaker should reuse the parent's breaker")
+	}
+
+	plain := NewDefault("https://api.example.com").Clone(WithSharedCircuitBreaker())
+	if plain.circuitBreaker != nil {
+		t.Error("clone of a client without a breaker should not get one")
+	}
+}
+
+func TestClient_CloneWithTransport(t *testing.T) {
+	base, err := New(Config{BaseURL: "https://api.example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	base.Use(HeaderMiddleware(map[string]string{"X-Team": "users"}))
+
+	var gotTeam string
+	stub := base.Clone(WithTransport(RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		gotTeam = req.Header.Get("X-Team")
+		return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody, Request: req}, nil
+	})))
+
+	resp, err := stub.Get(context.Background(), "/users").Do()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("status = %d, want the canned 204", resp.StatusCode)
+	}
+	if gotTeam != "users" {
+		t.Errorf("X-Team = %q, want inherited middleware to run before the transport", gotTeam)
+	}
+	if base.httpClient.Transport == stub.httpClient.Transport {
+		t.Error("WithTransport changed the parent's transport")
+	}
+}
@@ -0,0 +1,53 @@
+package httpclient_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rompi/core-backend/pkg/httpclient"
+)
+
+// A RoundTripperFunc set as the transport returns canned responses, so code built on the client can
+// be tested without a server. Retries, the circuit breaker, and middleware still run.
+func ExampleRoundTripperFunc() {
+	statuses := []int{http.StatusServiceUnavailable, http.StatusOK}
+	attempts := 0
+	transport := httpclient.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		status := statuses[attempts]
+		attempts++
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"id":"42"}`)),
+			Request:    req,
+		}, nil
+	})
+
+	client, err := httpclient.New(httpclient.Config{
+		BaseURL:      "https://users.internal",
+		Transport:    transport,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: time.Millisecond,
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	resp, err := client.Get(context.Background(), "/users/42").Do()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	body, _ := resp.String()
+
+	fmt.Println(resp.StatusCode, body)
+	fmt.Println("attempts:", attempts)
+	// Output:
+	// 200 {"id":"42"}
+	// attempts: 2
+}
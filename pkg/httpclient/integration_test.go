@@ -3,8 +3,10 @@ package httpclient
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -138,6 +140,93 @@ func TestClient_Retry_On5xx(t *testing.T) {
 	}
 }
 
+func TestClient_RetryStopsAtMaxElapsedTime(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, _ := New(Config{
+		BaseURL:        server.URL,
+		MaxRetries:     100,
+		RetryWaitMin:   20 * time.Millisecond,
+		RetryWaitMax:   20 * time.Millisecond,
+		MaxElapsedTime: 100 * time.Millisecond,
+	})
+
+	start := time.Now()
+	_, err := client.Get(context.Background(), "/test").Do()
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, ErrMaxRetriesExceeded) {
+		t.Fatalf("expected ErrMaxRetriesExceeded, got %v", err)
+	}
+	if n := attempts.Load(); n < 2 || n > 6 {
+		t.Errorf("expected retries to stop at the budget after about 5 attempts, got %d", n)
+	}
+	if elapsed > time.Second {
+		t.Errorf("expected request to end near the 100ms budget, took %v", elapsed)
+	}
+}
+
+func TestClient_MaxElapsedTimeCutsOffHungAttempt(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client, _ := New(Config{
+		BaseURL:        server.URL,
+		Timeout:        time.Minute,
+		MaxRetries:     3,
+		RetryWaitMin:   10 * time.Millisecond,
+		RetryWaitMax:   10 * time.Millisecond,
+		MaxElapsedTime: 100 * time.Millisecond,
+	})
+
+	start := time.Now()
+	_, err := client.Get(context.Background(), "/test").Do()
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, ErrMaxRetriesExceeded) {
+		t.Fatalf("expected ErrMaxRetriesExceeded, got %v", err)
+	}
+	if elapsed > time.Second {
+		t.Errorf("expected the hung attempt to end at the 100ms budget, took %v", elapsed)
+	}
+}
+
+func TestClient_RetryContextDeadlineBeforeMaxElapsedTime(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, _ := New(Config{
+		BaseURL:        server.URL,
+		MaxRetries:     100,
+		RetryWaitMin:   20 * time.Millisecond,
+		RetryWaitMax:   20 * time.Millisecond,
+		MaxElapsedTime: time.Minute,
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.Get(ctx, "/test").Do()
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
 func TestClient_ContextCancellation(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		time.Sleep(100 * time.Millisecond)
@@ -192,6 +281,8 @@ func TestClient_AllHTTPMethods(t *testing.T) {
 		{"PUT", (*Client).Put, http.MethodPut},
 		{"PATCH", (*Client).Patch, http.MethodPatch},
 		{"DELETE", (*Client).Delete, http.MethodDelete},
+		{"HEAD", (*Client).Head, http.MethodHead},
+		{"OPTIONS", (*Client).Options, http.MethodOptions},
 	}
 
 	for _, tt := range tests {
@@ -311,3 +402,64 @@ func TestClient_QueryParams(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestClient_Head_HeadersOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("expected HEAD, got %s", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewDefault(server.URL)
+	resp, err := client.Head(context.Background(), "/resource").Do()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !resp.IsSuccess() {
+		t.Errorf("expected success status, got %d", resp.StatusCode)
+	}
+
+	if got := resp.Header.Get("ETag"); got != `"v1"` {
+		t.Errorf("got ETag %q, want %q", got, `"v1"`)
+	}
+
+	body, err := resp.String()
+	if err != nil {
+		t.Fatalf("unexpected error reading body: %v", err)
+	}
+	if body != "" {
+		t.Errorf("expected empty body, got %q", body)
+	}
+}
+
+func TestClient_Options_AllowHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodOptions {
+			t.Errorf("expected OPTIONS, got %s", r.Method)
+		}
+		w.Header().Set("Allow", "GET, HEAD, OPTIONS")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewDefault(server.URL)
+	resp, err := client.Options(context.Background(), "/resource").Do()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !resp.IsSuccess() || resp.IsClientError() || resp.IsServerError() {
+		t.Errorf("unexpected status classification for %d", resp.StatusCode)
+	}
+
+	if got := resp.Header.Get("Allow"); got != "GET, HEAD, OPTIONS" {
+		t.Errorf("got Allow %q, want %q", got, "GET, HEAD, OPTIONS")
+	}
+}
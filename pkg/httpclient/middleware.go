@@ -1,6 +1,7 @@
 package httpclient
 
 import (
+	"context"
 	"net/http"
 	"time"
 )
@@ -9,11 +10,12 @@ import (
 // It can intercept, modify, or log requests and responses.
 type Middleware func(next http.RoundTripper) http.RoundTripper
 
-// roundTripperFunc is an adapter to use a function as an http.RoundTripper.
-type roundTripperFunc func(*http.Request) (*http.Response, error)
+// RoundTripperFunc is an adapter to use a function as an http.RoundTripper. In tests, set it as
+// Config.Transport, or pass it to WithTransport, to return canned responses without a server.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
 
 // RoundTrip implements http.RoundTripper.
-func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
 	return f(req)
 }
 
@@ -21,7 +23,7 @@ func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
 // It logs the method, URL, status code, and duration.
 func LoggingMiddleware(logger Logger) Middleware {
 	return func(next http.RoundTripper) http.RoundTripper {
-		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
 			start := time.Now()
 
 			logger.Info("http request",
@@ -59,7 +61,7 @@ func LoggingMiddleware(logger Logger) Middleware {
 // to the Authorization header of all requests.
 func AuthBearerMiddleware(token string) Middleware {
 	return func(next http.RoundTripper) http.RoundTripper {
-		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
 			req.Header.Set("Authorization", "Bearer "+token)
 			return next.RoundTrip(req)
 		})
@@ -70,7 +72,7 @@ func AuthBearerMiddleware(token string) Middleware {
 // to all requests.
 func AuthAPIKeyMiddleware(headerName, apiKey string) Middleware {
 	return func(next http.RoundTripper) http.RoundTripper {
-		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
 			req.Header.Set(headerName, apiKey)
 			return next.RoundTrip(req)
 		})
@@ -81,7 +83,7 @@ func AuthAPIKeyMiddleware(headerName, apiKey string) Middleware {
 // for all requests.
 func UserAgentMiddleware(userAgent string) Middleware {
 	return func(next http.RoundTripper) http.RoundTripper {
-		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
 			req.Header.Set("User-Agent", userAgent)
 			return next.RoundTrip(req)
 		})
@@ -91,7 +93,7 @@ func UserAgentMiddleware(userAgent string) Middleware {
 // HeaderMiddleware creates a middleware that adds custom headers to all requests.
 func HeaderMiddleware(headers map[string]string) Middleware {
 	return func(next http.RoundTripper) http.RoundTripper {
-		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
 			for key, value := range headers {
 				req.Header.Set(key, value)
 			}
@@ -99,3 +101,19 @@ func HeaderMiddleware(headers map[string]string) Middleware {
 		})
 	}
 }
+
+// CorrelationIDMiddleware creates a middleware that copies the correlation ID returned by
+// fromCtx for the request's context into headerName, so IDs propagate across service calls.
+// Requests that already carry the header, or whose context has no ID, are left unchanged.
+func CorrelationIDMiddleware(headerName string, fromCtx func(context.Context) string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get(headerName) == "" {
+				if id := fromCtx(req.Context()); id != "" {
+					req.Header.Set(headerName, id)
+				}
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
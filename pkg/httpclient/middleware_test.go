@@ -88,6 +88,44 @@ func TestHeaderMiddleware(t *testing.T) {
 	}
 }
 
+type correlationIDKey struct{}
+
+func TestCorrelationIDMiddleware(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("X-Correlation-ID"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewDefault(server.URL)
+	client.Use(CorrelationIDMiddleware("X-Correlation-ID", func(ctx context.Context) string {
+		id, _ := ctx.Value(correlationIDKey{}).(string)
+		return id
+	}))
+
+	ctx := context.WithValue(context.Background(), correlationIDKey{}, "corr-789")
+	if _, err := client.Get(ctx, "/test").Do(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Get(ctx, "/test").Header("X-Correlation-ID", "explicit").Do(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Get(context.Background(), "/test").Do(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"corr-789", "explicit", ""}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d requests, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("request %d: expected X-Correlation-ID %q, got %q", i+1, want[i], got[i])
+		}
+	}
+}
+
 func TestLoggingMiddleware(t *testing.T) {
 	logged := false
 	logger := &testLogger{
@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 // RequestBuilder provides a fluent API for building and executing HTTP requests.
@@ -23,8 +24,12 @@ type RequestBuilder struct {
 }
 
 // newRequestBuilder creates a new request builder.
+// Absolute http(s) URLs, such as a Location header value, are used verbatim instead of being joined to BaseURL.
 func newRequestBuilder(client *Client, ctx context.Context, method, path string) *RequestBuilder {
 	fullURL := client.baseURL + path
+	if isAbsoluteURL(path) {
+		fullURL = path
+	}
 
 	return &RequestBuilder{
 		client:  client,
@@ -84,6 +89,15 @@ func (rb *RequestBuilder) JSON(v interface{}) *RequestBuilder {
 	return rb
 }
 
+// Form sets the request body to the URL encoding of values, as expected by HTML form handlers and
+// OAuth token endpoints. It sets the Content-Type header to application/x-www-form-urlencoded.
+// Returns the builder for method chaining.
+func (rb *RequestBuilder) Form(values url.Values) *RequestBuilder {
+	rb.body = strings.NewReader(values.Encode())
+	rb.headers.Set("Content-Type", "application/x-www-form-urlencoded")
+	return rb
+}
+
 // Body sets the request body and content type.
 // Returns the builder for method chaining.
 func (rb *RequestBuilder) Body(body io.Reader, contentType string) *RequestBuilder {
@@ -102,11 +116,22 @@ func (rb *RequestBuilder) Do() (*Response, error) {
 	// Build the full URL with query parameters
 	fullURL := rb.url
 	if len(rb.query) > 0 {
-		fullURL = fullURL + "?" + rb.query.Encode()
+		separator := "?"
+		if strings.Contains(fullURL, "?") {
+			separator = "&"
+		}
+		fullURL = fullURL + separator + rb.query.Encode()
+	}
+
+	ctx := rb.ctx
+	var timing *timingRecorder
+	if rb.client.clientTrace {
+		timing = &timingRecorder{}
+		ctx = timing.withClientTrace(ctx)
 	}
 
 	// Create the HTTP request
-	req, err := http.NewRequestWithContext(rb.ctx, rb.method, fullURL, rb.body)
+	req, err := http.NewRequestWithContext(ctx, rb.method, fullURL, rb.body)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
@@ -125,7 +150,17 @@ func (rb *RequestBuilder) Do() (*Response, error) {
 		return nil, err
 	}
 
-	return &Response{Response: resp}, nil
+	response := &Response{Response: resp}
+	if timing != nil {
+		response.Timing = timing.result()
+	}
+	return response, nil
+}
+
+// isAbsoluteURL reports whether path starts with an http:// or https:// scheme.
+func isAbsoluteURL(path string) bool {
+	lower := strings.ToLower(path)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
 }
 
 // errorReader is a helper type to defer JSON encoding errors until Do() is called.
@@ -1,6 +1,9 @@
 package httpclient
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,6 +14,11 @@ import (
 // for reading and decoding response bodies.
 type Response struct {
 	*http.Response
+
+	// Timing holds the request's phase durations when the client was created with
+	// Config.ClientTrace, and is nil otherwise.
+	Timing *ResponseTiming
+
 	body []byte // cached body for multiple reads
 }
 
@@ -30,6 +38,56 @@ func (r *Response) JSON(v interface{}) error {
 	return nil
 }
 
+// JSONLines decodes a newline-delimited JSON (NDJSON) body, calling fn with each object as it arrives.
+// The body is streamed rather than cached, and blank lines are skipped.
+//
+// Returns the first error from fn, which stops reading, or an error if a line is not valid JSON,
+// the body cannot be read, or the request context is canceled.
+func (r *Response) JSONLines(fn func(json.RawMessage) error) error {
+	var src io.Reader
+	switch {
+	case r.body != nil:
+		src = bytes.NewReader(r.body)
+	case r.Body != nil:
+		defer r.Body.Close()
+		src = r.Body
+	default:
+		return nil
+	}
+
+	ctx := context.Background()
+	if r.Request != nil {
+		ctx = r.Request.Context()
+	}
+
+	reader := bufio.NewReader(src)
+	for lineNum := 1; ; lineNum++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		line, readErr := reader.ReadBytes('\n')
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			if !json.Valid(line) {
+				return fmt.Errorf("decoding JSON line %d: invalid JSON", lineNum)
+			}
+			if err := fn(json.RawMessage(line)); err != nil {
+				return err
+			}
+		}
+
+		if readErr == io.EOF {
+			return nil
+		}
+		if readErr != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			return fmt.Errorf("reading response body: %w", readErr)
+		}
+	}
+}
+
 // String returns the response body as a string.
 // The response body is cached, so this method can be called multiple times.
 //
@@ -2,8 +2,13 @@ package httpclient
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -285,3 +290,116 @@ func TestResponse_CacheBody(t *testing.T) {
 		}
 	})
 }
+
+func TestResponse_JSONLines(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		for i := 1; i <= 3; i++ {
+			fmt.Fprintf(w, "{\"id\":%d}\n", i)
+			w.(http.Flusher).Flush()
+		}
+		// Blank lines and CRLF endings are tolerated
+		io.WriteString(w, "\r\n{\"id\":4}")
+	}))
+	defer server.Close()
+
+	client := NewDefault(server.URL)
+
+	t.Run("callback per line", func(t *testing.T) {
+		resp, err := client.Get(context.Background(), "/stream").Do()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var ids []int
+		err = resp.JSONLines(func(line json.RawMessage) error {
+			var item struct {
+				ID int `json:"id"`
+			}
+			if err := json.Unmarshal(line, &item); err != nil {
+				return err
+			}
+			ids = append(ids, item.ID)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("JSONLines() error = %v", err)
+		}
+		if fmt.Sprint(ids) != "[1 2 3 4]" {
+			t.Errorf("ids = %v, want [1 2 3 4]", ids)
+		}
+	})
+
+	t.Run("early exit", func(t *testing.T) {
+		resp, err := client.Get(context.Background(), "/stream").Do()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		errStop := errors.New("stop")
+		calls := 0
+		err = resp.JSONLines(func(line json.RawMessage) error {
+			calls++
+			if calls == 2 {
+				return errStop
+			}
+			return nil
+		})
+		if !errors.Is(err, errStop) {
+			t.Fatalf("JSONLines() error = %v, want %v", err, errStop)
+		}
+		if calls != 2 {
+			t.Errorf("callback called %d times, want 2", calls)
+		}
+	})
+}
+
+func TestResponse_JSONLines_ContextCanceled(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "{\"id\":1}\n")
+		w.(http.Flusher).Flush()
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	resp, err := NewDefault(server.URL).Get(ctx, "/stream").Do()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = resp.JSONLines(func(line json.RawMessage) error {
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("JSONLines() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestResponse_JSONLines_InvalidLine(t *testing.T) {
+	resp := &Response{
+		Response: &http.Response{
+			Body: io.NopCloser(bytes.NewBufferString("{\"id\":1}\nnot json\n")),
+		},
+	}
+
+	calls := 0
+	err := resp.JSONLines(func(line json.RawMessage) error {
+		calls++
+		return nil
+	})
+	if err == nil {
+		t.Fatal("expected error for invalid line")
+	}
+	if calls != 1 {
+		t.Errorf("callback called %d times, want 1", calls)
+	}
+}
@@ -21,6 +21,9 @@ type RetryPolicy struct {
 
 	// RetryWaitMax is the maximum wait time between retries.
 	RetryWaitMax time.Duration
+
+	// MaxElapsedTime is the total time budget for a request across retries; zero means unlimited.
+	MaxElapsedTime time.Duration
 }
 
 // ShouldRetry determines whether a request should be retried based on
@@ -52,6 +55,11 @@ func (rp *RetryPolicy) Backoff(attempt int) time.Duration {
 	return backoff.Exponential(attempt, rp.RetryWaitMin, rp.RetryWaitMax)
 }
 
+// withinBudget reports whether a retry starting after elapsed still fits in MaxElapsedTime.
+func (rp *RetryPolicy) withinBudget(elapsed time.Duration) bool {
+	return rp.MaxElapsedTime <= 0 || elapsed <= rp.MaxElapsedTime
+}
+
 // isRetryableError checks if an error is retryable.
 func (rp *RetryPolicy) isRetryableError(err error) bool {
 	if err == nil {
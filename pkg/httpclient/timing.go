@@ -0,0 +1,109 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// ResponseTiming breaks down where the time of a request went, to tell network latency from server
+// latency. Phases that did not happen, such as DNS and connect on a reused connection or TLS for
+// plain HTTP, are zero. When a request was retried, it describes the attempt that produced the response.
+type ResponseTiming struct {
+	// DNSLookup is the time spent resolving the host name.
+	DNSLookup time.Duration
+
+	// TCPConnect is the time spent establishing the TCP connection.
+	TCPConnect time.Duration
+
+	// TLSHandshake is the time spent on the TLS handshake.
+	TLSHandshake time.Duration
+
+	// TimeToFirstByte is the time from asking for a connection until the first response byte
+	// arrived. It includes the phases above plus the time the server took to respond.
+	TimeToFirstByte time.Duration
+
+	// ConnReused reports whether an idle keep-alive connection was used.
+	ConnReused bool
+}
+
+// timingRecorder collects ResponseTiming through httptrace hooks. The hooks may run on dialer
+// goroutines, so the fields are guarded by mu.
+type timingRecorder struct {
+	mu        sync.Mutex
+	start     time.Time
+	dnsStart  time.Time
+	dialStart time.Time
+	tlsStart  time.Time
+	timing    ResponseTiming
+}
+
+// withClientTrace returns ctx with hooks that record into r.
+func (r *timingRecorder) withClientTrace(ctx context.Context) context.Context {
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GetConn: func(string) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			// Each retry asks for a new connection; keep only the latest attempt
+			r.start = time.Now()
+			r.timing = ResponseTiming{}
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.timing.ConnReused = info.Reused
+		},
+		DNSStart: func(httptrace.DNSStartInfo) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.dnsStart = time.Now()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.timing.DNSLookup = time.Since(r.dnsStart)
+		},
+		ConnectStart: func(string, string) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			// Dual-stack dialing may start several connects; measure from the first
+			if r.timing.TCPConnect == 0 && r.dialStart.Before(r.start) {
+				r.dialStart = time.Now()
+			}
+		},
+		ConnectDone: func(_, _ string, err error) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			if err == nil && r.timing.TCPConnect == 0 {
+				r.timing.TCPConnect = time.Since(r.dialStart)
+			}
+		},
+		TLSHandshakeStart: func() {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(_ tls.ConnectionState, err error) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			if err == nil {
+				r.timing.TLSHandshake = time.Since(r.tlsStart)
+			}
+		},
+		GotFirstResponseByte: func() {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.timing.TimeToFirstByte = time.Since(r.start)
+		},
+	})
+}
+
+// result returns a copy of the recorded timing.
+func (r *timingRecorder) result() *ResponseTiming {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	timing := r.timing
+	return &timing
+}
@@ -0,0 +1,94 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_ClientTrace(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// Use a host name so the request goes through a DNS lookup; the test
+	// certificate is issued for example.com, so verify against that name.
+	transport := server.Client().Transport.(*http.Transport).Clone()
+	transport.TLSClientConfig.ServerName = "example.com"
+	client, err := New(Config{
+		BaseURL:     strings.Replace(server.URL, "127.0.0.1", "localhost", 1),
+		Transport:   transport,
+		ClientTrace: true,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	resp, err := client.Get(context.Background(), "/").Do()
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	resp.Body.Close()
+
+	timing := resp.Timing
+	if timing == nil {
+		t.Fatal("expected Timing to be set")
+	}
+	if timing.DNSLookup <= 0 || timing.TCPConnect <= 0 || timing.TLSHandshake <= 0 {
+		t.Errorf("expected DNS, connect, and TLS durations, got %+v", timing)
+	}
+	if timing.ConnReused {
+		t.Error("expected a new connection for the first request")
+	}
+	if phases := timing.DNSLookup + timing.TCPConnect + timing.TLSHandshake; timing.TimeToFirstByte < phases+10*time.Millisecond {
+		t.Errorf("TimeToFirstByte = %v, want at least the connection phases (%v) plus the server delay", timing.TimeToFirstByte, phases)
+	}
+
+	resp, err = client.Get(context.Background(), "/").Do()
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	resp.Body.Close()
+
+	timing = resp.Timing
+	if !timing.ConnReused {
+		t.Error("expected the second request to reuse the connection")
+	}
+	if timing.DNSLookup != 0 || timing.TCPConnect != 0 || timing.TLSHandshake != 0 {
+		t.Errorf("expected no connection phases on a reused connection, got %+v", timing)
+	}
+	if timing.TimeToFirstByte < 10*time.Millisecond {
+		t.Errorf("TimeToFirstByte = %v, want at least the server delay", timing.TimeToFirstByte)
+	}
+}
+
+func TestClient_ClientTraceDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewDefault(server.URL)
+	resp, err := client.Get(context.Background(), "/").Do()
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.Timing != nil {
+		t.Errorf("expected no Timing by default, got %+v", resp.Timing)
+	}
+
+	resp, err = client.Clone(WithClientTrace(true)).Get(context.Background(), "/").Do()
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.Timing == nil || resp.Timing.TimeToFirstByte <= 0 {
+		t.Errorf("expected Timing from a clone with WithClientTrace, got %+v", resp.Timing)
+	}
+}
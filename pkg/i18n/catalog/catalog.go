@@ -3,6 +3,7 @@ package catalog
 
 import (
 	"errors"
+	"strings"
 )
 
 var (
@@ -24,6 +25,10 @@ type Message struct {
 	// Description provides context for translators.
 	Description string `json:"description,omitempty"`
 
+	// Context tells translators where the message appears, to tell apart keys with the same source
+	// text, such as "Open" as a button label or as a status.
+	Context string `json:"context,omitempty"`
+
 	// One is the singular form of the message.
 	One string `json:"one,omitempty"`
 
@@ -57,3 +62,24 @@ type Catalog interface {
 	// Reload reloads the catalog from the source.
 	Reload() error
 }
+
+// Describe returns the translator notes for a key: its description, followed by its context on a
+// separate line when both are set. It reports false when the key does not exist in the locale or
+// has no notes.
+func Describe(c Catalog, locale, key string) (string, bool) {
+	msg, err := c.Lookup(locale, key)
+	if err != nil {
+		return "", false
+	}
+
+	var notes []string
+	for _, note := range []string{msg.Description, msg.Context} {
+		if note != "" {
+			notes = append(notes, note)
+		}
+	}
+	if len(notes) == 0 {
+		return "", false
+	}
+	return strings.Join(notes, "\n"), true
+}
@@ -91,6 +91,11 @@ func (c *EmbedCatalog) All(locale string) (map[string]*Message, error) {
 	return result, nil
 }
 
+// Describe returns the translator notes for a key. See Describe.
+func (c *EmbedCatalog) Describe(locale, key string) (string, bool) {
+	return Describe(c, locale, key)
+}
+
 // Locales returns all available locales.
 func (c *EmbedCatalog) Locales() []string {
 	c.mu.RLock()
@@ -256,6 +261,9 @@ func (c *EmbedCatalog) parsePluralMessage(key string, m map[string]interface{})
 	if v, ok := m["description"].(string); ok {
 		msg.Description = v
 	}
+	if v, ok := m["context"].(string); ok {
+		msg.Context = v
+	}
 
 	return msg
 }
@@ -331,6 +339,11 @@ func (c *InMemoryCatalog) All(locale string) (map[string]*Message, error) {
 	return result, nil
 }
 
+// Describe returns the translator notes for a key. See Describe.
+func (c *InMemoryCatalog) Describe(locale, key string) (string, bool) {
+	return Describe(c, locale, key)
+}
+
 // Locales returns all available locales.
 func (c *InMemoryCatalog) Locales() []string {
 	c.mu.RLock()
@@ -69,6 +69,11 @@ func (c *JSONCatalog) All(locale string) (map[string]*Message, error) {
 	return result, nil
 }
 
+// Describe returns the translator notes for a key. See Describe.
+func (c *JSONCatalog) Describe(locale, key string) (string, bool) {
+	return Describe(c, locale, key)
+}
+
 // Locales returns all available locales.
 func (c *JSONCatalog) Locales() []string {
 	c.mu.RLock()
@@ -229,6 +234,9 @@ func (c *JSONCatalog) parsePluralMessage(key string, m map[string]interface{}) *
 	if v, ok := m["description"].(string); ok {
 		msg.Description = v
 	}
+	if v, ok := m["context"].(string); ok {
+		msg.Context = v
+	}
 
 	return msg
 }
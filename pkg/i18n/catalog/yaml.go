@@ -70,6 +70,11 @@ func (c *YAMLCatalog) All(locale string) (map[string]*Message, error) {
 	return result, nil
 }
 
+// Describe returns the translator notes for a key. See Describe.
+func (c *YAMLCatalog) Describe(locale, key string) (string, bool) {
+	return Describe(c, locale, key)
+}
+
 // Locales returns all available locales.
 func (c *YAMLCatalog) Locales() []string {
 	c.mu.RLock()
@@ -236,6 +241,9 @@ func (c *YAMLCatalog) parsePluralMessage(key string, m map[string]interface{}) *
 	if v, ok := m["description"].(string); ok {
 		msg.Description = v
 	}
+	if v, ok := m["context"].(string); ok {
+		msg.Context = v
+	}
 
 	return msg
 }
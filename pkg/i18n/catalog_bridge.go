@@ -36,6 +36,7 @@ func (a *catalogAdapter) Lookup(locale, key string) (*Message, error) {
 	return &Message{
 		ID:          msg.ID,
 		Description: msg.Description,
+		Context:     msg.Context,
 		One:         msg.One,
 		Other:       msg.Other,
 		Zero:        msg.Zero,
@@ -56,6 +57,7 @@ func (a *catalogAdapter) All(locale string) (map[string]*Message, error) {
 		result[k] = &Message{
 			ID:          msg.ID,
 			Description: msg.Description,
+			Context:     msg.Context,
 			One:         msg.One,
 			Other:       msg.Other,
 			Zero:        msg.Zero,
@@ -0,0 +1,16 @@
+package i18n
+
+import (
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// newCollator returns a collator for locale. Unknown locales use the root collation order, which
+// still places accented letters next to their base letters rather than after "z".
+func newCollator(locale string) *collate.Collator {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		tag = language.Und
+	}
+	return collate.New(tag)
+}
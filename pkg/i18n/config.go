@@ -79,6 +79,13 @@ type Config struct {
 	// Environment variable: I18N_LOG_MISSING
 	// Default: true
 	LogMissing bool `json:"log_missing"`
+
+	// SafeInterpolation checks that printf verbs in a translation match the types of its arguments.
+	// On a mismatch, such as "%d" with a string, the error is logged and passed to the error handler,
+	// and the translation is returned uninterpolated instead of with "%!d(string=...)" markers.
+	// Environment variable: I18N_SAFE_INTERPOLATION
+	// Default: false
+	SafeInterpolation bool `json:"safe_interpolation"`
 }
 
 // LoadConfig loads configuration from environment variables with sensible defaults.
@@ -130,6 +137,10 @@ func (c *Config) overrideFromEnv() {
 	if v := os.Getenv("I18N_LOG_MISSING"); v != "" {
 		c.LogMissing = parseBool(v)
 	}
+
+	if v := os.Getenv("I18N_SAFE_INTERPOLATION"); v != "" {
+		c.SafeInterpolation = parseBool(v)
+	}
 }
 
 // Validate validates the configuration and returns an error if invalid.
@@ -133,7 +133,12 @@ func FormatCurrency(locale string, amount float64, currency string, cfg FormatCo
 	currencyCfg.MinDecimals = currencyInfo.DecimalDigits
 	currencyCfg.MaxDecimals = currencyInfo.DecimalDigits
 
-	// Format the number
+	// Format the magnitude; the sign is applied around the currency below
+	negative := amount < 0
+	if negative {
+		amount = -amount
+		negative = roundDecimals(amount, currencyCfg.MaxDecimals, currencyCfg.RoundingMode) != 0
+	}
 	formatted := FormatNumber(locale, amount, currencyCfg)
 
 	// Get the currency display string
@@ -150,8 +155,19 @@ func FormatCurrency(locale string, amount float64, currency string, cfg FormatCo
 	// Get locale-specific formatting
 	format := getLocaleCurrencyFormat(locale)
 
-	// Combine currency symbol and amount
-	return combineCurrencyAndAmount(formatted, currencyStr, format.SymbolPosition)
+	if !negative {
+		return combineCurrencyAndAmount(formatted, currencyStr, format.SymbolPosition)
+	}
+
+	// Combine currency symbol and amount, marking negatives according to the style
+	switch cfg.NegativeStyle {
+	case NegativeParentheses:
+		return combineCurrencyAndAmount("("+formatted+")", currencyStr, format.SymbolPosition)
+	case NegativeAccounting:
+		return "(" + combineCurrencyAndAmount(formatted, currencyStr, format.SymbolPosition) + ")"
+	default: // NegativeMinus
+		return GetNumberFormat(locale).MinusSign + combineCurrencyAndAmount(formatted, currencyStr, format.SymbolPosition)
+	}
 }
 
 // getLocaleCurrencyFormat returns the currency format for a locale.
@@ -134,6 +134,82 @@ func TestFormatCurrency(t *testing.T) {
 	}
 }
 
+func TestFormatCurrency_RoundingMode(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount float64
+		mode   RoundingMode
+		want   string
+	}{
+		{name: "half up rounds up", amount: 2.675, mode: RoundHalfUp, want: "$2.68"},
+		{name: "half up rounds up from even", amount: 2.665, mode: RoundHalfUp, want: "$2.67"},
+		{name: "half even rounds to even", amount: 2.665, mode: RoundHalfEven, want: "$2.66"},
+		{name: "half even rounds odd up", amount: 2.675, mode: RoundHalfEven, want: "$2.68"},
+		{name: "half even above half", amount: 2.6651, mode: RoundHalfEven, want: "$2.67"},
+		{name: "down truncates", amount: 2.679, mode: RoundDown, want: "$2.67"},
+		{name: "half up carries", amount: 999.995, mode: RoundHalfUp, want: "$1,000.00"},
+		{name: "half up negative", amount: -2.675, mode: RoundHalfUp, want: "-$2.68"},
+		{name: "down negative", amount: -2.679, mode: RoundDown, want: "-$2.67"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultFormatConfig()
+			cfg.RoundingMode = tt.mode
+			got := FormatCurrency("en-US", tt.amount, "USD", cfg)
+			if got != tt.want {
+				t.Errorf("FormatCurrency() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatCurrency_NegativeStyle(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale string
+		amount float64
+		style  NegativeStyle
+		want   string
+	}{
+		{name: "minus", locale: "en-US", amount: -1234.56, style: NegativeMinus, want: "-$1,234.56"},
+		{name: "parentheses", locale: "en-US", amount: -1234.56, style: NegativeParentheses, want: "$(1,234.56)"},
+		{name: "accounting", locale: "en-US", amount: -1234.56, style: NegativeAccounting, want: "($1,234.56)"},
+		{name: "accounting symbol after", locale: "de-DE", amount: -1234.56, style: NegativeAccounting, want: "(1.234,56 €)"},
+		{name: "positive unaffected", locale: "en-US", amount: 1234.56, style: NegativeAccounting, want: "$1,234.56"},
+		{name: "rounds to zero", locale: "en-US", amount: -0.001, style: NegativeAccounting, want: "$0.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultFormatConfig()
+			cfg.NegativeStyle = tt.style
+			currency := "USD"
+			if tt.locale == "de-DE" {
+				currency = "EUR"
+			}
+			got := FormatCurrency(tt.locale, tt.amount, currency, cfg)
+			if got != tt.want {
+				t.Errorf("FormatCurrency() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatNumber_NegativeStyle(t *testing.T) {
+	cfg := FormatConfig{MaxDecimals: 2, UseGrouping: true}
+
+	cfg.NegativeStyle = NegativeMinus
+	if got := FormatNumber("en-US", -1234.5, cfg); got != "-1,234.5" {
+		t.Errorf("FormatNumber() minus = %q, want %q", got, "-1,234.5")
+	}
+
+	cfg.NegativeStyle = NegativeParentheses
+	if got := FormatNumber("en-US", -1234.5, cfg); got != "(1,234.5)" {
+		t.Errorf("FormatNumber() parentheses = %q, want %q", got, "(1,234.5)")
+	}
+}
+
 func TestFormatDate(t *testing.T) {
 	testTime := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
 
@@ -184,6 +260,68 @@ func TestFormatDate(t *testing.T) {
 	}
 }
 
+func TestFormatDateRange(t *testing.T) {
+	jan3 := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	jan5 := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	feb5 := time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC)
+	mar5 := time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		locale string
+		start  time.Time
+		end    time.Time
+		style  DateStyle
+		want   string
+	}{
+		{name: "en same month", locale: "en-US", start: jan3, end: jan5, style: DateStyleMedium, want: "Jan 3 – 5, 2024"},
+		{name: "en cross month", locale: "en-US", start: jan3, end: feb5, style: DateStyleMedium, want: "Jan 3 – Feb 5, 2024"},
+		{name: "en cross year", locale: "en-US", start: jan3, end: mar5, style: DateStyleMedium, want: "Jan 3, 2024 – Mar 5, 2025"},
+		{name: "en long same month", locale: "en", start: jan3, end: jan5, style: DateStyleLong, want: "January 3 – 5, 2024"},
+		{name: "en short", locale: "en", start: jan3, end: jan5, style: DateStyleShort, want: "1/3/24 – 1/5/24"},
+		{name: "en same day", locale: "en", start: jan3, end: jan3, style: DateStyleMedium, want: "Jan 3, 2024"},
+		{name: "de same month", locale: "de-DE", start: jan3, end: jan5, style: DateStyleMedium, want: "3.–5. Jan. 2024"},
+		{name: "de cross month", locale: "de-DE", start: jan3, end: feb5, style: DateStyleMedium, want: "3. Jan. – 5. Feb. 2024"},
+		{name: "de cross year", locale: "de-DE", start: jan3, end: mar5, style: DateStyleMedium, want: "3. Jan. 2024 – 5. Mär. 2025"},
+		{name: "de long same month", locale: "de", start: jan3, end: jan5, style: DateStyleLong, want: "3.–5. Januar 2024"},
+		{name: "no range rules", locale: "fr", start: jan3, end: jan5, style: DateStyleMedium, want: "3 janv. 2024 – 5 janv. 2024"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatDateRange(tt.locale, tt.start, tt.end, tt.style)
+			if got != tt.want {
+				t.Errorf("FormatDateRange() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatNumberRange(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale string
+		lo     float64
+		hi     float64
+		want   string
+	}{
+		{name: "en integers", locale: "en-US", lo: 10, hi: 20, want: "10–20"},
+		{name: "en grouping", locale: "en-US", lo: 1000, hi: 2500.5, want: "1,000–2,500.5"},
+		{name: "de decimals", locale: "de-DE", lo: 1.5, hi: 2.25, want: "1,5–2,25"},
+		{name: "negative", locale: "en", lo: -5, hi: 10, want: "-5 – 10"},
+		{name: "equal", locale: "en", lo: 10, hi: 10, want: "10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatNumberRange(tt.locale, tt.lo, tt.hi, DefaultFormatConfig())
+			if got != tt.want {
+				t.Errorf("FormatNumberRange() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestFormatRelativeTime(t *testing.T) {
 	now := time.Now()
 
@@ -75,6 +75,8 @@ type FormatConfig struct {
 	MaxDecimals     int
 	UseGrouping     bool
 	CurrencyDisplay CurrencyDisplay
+	RoundingMode    RoundingMode
+	NegativeStyle   NegativeStyle
 }
 
 // CurrencyDisplay defines how currency is displayed.
@@ -89,6 +91,30 @@ const (
 	CurrencyName
 )
 
+// RoundingMode defines how values are rounded to MaxDecimals.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds halves away from zero (e.g., 2.5 -> 3, -2.5 -> -3).
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds halves to the nearest even digit (e.g., 2.5 -> 2, 3.5 -> 4).
+	RoundHalfEven
+	// RoundDown truncates towards zero (e.g., 2.9 -> 2, -2.9 -> -2).
+	RoundDown
+)
+
+// NegativeStyle defines how negative values are displayed.
+type NegativeStyle int
+
+const (
+	// NegativeMinus prefixes the value with the locale's minus sign (e.g., -$1,234.56).
+	NegativeMinus NegativeStyle = iota
+	// NegativeParentheses wraps the number in parentheses, keeping the currency outside (e.g., $(1,234.56)).
+	NegativeParentheses
+	// NegativeAccounting wraps the whole amount, including the currency, in parentheses (e.g., ($1,234.56)).
+	NegativeAccounting
+)
+
 // DefaultFormatConfig returns the default format configuration.
 func DefaultFormatConfig() FormatConfig {
 	return FormatConfig{
@@ -111,10 +137,12 @@ func FormatNumber(locale string, n float64, cfg FormatConfig) string {
 
 	// Round to max decimals
 	if cfg.MaxDecimals >= 0 {
-		multiplier := math.Pow(10, float64(cfg.MaxDecimals))
-		n = math.Round(n*multiplier) / multiplier
+		n = roundDecimals(n, cfg.MaxDecimals, cfg.RoundingMode)
 	}
 
+	// Values that round to zero are not negative
+	negative = negative && n != 0
+
 	// Split into integer and decimal parts
 	intPart := int64(n)
 	decPart := n - float64(intPart)
@@ -127,9 +155,6 @@ func FormatNumber(locale string, n float64, cfg FormatConfig) string {
 
 	// Format decimal part
 	var result strings.Builder
-	if negative {
-		result.WriteString(nf.MinusSign)
-	}
 	result.WriteString(intStr)
 
 	// Add decimal part if needed
@@ -139,9 +164,81 @@ func FormatNumber(locale string, n float64, cfg FormatConfig) string {
 		result.WriteString(decStr)
 	}
 
+	if negative {
+		return applyNegativeStyle(result.String(), nf.MinusSign, cfg.NegativeStyle)
+	}
 	return result.String()
 }
 
+// applyNegativeStyle marks s as negative according to style.
+func applyNegativeStyle(s, minusSign string, style NegativeStyle) string {
+	switch style {
+	case NegativeParentheses, NegativeAccounting:
+		return "(" + s + ")"
+	default: // NegativeMinus
+		return minusSign + s
+	}
+}
+
+// roundDecimals rounds the non-negative value n to the given number of decimal places.
+// It works on the shortest decimal representation of n so that values such as 2.675,
+// which are stored as 2.67499999..., round as written.
+func roundDecimals(n float64, decimals int, mode RoundingMode) float64 {
+	if math.IsInf(n, 0) || math.IsNaN(n) {
+		return n
+	}
+
+	s := strconv.FormatFloat(n, 'f', -1, 64)
+	intStr, fracStr, _ := strings.Cut(s, ".")
+	if len(fracStr) <= decimals {
+		return n
+	}
+
+	digits := []byte(intStr + fracStr[:decimals])
+	rest := fracStr[decimals:]
+
+	roundUp := false
+	switch mode {
+	case RoundDown:
+	case RoundHalfEven:
+		switch {
+		case rest[0] > '5':
+			roundUp = true
+		case rest[0] == '5':
+			if strings.TrimRight(rest[1:], "0") != "" {
+				roundUp = true
+			} else {
+				roundUp = (digits[len(digits)-1]-'0')%2 == 1
+			}
+		}
+	default: // RoundHalfUp
+		roundUp = rest[0] >= '5'
+	}
+
+	if roundUp {
+		digits = incrementDigits(digits)
+	}
+
+	split := len(digits) - decimals
+	rounded, err := strconv.ParseFloat(string(digits[:split])+"."+string(digits[split:]), 64)
+	if err != nil {
+		return n
+	}
+	return rounded
+}
+
+// incrementDigits adds one to the decimal number in digits, growing it on overflow (e.g., 999 -> 1000).
+func incrementDigits(digits []byte) []byte {
+	for i := len(digits) - 1; i >= 0; i-- {
+		if digits[i] < '9' {
+			digits[i]++
+			return digits
+		}
+		digits[i] = '0'
+	}
+	return append([]byte{'1'}, digits...)
+}
+
 // addGrouping adds thousand separators to an integer string.
 func addGrouping(s string, separator string, size int) string {
 	if len(s) <= size {
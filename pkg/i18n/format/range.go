@@ -0,0 +1,118 @@
+package format
+
+import (
+	"strings"
+	"time"
+)
+
+// RangeFormat holds locale-specific range formatting rules.
+type RangeFormat struct {
+	// Separator joins the two ends of a range whose parts contain spaces, e.g. " – ".
+	Separator string
+	// NumberSeparator joins the two ends of a numeric range, e.g. "–".
+	NumberSeparator string
+
+	// Layouts for the medium and long date styles. Each pair holds the start and end layout
+	// used when both dates share the month (SameMonth) or only the year (SameYear).
+	// Dates in different years are formatted in full.
+	MediumSameMonth [2]string
+	MediumSameYear  [2]string
+	LongSameMonth   [2]string
+	LongSameYear    [2]string
+	// DaySeparator joins the start and end layouts of a same-month range.
+	DaySeparator string
+}
+
+// localeRangeFormats contains range formatting rules for various locales.
+// Locales without an entry still get ranges, but without collapsing shared components.
+var localeRangeFormats = map[string]RangeFormat{
+	"en": {
+		Separator:       " – ",
+		NumberSeparator: "–",
+		MediumSameMonth: [2]string{"Jan 2", "2, 2006"},
+		MediumSameYear:  [2]string{"Jan 2", "Jan 2, 2006"},
+		LongSameMonth:   [2]string{"January 2", "2, 2006"},
+		LongSameYear:    [2]string{"January 2", "January 2, 2006"},
+		DaySeparator:    " – ",
+	},
+	"de": {
+		Separator:       " – ",
+		NumberSeparator: "–",
+		MediumSameMonth: [2]string{"2.", "2. Jan. 2006"},
+		MediumSameYear:  [2]string{"2. Jan.", "2. Jan. 2006"},
+		LongSameMonth:   [2]string{"2.", "2. January 2006"},
+		LongSameYear:    [2]string{"2. January", "2. January 2006"},
+		DaySeparator:    "–",
+	},
+}
+
+// defaultRangeFormat is used for locales without range rules.
+var defaultRangeFormat = RangeFormat{
+	Separator:       " – ",
+	NumberSeparator: "–",
+}
+
+// GetRangeFormat returns the range format for a locale.
+func GetRangeFormat(locale string) RangeFormat {
+	// Try exact match
+	if rf, ok := localeRangeFormats[locale]; ok {
+		return rf
+	}
+
+	// Try language only
+	if idx := strings.Index(locale, "-"); idx != -1 {
+		lang := locale[:idx]
+		if rf, ok := localeRangeFormats[lang]; ok {
+			return rf
+		}
+	}
+
+	// No English fallback: its layouts would put month and day in the wrong order for other languages
+	return defaultRangeFormat
+}
+
+// FormatDateRange formats the dates from start to end, omitting the month and year
+// from the start when both dates share them (e.g. "Jan 3 – 5, 2024").
+func FormatDateRange(locale string, start, end time.Time, style DateStyle) string {
+	from := FormatDate(locale, start, style)
+	to := FormatDate(locale, end, style)
+	if from == to {
+		return from
+	}
+
+	rf := GetRangeFormat(locale)
+	sameMonth, sameYear := rf.MediumSameMonth, rf.MediumSameYear
+	if style == DateStyleLong {
+		sameMonth, sameYear = rf.LongSameMonth, rf.LongSameYear
+	}
+
+	// Short dates are compact already and full dates repeat the weekday, so only medium and long collapse
+	collapsible := (style == DateStyleMedium || style == DateStyleLong) && sameMonth[0] != ""
+	if !collapsible || start.Year() != end.Year() {
+		return from + rf.Separator + to
+	}
+
+	dtf := GetDateTimeFormat(locale)
+	if start.Month() == end.Month() {
+		return formatDateTime(start, sameMonth[0], dtf) + rf.DaySeparator + formatDateTime(end, sameMonth[1], dtf)
+	}
+	return formatDateTime(start, sameYear[0], dtf) + rf.Separator + formatDateTime(end, sameYear[1], dtf)
+}
+
+// FormatNumberRange formats the numbers from lo to hi (e.g. "10–20").
+// A range whose ends format identically is shown as a single number.
+func FormatNumberRange(locale string, lo, hi float64, cfg FormatConfig) string {
+	from := FormatNumber(locale, lo, cfg)
+	to := FormatNumber(locale, hi, cfg)
+	if from == to {
+		return from
+	}
+
+	rf := GetRangeFormat(locale)
+	separator := rf.NumberSeparator
+	if strings.ContainsAny(from+to, " -−(") {
+		// A tight dash next to a sign or space is ambiguous, e.g. "-5–-2"
+		separator = rf.Separator
+	}
+	return from + separator + to
+}
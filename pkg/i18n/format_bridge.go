@@ -14,9 +14,11 @@ func formatNumber(locale string, n float64, opts ...FormatOption) string {
 	}
 
 	fmtCfg := format.FormatConfig{
-		MinDecimals: cfg.minDecimals,
-		MaxDecimals: cfg.maxDecimals,
-		UseGrouping: cfg.useGrouping,
+		MinDecimals:   cfg.minDecimals,
+		MaxDecimals:   cfg.maxDecimals,
+		UseGrouping:   cfg.useGrouping,
+		RoundingMode:  format.RoundingMode(cfg.roundingMode),
+		NegativeStyle: format.NegativeStyle(cfg.negativeStyle),
 	}
 
 	return format.FormatNumber(locale, n, fmtCfg)
@@ -34,6 +36,8 @@ func formatCurrency(locale string, amount float64, currency string, opts ...Form
 		MaxDecimals:     cfg.maxDecimals,
 		UseGrouping:     cfg.useGrouping,
 		CurrencyDisplay: format.CurrencyDisplay(cfg.currencyDisplay),
+		RoundingMode:    format.RoundingMode(cfg.roundingMode),
+		NegativeStyle:   format.NegativeStyle(cfg.negativeStyle),
 	}
 
 	return format.FormatCurrency(locale, amount, currency, fmtCfg)
@@ -72,10 +76,35 @@ func formatPercent(locale string, n float64, opts ...FormatOption) string {
 	}
 
 	fmtCfg := format.FormatConfig{
-		MinDecimals: cfg.minDecimals,
-		MaxDecimals: cfg.maxDecimals,
-		UseGrouping: cfg.useGrouping,
+		MinDecimals:   cfg.minDecimals,
+		MaxDecimals:   cfg.maxDecimals,
+		UseGrouping:   cfg.useGrouping,
+		RoundingMode:  format.RoundingMode(cfg.roundingMode),
+		NegativeStyle: format.NegativeStyle(cfg.negativeStyle),
 	}
 
 	return format.FormatPercent(locale, n, fmtCfg)
 }
+
+// formatDateRange formats a date range according to locale conventions.
+func formatDateRange(locale string, start, end time.Time, style DateStyle) string {
+	return format.FormatDateRange(locale, start, end, format.DateStyle(style))
+}
+
+// formatNumberRange formats a numeric range according to locale conventions.
+func formatNumberRange(locale string, lo, hi float64, opts ...FormatOption) string {
+	cfg := defaultFormatConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	fmtCfg := format.FormatConfig{
+		MinDecimals:   cfg.minDecimals,
+		MaxDecimals:   cfg.maxDecimals,
+		UseGrouping:   cfg.useGrouping,
+		RoundingMode:  format.RoundingMode(cfg.roundingMode),
+		NegativeStyle: format.NegativeStyle(cfg.negativeStyle),
+	}
+
+	return format.FormatNumberRange(locale, lo, hi, fmtCfg)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"text/template"
@@ -17,6 +18,9 @@ const (
 	localeContextKey contextKey = "i18n_locale"
 )
 
+// namespaceSeparator separates a namespace registered with WithNamespace from the key it contains.
+const namespaceSeparator = ":"
+
 // DateStyle defines the style for date formatting.
 type DateStyle int
 
@@ -66,6 +70,10 @@ type I18n interface {
 	// Tf translates a message key with named arguments.
 	Tf(ctx context.Context, key string, args map[string]interface{}) string
 
+	// TE is like T but returns an error wrapping ErrKeyNotFound when the key is missing from the
+	// locale chain, instead of applying MissingKeyBehavior. Use it in tests to assert completeness.
+	TE(ctx context.Context, key string, args ...interface{}) (string, error)
+
 	// L returns a locale-specific localizer.
 	L(locale string) Localizer
 
@@ -78,6 +86,10 @@ type I18n interface {
 	// Locales returns all available locales.
 	Locales() []string
 
+	// AddMessages merges msgs into the translations for locale at runtime, e.g. messages loaded
+	// from a database. They take precedence over catalog messages with the same key and survive Reload.
+	AddMessages(locale string, msgs map[string]*Message)
+
 	// Reload reloads translations from the catalog.
 	Reload() error
 }
@@ -93,6 +105,9 @@ type Localizer interface {
 	// Tf translates a message key with named arguments.
 	Tf(key string, args map[string]interface{}) string
 
+	// TE is like T but returns an error wrapping ErrKeyNotFound when the key is missing.
+	TE(key string, args ...interface{}) (string, error)
+
 	// FormatNumber formats a number according to locale conventions.
 	FormatNumber(n float64, opts ...FormatOption) string
 
@@ -117,6 +132,19 @@ type Localizer interface {
 	// FormatPercent formats a number as a percentage.
 	FormatPercent(n float64, opts ...FormatOption) string
 
+	// FormatDateRange formats a date range, collapsing a shared month and year (e.g., "Jan 3 – 5, 2024").
+	FormatDateRange(start, end time.Time, style DateStyle) string
+
+	// FormatNumberRange formats a numeric range (e.g., "10–20").
+	FormatNumberRange(lo, hi float64, opts ...FormatOption) string
+
+	// Collator returns a function that compares strings by locale collation rules, for use with
+	// slices.SortFunc. The returned function is not safe for concurrent use.
+	Collator() func(a, b string) int
+
+	// SortStrings sorts strings in place by locale collation rules.
+	SortStrings(s []string)
+
 	// Locale returns the locale identifier.
 	Locale() string
 
@@ -151,9 +179,16 @@ type i18nImpl struct {
 	catalog        Catalog
 	logger         Logger
 	missingHandler MissingHandler
+	errorHandler   ErrorHandler
 	localeMatcher  *LocaleMatcher
 	localizers     map[string]*localizerImpl
-	localizersMu   sync.RWMutex
+	// namespaces holds the catalogs registered with WithNamespace by name.
+	namespaces        map[string]Catalog
+	namespaceFallback bool
+	// messages holds runtime messages by locale and key, added with AddMessages.
+	messages map[string]map[string]*Message
+	// localizersMu guards localizers, messages, and localeMatcher.
+	localizersMu sync.RWMutex
 }
 
 // New creates a new I18n instance with the given configuration and options.
@@ -166,6 +201,7 @@ func New(cfg Config, opts ...Option) (I18n, error) {
 		config:     &cfg,
 		logger:     NewNoopLogger(),
 		localizers: make(map[string]*localizerImpl),
+		messages:   make(map[string]map[string]*Message),
 	}
 
 	// Apply options
@@ -183,7 +219,7 @@ func New(cfg Config, opts ...Option) (I18n, error) {
 	}
 
 	// Initialize locale matcher
-	impl.localeMatcher = NewLocaleMatcher(impl.catalog.Locales())
+	impl.localeMatcher = NewLocaleMatcher(impl.availableLocales())
 
 	impl.logger.Info("i18n initialized",
 		"default_locale", cfg.DefaultLocale,
@@ -224,6 +260,12 @@ func (i *i18nImpl) Tf(ctx context.Context, key string, args map[string]interface
 	return i.getLocalizer(locale).Tf(key, args)
 }
 
+// TE translates a message key with positional arguments, returning an error if it is missing.
+func (i *i18nImpl) TE(ctx context.Context, key string, args ...interface{}) (string, error) {
+	locale := i.resolveLocale(ctx)
+	return i.getLocalizer(locale).TE(key, args...)
+}
+
 // L returns a locale-specific localizer.
 func (i *i18nImpl) L(locale string) Localizer {
 	return i.getLocalizer(locale)
@@ -239,9 +281,83 @@ func (i *i18nImpl) WithLocale(ctx context.Context, locale string) context.Contex
 	return context.WithValue(ctx, localeContextKey, locale)
 }
 
-// Locales returns all available locales.
+// Locales returns all available locales, including those only added with AddMessages.
 func (i *i18nImpl) Locales() []string {
-	return i.catalog.Locales()
+	i.localizersMu.RLock()
+	defer i.localizersMu.RUnlock()
+	return i.availableLocales()
+}
+
+// AddMessages merges runtime messages for locale and drops the cached localizers for it.
+func (i *i18nImpl) AddMessages(locale string, msgs map[string]*Message) {
+	i.localizersMu.Lock()
+	defer i.localizersMu.Unlock()
+
+	existing, ok := i.messages[locale]
+	if !ok {
+		existing = make(map[string]*Message, len(msgs))
+		i.messages[locale] = existing
+	}
+	for key, msg := range msgs {
+		if msg == nil {
+			continue
+		}
+		// Copy so callers can't mutate a message while it is being read
+		m := *msg
+		existing[key] = &m
+	}
+
+	// Regional localizers fall back to this locale, so drop them too
+	for cached := range i.localizers {
+		if cached == locale || strings.HasPrefix(cached, locale+"-") {
+			delete(i.localizers, cached)
+		}
+	}
+	if !ok {
+		i.localeMatcher = NewLocaleMatcher(i.availableLocales())
+	}
+
+	i.logger.Debug("i18n messages added", "locale", locale, "count", len(msgs))
+}
+
+// availableLocales returns the catalog locales plus runtime-only locales.
+// The caller must hold localizersMu.
+func (i *i18nImpl) availableLocales() []string {
+	locales := slices.Clone(i.catalog.Locales())
+	for _, cat := range i.namespaces {
+		for _, locale := range cat.Locales() {
+			if !slices.Contains(locales, locale) {
+				locales = append(locales, locale)
+			}
+		}
+	}
+	for locale := range i.messages {
+		if !slices.Contains(locales, locale) {
+			locales = append(locales, locale)
+		}
+	}
+	return locales
+}
+
+// lookup finds a message for exactly locale, preferring runtime messages over the catalog.
+func (i *i18nImpl) lookup(locale, key string) (*Message, error) {
+	i.localizersMu.RLock()
+	msg, ok := i.messages[locale][key]
+	i.localizersMu.RUnlock()
+	if ok {
+		return msg, nil
+	}
+
+	if name, short, found := strings.Cut(key, namespaceSeparator); found {
+		if cat, ok := i.namespaces[name]; ok {
+			msg, err := cat.Lookup(locale, short)
+			if (err != nil || msg == nil) && i.namespaceFallback {
+				return i.catalog.Lookup(locale, short)
+			}
+			return msg, err
+		}
+	}
+	return i.catalog.Lookup(locale, key)
 }
 
 // Reload reloads translations from the catalog.
@@ -249,15 +365,18 @@ func (i *i18nImpl) Reload() error {
 	if err := i.catalog.Reload(); err != nil {
 		return err
 	}
+	for name, cat := range i.namespaces {
+		if err := cat.Reload(); err != nil {
+			return fmt.Errorf("namespace %s: %w", name, err)
+		}
+	}
 
-	// Clear cached localizers
+	// Clear cached localizers and update locale matcher
 	i.localizersMu.Lock()
 	i.localizers = make(map[string]*localizerImpl)
+	i.localeMatcher = NewLocaleMatcher(i.availableLocales())
 	i.localizersMu.Unlock()
 
-	// Update locale matcher
-	i.localeMatcher = NewLocaleMatcher(i.catalog.Locales())
-
 	i.logger.Info("i18n reloaded", "available_locales", i.catalog.Locales())
 	return nil
 }
@@ -268,7 +387,10 @@ func (i *i18nImpl) resolveLocale(ctx context.Context) string {
 	if v := ctx.Value(localeContextKey); v != nil {
 		if locale, ok := v.(string); ok && locale != "" {
 			// Try to match the requested locale
-			if matched := i.localeMatcher.Match(locale); matched != "" {
+			i.localizersMu.RLock()
+			matcher := i.localeMatcher
+			i.localizersMu.RUnlock()
+			if matched := matcher.Match(locale); matched != "" {
 				return matched
 			}
 		}
@@ -328,7 +450,7 @@ func (l *localizerImpl) T(key string, args ...interface{}) string {
 	}
 
 	text := msg.SimpleMessage()
-	return l.interpolate(text, args)
+	return l.interpolate(key, text, args)
 }
 
 // Tn translates a message key with pluralization.
@@ -343,7 +465,7 @@ func (l *localizerImpl) Tn(key string, count int, args ...interface{}) string {
 
 	// Prepend count to args for interpolation
 	allArgs := append([]interface{}{count}, args...)
-	return l.interpolate(text, allArgs)
+	return l.interpolate(key, text, allArgs)
 }
 
 // Tf translates a message key with named arguments.
@@ -354,20 +476,32 @@ func (l *localizerImpl) Tf(key string, args map[string]interface{}) string {
 	}
 
 	text := msg.SimpleMessage()
-	return l.interpolateNamed(text, args)
+	return l.interpolateNamed(key, text, args)
+}
+
+// TE translates a message key with positional arguments, returning an error if it is missing.
+// Missing keys are not logged or passed to the missing handler; the caller gets the error instead.
+func (l *localizerImpl) TE(key string, args ...interface{}) (string, error) {
+	msg, err := l.lookupMessage(key)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s (locale %s)", err, key, l.locale)
+	}
+
+	text := msg.SimpleMessage()
+	return l.interpolate(key, text, args), nil
 }
 
 // lookupMessage looks up a message, trying locale chain.
 func (l *localizerImpl) lookupMessage(key string) (*Message, error) {
 	// Try current locale
-	msg, err := l.i18n.catalog.Lookup(l.locale, key)
+	msg, err := l.i18n.lookup(l.locale, key)
 	if err == nil && msg != nil {
 		return msg, nil
 	}
 
 	// Try language only (strip region)
 	if l.parsedLoc != nil && l.parsedLoc.Region != "" {
-		msg, err = l.i18n.catalog.Lookup(l.parsedLoc.Language, key)
+		msg, err = l.i18n.lookup(l.parsedLoc.Language, key)
 		if err == nil && msg != nil {
 			return msg, nil
 		}
@@ -375,7 +509,7 @@ func (l *localizerImpl) lookupMessage(key string) (*Message, error) {
 
 	// Try fallback locale
 	if l.locale != l.i18n.config.FallbackLocale {
-		msg, err = l.i18n.catalog.Lookup(l.i18n.config.FallbackLocale, key)
+		msg, err = l.i18n.lookup(l.i18n.config.FallbackLocale, key)
 		if err == nil && msg != nil {
 			return msg, nil
 		}
@@ -404,8 +538,8 @@ func (l *localizerImpl) handleMissing(key string) string {
 	}
 }
 
-// interpolate interpolates positional arguments into the text.
-func (l *localizerImpl) interpolate(text string, args []interface{}) string {
+// interpolate interpolates positional arguments into the text of the message for key.
+func (l *localizerImpl) interpolate(key, text string, args []interface{}) string {
 	if len(args) == 0 {
 		return text
 	}
@@ -419,34 +553,52 @@ func (l *localizerImpl) interpolate(text string, args []interface{}) string {
 		for i, arg := range args {
 			data[fmt.Sprintf("Arg%d", i)] = arg
 		}
-		return l.interpolateNamed(text, data)
+		return l.interpolateNamed(key, text, data)
 	}
 
 	// Simple printf-style interpolation
+	if l.i18n.config.SafeInterpolation {
+		n, err := checkFormatArgs(text, args)
+		if err != nil {
+			l.handleFormatError(key, text, err)
+			return text
+		}
+		// Unused arguments, like the count Tn passes to a form without a verb, are dropped
+		args = args[:n]
+	}
 	return fmt.Sprintf(text, args...)
 }
 
-// interpolateNamed interpolates named arguments into the text using Go templates.
-func (l *localizerImpl) interpolateNamed(text string, args map[string]interface{}) string {
+// interpolateNamed interpolates named arguments into the text of the message for key using Go templates.
+func (l *localizerImpl) interpolateNamed(key, text string, args map[string]interface{}) string {
 	if args == nil || !strings.Contains(text, "{{") {
 		return text
 	}
 
 	tmpl, err := template.New("msg").Parse(text)
 	if err != nil {
-		l.i18n.logger.Error("template parse error", "text", text, "error", err)
+		l.handleFormatError(key, text, fmt.Errorf("%w: %v", ErrInvalidFormat, err))
 		return text
 	}
 
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, args); err != nil {
-		l.i18n.logger.Error("template execute error", "text", text, "error", err)
+		l.handleFormatError(key, text, fmt.Errorf("%w: %v", ErrTemplateExecution, err))
 		return text
 	}
 
 	return buf.String()
 }
 
+// handleFormatError logs a translation that cannot be interpolated and passes it to the error handler.
+func (l *localizerImpl) handleFormatError(key, text string, err error) {
+	l.i18n.logger.Error("interpolation error", "locale", l.locale, "key", key, "text", text, "error", err)
+
+	if l.i18n.errorHandler != nil {
+		l.i18n.errorHandler(l.locale, key, err)
+	}
+}
+
 // Locale returns the locale identifier.
 func (l *localizerImpl) Locale() string {
 	return l.locale
@@ -518,3 +670,23 @@ func (l *localizerImpl) FormatList(items []string, style ListStyle) string {
 func (l *localizerImpl) FormatPercent(n float64, opts ...FormatOption) string {
 	return formatPercent(l.locale, n, opts...)
 }
+
+// FormatDateRange formats a date range according to locale conventions.
+func (l *localizerImpl) FormatDateRange(start, end time.Time, style DateStyle) string {
+	return formatDateRange(l.locale, start, end, style)
+}
+
+// FormatNumberRange formats a numeric range according to locale conventions.
+func (l *localizerImpl) FormatNumberRange(lo, hi float64, opts ...FormatOption) string {
+	return formatNumberRange(l.locale, lo, hi, opts...)
+}
+
+// Collator returns a comparison function using the collation rules of the locale.
+func (l *localizerImpl) Collator() func(a, b string) int {
+	return newCollator(l.locale).CompareString
+}
+
+// SortStrings sorts strings in place using the collation rules of the locale.
+func (l *localizerImpl) SortStrings(s []string) {
+	newCollator(l.locale).SortStrings(s)
+}
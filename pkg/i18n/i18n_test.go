@@ -2,7 +2,15 @@ package i18n
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/rompi/core-backend/pkg/i18n/catalog"
 )
@@ -287,3 +295,474 @@ func TestMissingKeyBehavior(t *testing.T) {
 		})
 	}
 }
+
+func TestI18n_SafeInterpolation(t *testing.T) {
+	cat := catalog.NewInMemoryCatalog()
+	cat.AddSimpleMessage("en", "cart.total", "%d items for %.2f %s")
+	cat.AddSimpleMessage("en", "order.count", "You have %d orders")
+	cat.AddPluralMessage("en", "inbox", "One message", "%d messages")
+
+	type formatError struct {
+		locale, key string
+		err         error
+	}
+	var errs []formatError
+	i, err := New(Config{
+		DefaultLocale:      "en",
+		FallbackLocale:     "en",
+		MissingKeyBehavior: MissingKeyReturnKey,
+		SafeInterpolation:  true,
+	}, WithCatalog(&catalogAdapter{cat: cat}), WithErrorHandler(func(locale, key string, err error) {
+		errs = append(errs, formatError{locale: locale, key: key, err: err})
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create i18n: %v", err)
+	}
+	l := i.L("en")
+
+	if got, want := l.T("cart.total", 3, 19.5, "EUR"), "3 items for 19.50 EUR"; got != want {
+		t.Errorf("T() with matching args = %q, want %q", got, want)
+	}
+	if got, want := l.Tn("inbox", 1), "One message"; got != want {
+		t.Errorf("Tn() with a form without verbs = %q, want %q", got, want)
+	}
+	if got, want := l.Tn("inbox", 4), "4 messages"; got != want {
+		t.Errorf("Tn() = %q, want %q", got, want)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("error handler called for valid interpolations: %v", errs)
+	}
+
+	if got, want := l.T("order.count", "many"), "You have %d orders"; got != want {
+		t.Errorf("T() with a mismatched arg = %q, want the uninterpolated %q", got, want)
+	}
+	if len(errs) != 1 || errs[0].locale != "en" || errs[0].key != "order.count" || !errors.Is(errs[0].err, ErrInvalidFormat) {
+		t.Fatalf("error handler calls = %v, want one ErrInvalidFormat for order.count", errs)
+	}
+
+	if got, want := l.T("order.count"), "You have %d orders"; got != want {
+		t.Errorf("T() without args = %q, want %q", got, want)
+	}
+
+	unsafe, err := New(Config{
+		DefaultLocale:      "en",
+		FallbackLocale:     "en",
+		MissingKeyBehavior: MissingKeyReturnKey,
+	}, WithCatalog(&catalogAdapter{cat: cat}))
+	if err != nil {
+		t.Fatalf("Failed to create i18n: %v", err)
+	}
+	if got, want := unsafe.L("en").T("order.count", "many"), "You have %!d(string=many) orders"; got != want {
+		t.Errorf("T() without SafeInterpolation = %q, want %q", got, want)
+	}
+}
+
+func TestCheckFormatArgs(t *testing.T) {
+	tests := []struct {
+		format  string
+		args    []interface{}
+		wantN   int
+		wantErr bool
+	}{
+		{format: "%d of %d", args: []interface{}{1, 2}, wantN: 2},
+		{format: "%5.1f%%", args: []interface{}{99.5}, wantN: 1},
+		{format: "%s and %q", args: []interface{}{"a", []byte("b")}, wantN: 2},
+		{format: "%s", args: []interface{}{errors.New("boom")}, wantN: 1},
+		{format: "%v %T", args: []interface{}{nil, 1}, wantN: 2},
+		{format: "%t", args: []interface{}{true}, wantN: 1},
+		{format: "%x", args: []interface{}{"hex"}, wantN: 1},
+		{format: "no verbs", args: []interface{}{1}, wantN: 0},
+		{format: "%[2]s %[1]s", args: []interface{}{"a", "b"}, wantN: 2},
+		{format: "%d", args: []interface{}{"abc"}, wantErr: true},
+		{format: "%s", args: []interface{}{42}, wantErr: true},
+		{format: "%f", args: []interface{}{42}, wantErr: true},
+		{format: "%d %d", args: []interface{}{1}, wantErr: true},
+		{format: "100%", args: []interface{}{1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			n, err := checkFormatArgs(tt.format, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkFormatArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && n != tt.wantN {
+				t.Errorf("checkFormatArgs() = %d, want %d", n, tt.wantN)
+			}
+		})
+	}
+}
+
+func TestI18n_TE(t *testing.T) {
+	cat := catalog.NewInMemoryCatalog()
+	cat.AddSimpleMessage("en", "greeting", "Hello, {{.Arg0}}!")
+	cat.AddSimpleMessage("es", "greeting", "¡Hola, {{.Arg0}}!")
+
+	var missing []string
+	i, err := New(Config{
+		DefaultLocale:      "en",
+		FallbackLocale:     "en",
+		MissingKeyBehavior: MissingKeyReturnKey,
+	}, WithCatalog(&catalogAdapter{cat: cat}), WithMissingHandler(func(locale, key string) {
+		missing = append(missing, key)
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create i18n: %v", err)
+	}
+
+	ctx := i.WithLocale(context.Background(), "es")
+
+	t.Run("present key", func(t *testing.T) {
+		got, err := i.TE(ctx, "greeting", "Mundo")
+		if err != nil {
+			t.Fatalf("TE() error = %v", err)
+		}
+		if want := "¡Hola, Mundo!"; got != want {
+			t.Errorf("TE() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		got, err := i.TE(ctx, "nonexistent")
+		if !errors.Is(err, ErrKeyNotFound) {
+			t.Errorf("TE() error = %v, want ErrKeyNotFound", err)
+		}
+		if got != "" {
+			t.Errorf("TE() = %q, want empty string", got)
+		}
+	})
+
+	t.Run("localizer", func(t *testing.T) {
+		if _, err := i.L("de").TE("nonexistent"); !errors.Is(err, ErrKeyNotFound) {
+			t.Errorf("Localizer.TE() error = %v, want ErrKeyNotFound", err)
+		}
+		// Falls back to English like T
+		if got, err := i.L("de").TE("greeting", "Welt"); err != nil || got != "Hello, Welt!" {
+			t.Errorf("Localizer.TE() = %q, %v, want %q, nil", got, err, "Hello, Welt!")
+		}
+	})
+
+	if len(missing) != 0 {
+		t.Errorf("missing handler called for %v, want no calls", missing)
+	}
+	if got := i.T(ctx, "nonexistent"); got != "nonexistent" {
+		t.Errorf("T() = %q, want the key unchanged", got)
+	}
+}
+
+func TestLocalizer_FormatCurrencyOptions(t *testing.T) {
+	i, err := New(Config{
+		DefaultLocale:      "en",
+		FallbackLocale:     "en",
+		MissingKeyBehavior: MissingKeyReturnKey,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create i18n: %v", err)
+	}
+	l := i.L("en-US")
+
+	if got := l.FormatCurrency(-1234.56, "USD", WithNegativeStyle(NegativeAccounting)); got != "($1,234.56)" {
+		t.Errorf("FormatCurrency() accounting = %q, want %q", got, "($1,234.56)")
+	}
+	if got := l.FormatCurrency(2.665, "USD", WithRoundingMode(RoundHalfEven)); got != "$2.66" {
+		t.Errorf("FormatCurrency() half even = %q, want %q", got, "$2.66")
+	}
+}
+
+func TestLocalizer_FormatRange(t *testing.T) {
+	i, err := New(Config{
+		DefaultLocale:      "en",
+		FallbackLocale:     "en",
+		MissingKeyBehavior: MissingKeyReturnKey,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create i18n: %v", err)
+	}
+
+	start := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	if got := i.L("en-US").FormatDateRange(start, end, DateStyleMedium); got != "Jan 3 – 5, 2024" {
+		t.Errorf("FormatDateRange() = %q, want %q", got, "Jan 3 – 5, 2024")
+	}
+	if got := i.L("de-DE").FormatDateRange(start, end, DateStyleMedium); got != "3.–5. Jan. 2024" {
+		t.Errorf("FormatDateRange() de = %q, want %q", got, "3.–5. Jan. 2024")
+	}
+	if got := i.L("en-US").FormatNumberRange(10, 20); got != "10–20" {
+		t.Errorf("FormatNumberRange() = %q, want %q", got, "10–20")
+	}
+}
+
+func TestLocalizer_Collation(t *testing.T) {
+	i, err := New(Config{
+		DefaultLocale:      "en",
+		FallbackLocale:     "en",
+		MissingKeyBehavior: MissingKeyReturnKey,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create i18n: %v", err)
+	}
+
+	names := []string{"Öberg", "Zander", "Ahlberg", "Åkesson", "Ärlig"}
+
+	bytewise := slices.Clone(names)
+	sort.Strings(bytewise)
+	if want := []string{"Ahlberg", "Zander", "Ärlig", "Åkesson", "Öberg"}; !slices.Equal(bytewise, want) {
+		t.Fatalf("sort.Strings() = %v, want %v", bytewise, want)
+	}
+
+	german := slices.Clone(names)
+	i.L("de-DE").SortStrings(german)
+	if want := []string{"Ahlberg", "Åkesson", "Ärlig", "Öberg", "Zander"}; !slices.Equal(german, want) {
+		t.Errorf("SortStrings() de = %v, want %v", german, want)
+	}
+
+	swedish := slices.Clone(names)
+	i.L("sv-SE").SortStrings(swedish)
+	if want := []string{"Ahlberg", "Zander", "Åkesson", "Ärlig", "Öberg"}; !slices.Equal(swedish, want) {
+		t.Errorf("SortStrings() sv = %v, want %v", swedish, want)
+	}
+
+	compare := i.L("de").Collator()
+	if compare("Ärlig", "Zander") >= 0 {
+		t.Error("Collator() de should order Ärlig before Zander")
+	}
+	if compare("Zander", "Ärlig") <= 0 || compare("Ärlig", "Ärlig") != 0 {
+		t.Error("Collator() de should be a consistent comparison")
+	}
+	sorted := slices.Clone(names)
+	slices.SortFunc(sorted, i.L("sv").Collator())
+	if !slices.Equal(sorted, swedish) {
+		t.Errorf("slices.SortFunc(Collator()) sv = %v, want %v", sorted, swedish)
+	}
+}
+
+func TestI18n_AddMessages(t *testing.T) {
+	cat := catalog.NewInMemoryCatalog()
+	cat.AddSimpleMessage("en", "hello", "Hello")
+
+	i, err := New(Config{
+		DefaultLocale:      "en",
+		FallbackLocale:     "en",
+		MissingKeyBehavior: MissingKeyReturnKey,
+	}, WithCatalog(&catalogAdapter{cat: cat}))
+	if err != nil {
+		t.Fatalf("Failed to create i18n: %v", err)
+	}
+
+	ctx := context.Background()
+	en := i.L("en-US")
+	if got := en.T("promo"); got != "promo" {
+		t.Fatalf("T() before add = %q, want missing key", got)
+	}
+
+	i.AddMessages("en", map[string]*Message{
+		"promo": {Other: "Summer sale"},
+		"hello": {Other: "Hi"},
+	})
+	if got := i.L("en-US").T("promo"); got != "Summer sale" {
+		t.Errorf("T() after add = %q, want %q", got, "Summer sale")
+	}
+	if got := i.T(ctx, "hello"); got != "Hi" {
+		t.Errorf("T() override = %q, want %q", got, "Hi")
+	}
+
+	// A locale only known from runtime messages is matched and listed
+	i.AddMessages("nl", map[string]*Message{"hello": {Other: "Hallo"}})
+	if got := i.T(i.WithLocale(ctx, "nl-BE"), "hello"); got != "Hallo" {
+		t.Errorf("T() new locale = %q, want %q", got, "Hallo")
+	}
+	if got := len(i.Locales()); got != 2 {
+		t.Errorf("Locales() returned %d locales, want 2", got)
+	}
+
+	// Runtime messages survive a catalog reload
+	if err := i.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if got := i.T(ctx, "promo"); got != "Summer sale" {
+		t.Errorf("T() after reload = %q, want %q", got, "Summer sale")
+	}
+}
+
+func TestI18n_Namespaces(t *testing.T) {
+	common := catalog.NewInMemoryCatalog()
+	common.AddSimpleMessage("en", "title", "Home")
+	common.AddSimpleMessage("en", "cancel", "Cancel")
+
+	billing := catalog.NewInMemoryCatalog()
+	billing.AddSimpleMessage("en", "title", "Invoice")
+	billing.AddSimpleMessage("de", "title", "Rechnung")
+
+	auth := catalog.NewInMemoryCatalog()
+	auth.AddSimpleMessage("en", "title", "Sign in")
+
+	newI18n := func(opts ...Option) I18n {
+		t.Helper()
+		opts = append([]Option{
+			WithCatalog(&catalogAdapter{cat: common}),
+			WithNamespace("billing", &catalogAdapter{cat: billing}),
+			WithNamespace("auth", &catalogAdapter{cat: auth}),
+		}, opts...)
+		i, err := New(Config{
+			DefaultLocale:      "en",
+			FallbackLocale:     "en",
+			MissingKeyBehavior: MissingKeyReturnKey,
+		}, opts...)
+		if err != nil {
+			t.Fatalf("Failed to create i18n: %v", err)
+		}
+		return i
+	}
+
+	i := newI18n()
+	ctx := context.Background()
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "title", want: "Home"},
+		{key: "billing:title", want: "Invoice"},
+		{key: "auth:title", want: "Sign in"},
+		{key: "billing:cancel", want: "billing:cancel"},
+		{key: "unknown:title", want: "unknown:title"},
+	}
+	for _, tt := range tests {
+		if got := i.T(ctx, tt.key); got != tt.want {
+			t.Errorf("T(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+
+	// Locales known only to a namespace are matched
+	if got := i.T(i.WithLocale(ctx, "de-DE"), "billing:title"); got != "Rechnung" {
+		t.Errorf("T(billing:title) in de = %q, want %q", got, "Rechnung")
+	}
+	if got := i.T(i.WithLocale(ctx, "de-DE"), "auth:title"); got != "Sign in" {
+		t.Errorf("T(auth:title) in de = %q, want the fallback locale", got)
+	}
+
+	if _, err := i.TE(ctx, "billing:cancel"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("TE(billing:cancel) error = %v, want ErrKeyNotFound", err)
+	}
+
+	fallback := newI18n(WithNamespaceFallback(true))
+	if got := fallback.T(ctx, "billing:cancel"); got != "Cancel" {
+		t.Errorf("T(billing:cancel) with fallback = %q, want %q", got, "Cancel")
+	}
+	if got := fallback.T(ctx, "billing:title"); got != "Invoice" {
+		t.Errorf("T(billing:title) with fallback = %q, want the namespace message", got)
+	}
+}
+
+func TestI18n_AddMessagesConcurrent(t *testing.T) {
+	i, err := New(Config{
+		DefaultLocale:      "en",
+		FallbackLocale:     "en",
+		MissingKeyBehavior: MissingKeyReturnKey,
+	}, WithCatalog(&catalogAdapter{cat: catalog.NewInMemoryCatalog()}))
+	if err != nil {
+		t.Fatalf("Failed to create i18n: %v", err)
+	}
+
+	locales := []string{"en", "de", "fr", "es"}
+	var wg sync.WaitGroup
+	for n := 0; n < 8; n++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				locale := locales[(n+j)%len(locales)]
+				key := fmt.Sprintf("key%d_%d", n, j)
+				i.AddMessages(locale, map[string]*Message{key: {Other: "value " + key}})
+				ctx := i.WithLocale(context.Background(), locale)
+				if got := i.T(ctx, key); got != "value "+key {
+					t.Errorf("T(%q) = %q, want %q", key, got, "value "+key)
+				}
+				_ = i.Locales()
+			}
+		}(n)
+	}
+	wg.Wait()
+
+	if got := len(i.Locales()); got != len(locales) {
+		t.Errorf("Locales() returned %d locales, want %d", got, len(locales))
+	}
+}
+
+func TestCatalog_Describe(t *testing.T) {
+	files := map[string]string{
+		"en.json": `{
+			"greeting": "Hello",
+			"actions": {
+				"open": {
+					"other": "Open",
+					"description": "Button that opens the selected file",
+					"context": "toolbar"
+				}
+			}
+		}`,
+		"en.yaml": `
+greeting: Hello
+actions:
+  open:
+    other: Open
+    description: Button that opens the selected file
+    context: toolbar
+`,
+	}
+
+	for name, content := range files {
+		t.Run(filepath.Ext(name), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+				t.Fatalf("WriteFile() error = %v", err)
+			}
+
+			var cat catalog.Catalog
+			var err error
+			if filepath.Ext(name) == ".json" {
+				cat, err = catalog.NewJSONCatalog(path)
+			} else {
+				cat, err = catalog.NewYAMLCatalog(path)
+			}
+			if err != nil {
+				t.Fatalf("failed to load catalog: %v", err)
+			}
+
+			want := "Button that opens the selected file\ntoolbar"
+			if got, ok := catalog.Describe(cat, "en", "actions.open"); !ok || got != want {
+				t.Errorf("Describe(actions.open) = %q, %v, want %q, true", got, ok, want)
+			}
+			if got, ok := catalog.Describe(cat, "en", "greeting"); ok {
+				t.Errorf("Describe(greeting) = %q, true, want no notes", got)
+			}
+			if _, ok := catalog.Describe(cat, "en", "missing"); ok {
+				t.Error("Describe(missing) reported notes for a missing key")
+			}
+
+			msg, err := (&catalogAdapter{cat: cat}).Lookup("en", "actions.open")
+			if err != nil {
+				t.Fatalf("Lookup() error = %v", err)
+			}
+			if msg.Other != "Open" || msg.Context != "toolbar" {
+				t.Errorf("Lookup() = %+v, want Other %q and Context %q", msg, "Open", "toolbar")
+			}
+
+			i, err := New(Config{
+				DefaultLocale:      "en",
+				FallbackLocale:     "en",
+				MissingKeyBehavior: MissingKeyReturnKey,
+			}, WithCatalog(&catalogAdapter{cat: cat}))
+			if err != nil {
+				t.Fatalf("Failed to create i18n: %v", err)
+			}
+			ctx := i.WithLocale(context.Background(), "en")
+			if got := i.T(ctx, "actions.open"); got != "Open" {
+				t.Errorf("T(actions.open) = %q, want %q", got, "Open")
+			}
+			if got := i.T(ctx, "greeting"); got != "Hello" {
+				t.Errorf("T(greeting) = %q, want %q", got, "Hello")
+			}
+		})
+	}
+}
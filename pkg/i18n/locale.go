@@ -29,7 +29,9 @@ type Locale struct {
 }
 
 // ParseLocale parses a locale string into a Locale struct.
-// Supported formats: "en", "en-US", "en_US", "zh-Hans", "zh-Hans-CN".
+// Supported formats: "en", "en-US", "en_US", "es-419", "zh-Hans", "zh-Hans-CN".
+// BCP 47 variants ("de-DE-1996"), extensions ("en-US-u-ca-gregory"), and private use subtags
+// ("en-x-custom") are accepted but not kept.
 func ParseLocale(s string) (*Locale, error) {
 	if s == "" {
 		return nil, fmt.Errorf("%w: empty locale string", ErrInvalidLocale)
@@ -46,36 +48,51 @@ func ParseLocale(s string) (*Locale, error) {
 	if len(locale.Language) < 2 || len(locale.Language) > 3 {
 		return nil, fmt.Errorf("%w: invalid language code: %s", ErrInvalidLocale, parts[0])
 	}
+	parts = parts[1:]
 
-	if len(parts) == 1 {
-		return locale, nil
+	// Optional 4-letter script (e.g., "Hans", "Hant")
+	if len(parts) > 0 && len(parts[0]) == 4 && isAlpha(parts[0]) {
+		locale.Script = strings.Title(strings.ToLower(parts[0]))
+		parts = parts[1:]
 	}
 
-	// Handle second part (can be script or region)
-	if len(parts) >= 2 {
-		part := parts[1]
-		if len(part) == 4 {
-			// 4-letter code is a script (e.g., "Hans", "Hant")
-			locale.Script = strings.Title(strings.ToLower(part))
-		} else if len(part) == 2 {
-			// 2-letter code is a region
-			locale.Region = strings.ToUpper(part)
-		} else {
+	// Optional region: 2 letters (e.g., "US") or 3 digits (e.g., "419")
+	if len(parts) > 0 && (len(parts[0]) == 2 && isAlpha(parts[0]) || len(parts[0]) == 3 && isDigits(parts[0])) {
+		locale.Region = strings.ToUpper(parts[0])
+		parts = parts[1:]
+	}
+
+	// Variants are 5-8 characters, or 4 starting with a digit; a single-character subtag starts
+	// the extensions and private use section, which runs to the end.
+	for _, part := range parts {
+		switch {
+		case len(part) == 1:
+			return locale, nil
+		case len(part) >= 5 && len(part) <= 8, len(part) == 4 && isDigits(part[:1]):
+		default:
 			return nil, fmt.Errorf("%w: invalid locale part: %s", ErrInvalidLocale, part)
 		}
 	}
 
-	// Handle third part (must be region if script was second)
-	if len(parts) >= 3 {
-		part := parts[2]
-		if len(part) == 2 {
-			locale.Region = strings.ToUpper(part)
-		} else {
-			return nil, fmt.Errorf("%w: invalid region code: %s", ErrInvalidLocale, part)
+	return locale, nil
+}
+
+func isAlpha(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
 		}
 	}
+	return true
+}
 
-	return locale, nil
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
 }
 
 // String returns the canonical string representation of the locale.
@@ -140,7 +157,11 @@ func NewLocaleMatcher(available []string) *LocaleMatcher {
 }
 
 // Match finds the best matching locale for the requested locale.
-// It tries exact match first, then language-region, then language only.
+// It tries an exact match first, ignoring variants and extensions, then language-script,
+// then language-region, then language only. A locale with a different script is used only
+// when nothing else shares the language, so "zh-Hant-HK" prefers "zh-Hant" and "zh-Hans"
+// prefers "zh" over "zh-Hant". A request without a script matches a region of any script, so
+// "zh-TW" picks "zh-Hant-TW".
 func (m *LocaleMatcher) Match(requested string) string {
 	if requested == "" {
 		return ""
@@ -163,23 +184,60 @@ func (m *LocaleMatcher) Match(requested string) string {
 		}
 	}
 
-	// Try language-region match (ignoring script)
-	if reqLocale.Region != "" {
-		for avail, parsed := range m.parsed {
-			if strings.EqualFold(parsed.Language, reqLocale.Language) &&
-				strings.EqualFold(parsed.Region, reqLocale.Region) {
-				return avail
-			}
+	sameLanguage := func(l *Locale) bool {
+		return strings.EqualFold(l.Language, reqLocale.Language)
+	}
+	// An available locale without a script is assumed to cover every script of its language.
+	compatibleScript := func(l *Locale) bool {
+		return l.Script == "" || strings.EqualFold(l.Script, reqLocale.Script)
+	}
+
+	// Try language-script match, preferring a locale without a region
+	if reqLocale.Script != "" {
+		if avail := m.first(func(l *Locale) bool {
+			return sameLanguage(l) && strings.EqualFold(l.Script, reqLocale.Script) && l.Region == ""
+		}); avail != "" {
+			return avail
+		}
+		if avail := m.first(func(l *Locale) bool {
+			return sameLanguage(l) && strings.EqualFold(l.Script, reqLocale.Script)
+		}); avail != "" {
+			return avail
 		}
 	}
 
-	// Try language-only match
-	for avail, parsed := range m.parsed {
-		if strings.EqualFold(parsed.Language, reqLocale.Language) {
+	// Try language-region match; a request without a script accepts any script in that region
+	if reqLocale.Region != "" {
+		if avail := m.first(func(l *Locale) bool {
+			return sameLanguage(l) && (reqLocale.Script == "" || compatibleScript(l)) &&
+				strings.EqualFold(l.Region, reqLocale.Region)
+		}); avail != "" {
 			return avail
 		}
 	}
 
+	// Try language-only match: the bare language, then any compatible script, then any script
+	if avail := m.first(func(l *Locale) bool {
+		return sameLanguage(l) && l.Script == "" && l.Region == ""
+	}); avail != "" {
+		return avail
+	}
+	if avail := m.first(func(l *Locale) bool {
+		return sameLanguage(l) && compatibleScript(l)
+	}); avail != "" {
+		return avail
+	}
+	return m.first(sameLanguage)
+}
+
+// first returns the first available locale, in the order given to NewLocaleMatcher, for which
+// match returns true, or "" if there is none.
+func (m *LocaleMatcher) first(match func(*Locale) bool) string {
+	for _, avail := range m.available {
+		if parsed, ok := m.parsed[avail]; ok && match(parsed) {
+			return avail
+		}
+	}
 	return ""
 }
 
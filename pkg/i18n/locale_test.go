@@ -37,6 +37,31 @@ func TestParseLocale(t *testing.T) {
 			input: "zh-Hans-CN",
 			want:  &Locale{Language: "zh", Script: "Hans", Region: "CN"},
 		},
+		{
+			name:  "numeric region",
+			input: "es-419",
+			want:  &Locale{Language: "es", Region: "419"},
+		},
+		{
+			name:  "variant",
+			input: "de-DE-1996",
+			want:  &Locale{Language: "de", Region: "DE"},
+		},
+		{
+			name:  "extension",
+			input: "zh-Hant-TW-u-nu-hanidec",
+			want:  &Locale{Language: "zh", Script: "Hant", Region: "TW"},
+		},
+		{
+			name:  "private use",
+			input: "en-x-custom",
+			want:  &Locale{Language: "en"},
+		},
+		{
+			name:    "invalid subtag",
+			input:   "en-USA",
+			wantErr: true,
+		},
 		{
 			name:  "case normalization",
 			input: "EN-us",
@@ -196,6 +221,72 @@ func TestLocaleMatcher_Match(t *testing.T) {
 	}
 }
 
+func TestLocaleMatcher_MatchScript(t *testing.T) {
+	tests := []struct {
+		name      string
+		available []string
+		requested string
+		want      string
+	}{
+		{
+			name:      "script before region",
+			available: []string{"zh", "zh-Hans", "zh-Hant"},
+			requested: "zh-Hant-HK",
+			want:      "zh-Hant",
+		},
+		{
+			name:      "script without region preferred",
+			available: []string{"zh-Hant-TW", "zh-Hant"},
+			requested: "zh-Hant-HK",
+			want:      "zh-Hant",
+		},
+		{
+			name:      "other script skipped for bare language",
+			available: []string{"zh-Hant", "zh"},
+			requested: "zh-Hans",
+			want:      "zh",
+		},
+		{
+			name:      "region with other script skipped",
+			available: []string{"zh-Hans-TW", "zh-Hant"},
+			requested: "zh-Hant-TW",
+			want:      "zh-Hant",
+		},
+		{
+			name:      "region without script matches any script",
+			available: []string{"zh-Hans-CN", "zh-Hant-TW"},
+			requested: "zh-TW",
+			want:      "zh-Hant-TW",
+		},
+		{
+			name:      "script falls back to language",
+			available: []string{"en", "sr"},
+			requested: "sr-Latn",
+			want:      "sr",
+		},
+		{
+			name:      "other script as last resort",
+			available: []string{"en", "sr-Cyrl"},
+			requested: "sr-Latn",
+			want:      "sr-Cyrl",
+		},
+		{
+			name:      "extensions ignored",
+			available: []string{"en", "en-US"},
+			requested: "en-US-u-ca-gregory",
+			want:      "en-US",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewLocaleMatcher(tt.available).Match(tt.requested); got != tt.want {
+				t.Errorf("LocaleMatcher.Match(%q) = %q, want %q", tt.requested, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestParseAcceptLanguage(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -8,6 +8,10 @@ type Message struct {
 	// Description provides context for translators.
 	Description string `json:"description,omitempty"`
 
+	// Context tells translators where the message appears, to tell apart keys with the same source
+	// text, such as "Open" as a button label or as a status.
+	Context string `json:"context,omitempty"`
+
 	// One is the singular form of the message.
 	One string `json:"one,omitempty"`
 
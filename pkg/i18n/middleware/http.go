@@ -3,6 +3,9 @@ package middleware
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
 	"net/http"
 	"strings"
 )
@@ -38,6 +41,7 @@ type httpMiddleware struct {
 	cookieSecure    bool
 	cookieHTTPOnly  bool
 	cookieSameSite  http.SameSite
+	cookieSecret    []byte
 }
 
 // localeMatcher matches requested locales to available ones.
@@ -124,7 +128,7 @@ func HTTP(i18n I18n, opts ...HTTPOption) func(http.Handler) http.Handler {
 			if m.setCookie && locale != "" && m.cookieName != "" {
 				http.SetCookie(w, &http.Cookie{
 					Name:     m.cookieName,
-					Value:    locale,
+					Value:    m.encodeCookie(locale),
 					Path:     m.cookiePath,
 					MaxAge:   m.cookieMaxAge,
 					Secure:   m.cookieSecure,
@@ -156,8 +160,10 @@ func (m *httpMiddleware) detectLocale(r *http.Request) string {
 	// 2. Try cookie
 	if m.cookieName != "" {
 		if cookie, err := r.Cookie(m.cookieName); err == nil && cookie.Value != "" {
-			if matched := m.localeMatcher.Match(cookie.Value); matched != "" {
-				return matched
+			if value, ok := m.decodeCookie(cookie.Value); ok {
+				if matched := m.localeMatcher.Match(value); matched != "" {
+					return matched
+				}
 			}
 		}
 	}
@@ -183,6 +189,40 @@ func (m *httpMiddleware) detectLocale(r *http.Request) string {
 	return m.defaultLocale
 }
 
+// encodeCookie returns the cookie value for locale, appending an HMAC signature when a secret is set.
+func (m *httpMiddleware) encodeCookie(locale string) string {
+	if len(m.cookieSecret) == 0 {
+		return locale
+	}
+	return locale + "." + m.signCookie(locale)
+}
+
+// decodeCookie returns the locale stored in a cookie value.
+// With a secret set, values with a missing or invalid signature are rejected.
+func (m *httpMiddleware) decodeCookie(value string) (string, bool) {
+	if len(m.cookieSecret) == 0 {
+		return value, true
+	}
+
+	idx := strings.LastIndex(value, ".")
+	if idx == -1 {
+		return "", false
+	}
+
+	locale, sig := value[:idx], value[idx+1:]
+	if !hmac.Equal([]byte(sig), []byte(m.signCookie(locale))) {
+		return "", false
+	}
+	return locale, true
+}
+
+// signCookie computes the base64url-encoded HMAC-SHA256 of the cookie name and locale.
+func (m *httpMiddleware) signCookie(locale string) string {
+	mac := hmac.New(sha256.New, m.cookieSecret)
+	mac.Write([]byte(m.cookieName + "=" + locale))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
 // parseAcceptLanguage parses the Accept-Language header and returns the best match.
 func (m *httpMiddleware) parseAcceptLanguage(header string) string {
 	if header == "" {
@@ -342,6 +382,15 @@ func WithCookieConfig(maxAge int, path string, secure, httpOnly bool, sameSite h
 	}
 }
 
+// WithSignedCookie signs the locale cookie with HMAC-SHA256 using secret.
+// Cookies with a missing or invalid signature are ignored, so detection falls through
+// to the header and Accept-Language checks.
+func WithSignedCookie(secret []byte) HTTPOption {
+	return func(m *httpMiddleware) {
+		m.cookieSecret = secret
+	}
+}
+
 // LocaleFromContext extracts the locale from a context.
 func LocaleFromContext(ctx context.Context) string {
 	if v := ctx.Value(localeContextKey); v != nil {
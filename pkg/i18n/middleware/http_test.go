@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// stubI18n implements I18n with a fixed set of locales.
+type stubI18n struct {
+	locales []string
+}
+
+func (s stubI18n) WithLocale(ctx context.Context, locale string) context.Context {
+	return ContextWithLocale(ctx, locale)
+}
+
+func (s stubI18n) Locales() []string {
+	return s.locales
+}
+
+func TestHTTP_SignedCookie(t *testing.T) {
+	secret := []byte("test-secret")
+	var got string
+	handler := HTTP(stubI18n{locales: []string{"en", "es", "fr"}},
+		WithCookie("lang"),
+		WithSetCookie(true),
+		WithSignedCookie(secret),
+		WithAcceptLanguage(),
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = LocaleFromContext(r.Context())
+	}))
+
+	serve := func(cookie, acceptLanguage string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if cookie != "" {
+			req.AddCookie(&http.Cookie{Name: "lang", Value: cookie})
+		}
+		if acceptLanguage != "" {
+			req.Header.Set("Accept-Language", acceptLanguage)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Set: the detected locale is written as a signed cookie
+	rec := serve("", "es")
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("expected 1 cookie, got %d", len(cookies))
+	}
+	signed := cookies[0].Value
+	if signed == "es" || len(signed) <= len("es.") {
+		t.Fatalf("expected signed cookie value, got %q", signed)
+	}
+
+	tests := []struct {
+		name           string
+		cookie         string
+		acceptLanguage string
+		want           string
+	}{
+		{"valid signature", signed, "fr", "es"},
+		{"unsigned value", "es", "fr", "fr"},
+		{"tampered locale", "fr" + signed[len("es"):], "en", "en"},
+		{"tampered signature", signed + "x", "fr", "fr"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serve(tt.cookie, tt.acceptLanguage)
+			if got != tt.want {
+				t.Errorf("locale = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTTP_SignedCookieSecretMismatch(t *testing.T) {
+	m := &httpMiddleware{cookieName: "lang", cookieSecret: []byte("a")}
+	value := m.encodeCookie("es")
+
+	other := &httpMiddleware{cookieName: "lang", cookieSecret: []byte("b")}
+	if _, ok := other.decodeCookie(value); ok {
+		t.Error("expected cookie signed with a different secret to be rejected")
+	}
+	if locale, ok := m.decodeCookie(value); !ok || locale != "es" {
+		t.Errorf("decodeCookie() = %q, %v; want es, true", locale, ok)
+	}
+}
@@ -21,6 +21,29 @@ func WithCatalog(catalog Catalog) Option {
 	}
 }
 
+// WithNamespace routes keys prefixed with name and a colon, such as "billing:invoice.title", to
+// catalog, which holds them without the prefix ("invoice.title"). Register one per translation domain.
+func WithNamespace(name string, catalog Catalog) Option {
+	return func(i *i18nImpl) {
+		if name == "" || catalog == nil {
+			return
+		}
+		if i.namespaces == nil {
+			i.namespaces = make(map[string]Catalog)
+		}
+		i.namespaces[name] = catalog
+	}
+}
+
+// WithNamespaceFallback makes a namespaced key missing from its namespace catalog fall back to the
+// same key without the prefix in the main catalog, e.g. for strings shared by all domains.
+// Disabled by default, so a namespace only ever resolves its own keys.
+func WithNamespaceFallback(enabled bool) Option {
+	return func(i *i18nImpl) {
+		i.namespaceFallback = enabled
+	}
+}
+
 // WithMissingHandler sets a custom handler for missing translations.
 func WithMissingHandler(handler MissingHandler) Option {
 	return func(i *i18nImpl) {
@@ -33,6 +56,20 @@ func WithMissingHandler(handler MissingHandler) Option {
 // MissingHandler is a function called when a translation is missing.
 type MissingHandler func(locale, key string)
 
+// WithErrorHandler sets a handler for translations that cannot be interpolated, such as a template
+// that fails to parse or, with Config.SafeInterpolation, a verb that does not match its argument.
+func WithErrorHandler(handler ErrorHandler) Option {
+	return func(i *i18nImpl) {
+		if handler != nil {
+			i.errorHandler = handler
+		}
+	}
+}
+
+// ErrorHandler is a function called when a translation cannot be interpolated.
+// err wraps ErrInvalidFormat or ErrTemplateExecution.
+type ErrorHandler func(locale, key string, err error)
+
 // FormatOption is a functional option for configuring formatting.
 type FormatOption func(*formatConfig)
 
@@ -42,6 +79,8 @@ type formatConfig struct {
 	maxDecimals     int
 	useGrouping     bool
 	currencyDisplay CurrencyDisplay
+	roundingMode    RoundingMode
+	negativeStyle   NegativeStyle
 }
 
 // defaultFormatConfig returns the default formatting configuration.
@@ -51,6 +90,8 @@ func defaultFormatConfig() *formatConfig {
 		maxDecimals:     3,
 		useGrouping:     true,
 		currencyDisplay: CurrencySymbol,
+		roundingMode:    RoundHalfUp,
+		negativeStyle:   NegativeMinus,
 	}
 }
 
@@ -86,6 +127,20 @@ func WithCurrencyDisplay(display CurrencyDisplay) FormatOption {
 	}
 }
 
+// WithRoundingMode sets how values are rounded to the maximum number of decimal places.
+func WithRoundingMode(mode RoundingMode) FormatOption {
+	return func(c *formatConfig) {
+		c.roundingMode = mode
+	}
+}
+
+// WithNegativeStyle sets how negative values are displayed.
+func WithNegativeStyle(style NegativeStyle) FormatOption {
+	return func(c *formatConfig) {
+		c.negativeStyle = style
+	}
+}
+
 // CurrencyDisplay defines how currency is displayed.
 type CurrencyDisplay int
 
@@ -99,3 +154,31 @@ const (
 	// CurrencyName displays the currency name (e.g., US Dollar).
 	CurrencyName
 )
+
+// RoundingMode defines how values are rounded when formatting.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds halves away from zero (e.g., 2.5 -> 3).
+	RoundHalfUp RoundingMode = iota
+
+	// RoundHalfEven rounds halves to the nearest even digit (e.g., 2.5 -> 2, 3.5 -> 4).
+	RoundHalfEven
+
+	// RoundDown truncates towards zero (e.g., 2.9 -> 2).
+	RoundDown
+)
+
+// NegativeStyle defines how negative values are displayed.
+type NegativeStyle int
+
+const (
+	// NegativeMinus prefixes the value with a minus sign (e.g., -$1,234.56).
+	NegativeMinus NegativeStyle = iota
+
+	// NegativeParentheses wraps the number in parentheses (e.g., $(1,234.56)).
+	NegativeParentheses
+
+	// NegativeAccounting wraps the whole amount, including the currency, in parentheses (e.g., ($1,234.56)).
+	NegativeAccounting
+)
@@ -0,0 +1,104 @@
+package i18n
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// checkFormatArgs reports whether the printf verbs in format accept args, so that Sprintf will not
+// produce markers like "%!d(string=abc)". It returns the number of args the verbs consume. Formats
+// using explicit argument indexes ("%[1]d") or "*" widths are not checked and consume every arg.
+func checkFormatArgs(format string, args []interface{}) (int, error) {
+	argNum := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		i++
+		// Flags, width, and precision
+		for i < len(format) && isFormatModifier(format[i]) {
+			if format[i] == '[' || format[i] == '*' {
+				return len(args), nil
+			}
+			i++
+		}
+		if i >= len(format) {
+			return 0, fmt.Errorf("%w: format ends with an incomplete verb", ErrInvalidFormat)
+		}
+		verb := format[i]
+		if verb == '%' {
+			continue
+		}
+		if argNum >= len(args) {
+			return 0, fmt.Errorf("%w: verb %%%c has no argument", ErrInvalidFormat, verb)
+		}
+		if !verbAccepts(verb, args[argNum]) {
+			return 0, fmt.Errorf("%w: verb %%%c does not accept argument %d of type %T",
+				ErrInvalidFormat, verb, argNum+1, args[argNum])
+		}
+		argNum++
+	}
+	return argNum, nil
+}
+
+// isFormatModifier reports whether c can appear between '%' and the verb.
+func isFormatModifier(c byte) bool {
+	switch c {
+	case '+', '-', '#', ' ', '0', '.', '[', ']', '*':
+		return true
+	}
+	return c >= '1' && c <= '9'
+}
+
+// verbAccepts reports whether fmt formats arg with verb without an error marker.
+func verbAccepts(verb byte, arg interface{}) bool {
+	switch verb {
+	case 'v', 'T':
+		return true
+	}
+	if arg == nil {
+		return false
+	}
+	switch arg.(type) {
+	case fmt.Formatter:
+		return true
+	case error, fmt.Stringer:
+		if verb == 's' || verb == 'q' || verb == 'x' || verb == 'X' {
+			return true
+		}
+	}
+
+	kind := reflect.TypeOf(arg).Kind()
+	isInt := kind >= reflect.Int && kind <= reflect.Uintptr
+	isFloat := kind >= reflect.Float32 && kind <= reflect.Complex128
+	isString := kind == reflect.String || isByteSlice(arg)
+
+	switch verb {
+	case 'd', 'o', 'O', 'c', 'U':
+		return isInt
+	case 'b':
+		return isInt || isFloat
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+		return isFloat
+	case 'x', 'X':
+		return isInt || isFloat || isString
+	case 's':
+		return isString
+	case 'q':
+		return isString || isInt
+	case 't':
+		return kind == reflect.Bool
+	case 'p':
+		switch kind {
+		case reflect.Pointer, reflect.Chan, reflect.Func, reflect.Map, reflect.Slice, reflect.UnsafePointer:
+			return true
+		}
+	}
+	return false
+}
+
+// isByteSlice reports whether arg is a []byte, which string verbs accept.
+func isByteSlice(arg interface{}) bool {
+	t := reflect.TypeOf(arg)
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
@@ -3,6 +3,8 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -17,6 +19,13 @@ type Client struct {
 	config    *Config
 	logger    Logger
 	queryHook QueryHook
+	observer  QueryObserver
+
+	// primary receives writes; replicas, when configured, receive reads in round-robin order.
+	primary      Querier
+	replicas     []Querier
+	replicaPools []*pgxpool.Pool
+	nextReplica  *atomic.Uint64
 }
 
 // PoolStats contains connection pool statistics.
@@ -39,8 +48,9 @@ func New(cfg Config, opts ...Option) (*Client, error) {
 	}
 
 	client := &Client{
-		config: &cfg,
-		logger: NewNoopLogger(),
+		config:      &cfg,
+		logger:      NewNoopLogger(),
+		nextReplica: new(atomic.Uint64),
 	}
 
 	// Apply options
@@ -48,20 +58,63 @@ func New(cfg Config, opts ...Option) (*Client, error) {
 		opt(client)
 	}
 
-	// Configure pool
-	poolConfig, err := pgxpool.ParseConfig(cfg.ConnectionURL())
+	pool, err := connectPool(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrConnectionFailed, err)
+		return nil, err
 	}
 
-	poolConfig.MaxConns = cfg.MaxConns
-	poolConfig.MinConns = cfg.MinConns
-	poolConfig.MaxConnLifetime = cfg.MaxConnLifetime
-	poolConfig.MaxConnIdleTime = cfg.MaxConnIdleTime
-	poolConfig.HealthCheckPeriod = time.Minute
+	client.pool = pool
+	client.primary = pool
+	client.logger.Info("postgres client connected",
+		"host", cfg.Host,
+		"port", cfg.Port,
+		"database", cfg.Database,
+		"schema", cfg.Schema,
+	)
 
-	// Set connect timeout
-	poolConfig.ConnConfig.ConnectTimeout = cfg.ConnectTimeout
+	return client, nil
+}
+
+// NewWithReplicas creates a client that sends read-only Query and QueryRow statements to the replicas
+// in round-robin order, and data-modifying statements such as INSERT ... RETURNING, Exec,
+// Transaction, and CopyFrom to the primary. Use Primary when a read must observe a preceding write
+// or calls a function with side effects, which cannot be told apart from a plain SELECT.
+func NewWithReplicas(primary Config, replicas []Config, opts ...Option) (*Client, error) {
+	for i := range replicas {
+		if err := replicas[i].Validate(); err != nil {
+			return nil, fmt.Errorf("replica %d: %w", i, err)
+		}
+	}
+
+	client, err := New(primary, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, cfg := range replicas {
+		pool, err := connectPool(cfg)
+		if err != nil {
+			client.Close()
+			return nil, fmt.Errorf("replica %d: %w", i, err)
+		}
+		client.replicaPools = append(client.replicaPools, pool)
+		client.replicas = append(client.replicas, pool)
+		client.logger.Info("postgres replica connected",
+			"host", cfg.Host,
+			"port", cfg.Port,
+			"database", cfg.Database,
+		)
+	}
+
+	return client, nil
+}
+
+// connectPool opens and pings a connection pool for cfg.
+func connectPool(cfg Config) (*pgxpool.Pool, error) {
+	poolConfig, err := newPoolConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create pool with timeout context
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.ConnectTimeout)
@@ -78,21 +131,14 @@ func New(cfg Config, opts ...Option) (*Client, error) {
 		return nil, fmt.Errorf("%w: %v", ErrConnectionFailed, err)
 	}
 
-	client.pool = pool
-	client.logger.Info("postgres client connected",
-		"host", cfg.Host,
-		"port", cfg.Port,
-		"database", cfg.Database,
-		"schema", cfg.Schema,
-	)
-
-	return client, nil
+	return pool, nil
 }
 
 // NewFromURL creates a new PostgreSQL client from a connection URL.
 func NewFromURL(url string, opts ...Option) (*Client, error) {
 	client := &Client{
-		logger: NewNoopLogger(),
+		logger:      NewNoopLogger(),
+		nextReplica: new(atomic.Uint64),
 	}
 
 	// Apply options
@@ -119,23 +165,150 @@ func NewFromURL(url string, opts ...Option) (*Client, error) {
 	}
 
 	client.pool = pool
+	client.primary = pool
 	client.logger.Info("postgres client connected from URL")
 
 	return client, nil
 }
 
-// Pool returns the underlying connection pool.
+// newPoolConfig translates cfg into a pgxpool configuration.
+func newPoolConfig(cfg Config) (*pgxpool.Config, error) {
+	poolConfig, err := pgxpool.ParseConfig(cfg.ConnectionURL())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrConnectionFailed, err)
+	}
+
+	poolConfig.MaxConns = cfg.MaxConns
+	poolConfig.MinConns = cfg.MinConns
+	poolConfig.MaxConnLifetime = cfg.MaxConnLifetime
+	poolConfig.MaxConnIdleTime = cfg.MaxConnIdleTime
+	poolConfig.HealthCheckPeriod = cfg.HealthCheckPeriod
+	if poolConfig.HealthCheckPeriod == 0 {
+		poolConfig.HealthCheckPeriod = time.Minute
+	}
+
+	// Set connect timeout
+	poolConfig.ConnConfig.ConnectTimeout = cfg.ConnectTimeout
+
+	mode, err := parseQueryExecMode(cfg.QueryExecMode)
+	if err != nil {
+		return nil, fmt.Errorf("%w: POSTGRES_QUERY_EXEC_MODE: %v", ErrInvalidConfig, err)
+	}
+	poolConfig.ConnConfig.DefaultQueryExecMode = mode
+
+	// pgxpool also traces acquires and releases through the connection tracer
+	traceLog, err := newTraceLog(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%w: POSTGRES_LOG_LEVEL: %v", ErrInvalidConfig, err)
+	}
+	if traceLog != nil {
+		poolConfig.ConnConfig.Tracer = traceLog
+	}
+
+	return poolConfig, nil
+}
+
+// Pool returns the underlying connection pool of the primary.
 func (c *Client) Pool() *pgxpool.Pool {
 	return c.pool
 }
 
-// Query executes a query that returns rows.
+// Primary returns a view of the client that sends every statement, including reads, to the primary.
+// It shares the pools of c and must not be closed separately.
+func (c *Client) Primary() *Client {
+	return &Client{
+		pool:        c.pool,
+		config:      c.config,
+		logger:      c.logger,
+		queryHook:   c.queryHook,
+		observer:    c.observer,
+		primary:     c.primary,
+		nextReplica: c.nextReplica,
+	}
+}
+
+// reader returns the transaction in ctx, if any, or else the pool that serves sql: a replica for
+// read-only statements and the primary for anything with side effects, such as INSERT ... RETURNING.
+func (c *Client) reader(ctx context.Context, sql string) Querier {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx
+	}
+	if len(c.replicas) == 0 || !isReadOnly(sql) {
+		return c.primary
+	}
+	n := c.nextReplica.Add(1) - 1
+	return c.replicas[n%uint64(len(c.replicas))]
+}
+
+// readOnlyKeywords are the statements that may run on a replica, provided they contain none of
+// writeKeywords outside literals and comments.
+var (
+	readOnlyKeywords = map[string]bool{"SELECT": true, "WITH": true, "VALUES": true, "TABLE": true, "SHOW": true}
+	writeKeywords    = map[string]bool{
+		"INSERT": true, "UPDATE": true, "DELETE": true, "MERGE": true,
+		"INTO": true, "SHARE": true, "RETURNING": true,
+	}
+)
+
+// isReadOnly reports whether sql is a plain read. It is conservative: writes in CTEs, SELECT INTO,
+// and row-locking clauses such as FOR UPDATE all count as writes.
+func isReadOnly(sql string) bool {
+	first := true
+	for i := 0; i < len(sql); {
+		ch := sql[i]
+		switch {
+		case ch == '\'' || ch == '"':
+			i = skipQuoted(sql, i, ch)
+		case ch == '-' && i+1 < len(sql) && sql[i+1] == '-':
+			if end := strings.IndexByte(sql[i:], '\n'); end >= 0 {
+				i += end
+			} else {
+				i = len(sql)
+			}
+		case ch == '/' && i+1 < len(sql) && sql[i+1] == '*':
+			i = skipBlockComment(sql, i)
+		case ch == '$' && (i == 0 || !isNamePart(sql[i-1])) && dollarTag(sql, i) != "":
+			tag := dollarTag(sql, i)
+			if end := strings.Index(sql[i+len(tag):], tag); end >= 0 {
+				i += len(tag) + end + len(tag)
+			} else {
+				i = len(sql)
+			}
+		case isNameStart(ch):
+			j := i
+			for j < len(sql) && isNamePart(sql[j]) {
+				j++
+			}
+			word := strings.ToUpper(sql[i:j])
+			if first && !readOnlyKeywords[word] || writeKeywords[word] {
+				return false
+			}
+			first = false
+			i = j
+		default:
+			i++
+		}
+	}
+	return !first
+}
+
+// writer returns the transaction in ctx, if any, or else the primary.
+func (c *Client) writer(ctx context.Context) Querier {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx
+	}
+	return c.primary
+}
+
+// Query executes a query that returns rows. It runs inside the transaction carried by ctx, if any.
 func (c *Client) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
 	if c.queryHook != nil {
 		c.queryHook.BeforeQuery(sql, args)
 	}
 
-	rows, err := c.pool.Query(ctx, sql, args...)
+	start := time.Now()
+	rows, err := c.reader(ctx, sql).Query(ctx, sql, args...)
+	c.observeQuery(ctx, OperationQuery, sql, start, 0, err)
 
 	if c.queryHook != nil {
 		c.queryHook.AfterQuery(sql, args, err)
@@ -147,23 +320,29 @@ func (c *Client) Query(ctx context.Context, sql string, args ...any) (pgx.Rows,
 	return rows, nil
 }
 
-// QueryRow executes a query that returns at most one row.
+// QueryRow executes a query that returns at most one row. It runs inside the transaction carried
+// by ctx, if any.
 func (c *Client) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
 	if c.queryHook != nil {
 		c.queryHook.BeforeQuery(sql, args)
 		defer c.queryHook.AfterQuery(sql, args, nil)
 	}
 
-	return c.pool.QueryRow(ctx, sql, args...)
+	start := time.Now()
+	row := c.reader(ctx, sql).QueryRow(ctx, sql, args...)
+	c.observeQuery(ctx, OperationQueryRow, sql, start, 0, nil)
+	return row
 }
 
-// Exec executes a query that doesn't return rows.
+// Exec executes a query that doesn't return rows. It runs inside the transaction carried by ctx, if any.
 func (c *Client) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
 	if c.queryHook != nil {
 		c.queryHook.BeforeQuery(sql, args)
 	}
 
-	tag, err := c.pool.Exec(ctx, sql, args...)
+	start := time.Now()
+	tag, err := c.writer(ctx).Exec(ctx, sql, args...)
+	c.observeQuery(ctx, OperationExec, sql, start, tag.RowsAffected(), err)
 
 	if c.queryHook != nil {
 		c.queryHook.AfterQuery(sql, args, err)
@@ -178,15 +357,24 @@ func (c *Client) Exec(ctx context.Context, sql string, args ...any) (pgconn.Comm
 // Transaction executes a function within a database transaction.
 // If the function returns an error, the transaction is rolled back.
 // Otherwise, the transaction is committed.
+// If ctx already carries a transaction (see WithTx), fn runs in a nested transaction of it, which
+// rolls back on its own on error and otherwise commits together with the outer transaction.
 func (c *Client) Transaction(ctx context.Context, fn func(tx pgx.Tx) error) error {
 	return c.TransactionWithOptions(ctx, pgx.TxOptions{}, fn)
 }
 
 // TransactionWithOptions executes a function within a transaction with custom options.
+// opts are ignored when ctx already carries a transaction, as a nested transaction inherits them.
 func (c *Client) TransactionWithOptions(ctx context.Context, opts pgx.TxOptions, fn func(tx pgx.Tx) error) error {
-	tx, err := c.pool.BeginTx(ctx, opts)
+	var tx pgx.Tx
+	var err error
+	if outer, ok := TxFromContext(ctx); ok {
+		tx, err = outer.Begin(ctx)
+	} else {
+		tx, err = c.pool.BeginTx(ctx, opts)
+	}
 	if err != nil {
-		return fmt.Errorf("%w: begin transaction: %v", ErrQueryFailed, err)
+		return fmt.Errorf("%w: begin transaction: %w", ErrQueryFailed, err)
 	}
 
 	defer func() {
@@ -204,7 +392,7 @@ func (c *Client) TransactionWithOptions(ctx context.Context, opts pgx.TxOptions,
 	}
 
 	if err := tx.Commit(ctx); err != nil {
-		return fmt.Errorf("%w: commit transaction: %v", ErrQueryFailed, err)
+		return fmt.Errorf("%w: commit transaction: %w", ErrQueryFailed, err)
 	}
 
 	return nil
@@ -218,8 +406,11 @@ func (c *Client) Ping(ctx context.Context) error {
 	return nil
 }
 
-// Close closes the connection pool.
+// Close closes the connection pool and any replica pools.
 func (c *Client) Close() {
+	for _, pool := range c.replicaPools {
+		pool.Close()
+	}
 	if c.pool != nil {
 		c.pool.Close()
 		c.logger.Info("postgres client closed")
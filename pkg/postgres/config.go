@@ -6,6 +6,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/jackc/pgx/v5"
 )
 
 // Config holds PostgreSQL connection configuration loaded from the environment.
@@ -24,6 +26,26 @@ type Config struct {
 	MaxConnIdleTime time.Duration `json:"max_conn_idle_time"`
 	ConnectTimeout  time.Duration `json:"connect_timeout"`
 	QueryTimeout    time.Duration `json:"query_timeout"`
+
+	// HealthCheckPeriod is how often idle pool connections are checked; zero uses the 1m default.
+	HealthCheckPeriod time.Duration `json:"health_check_period"`
+	// HealthAcquireTimeout bounds how long Health waits for a pool connection; zero uses 2s.
+	HealthAcquireTimeout time.Duration `json:"health_acquire_timeout"`
+
+	// SlowQueryThreshold logs statements that take at least this long; zero disables the log.
+	SlowQueryThreshold time.Duration `json:"slow_query_threshold"`
+
+	// Logger, when set, receives pgx driver events: connects, pool acquires and releases, and queries.
+	// It is separate from the client logger set with WithLogger.
+	Logger Logger `json:"-"`
+	// LogLevel is the least severe pgx event passed to Logger: "trace", "debug" (adds pool acquires and
+	// releases), "info" (adds connects and every query), "warn", "error", or "none". Empty uses "warn".
+	LogLevel string `json:"log_level"`
+
+	// QueryExecMode selects how pgx sends statements: "cache_statement" (prepared and cached per
+	// connection), "cache_describe", "describe_exec", "exec", or "simple_protocol". Behind a pooler in
+	// transaction mode, such as PgBouncer, use "exec" or "simple_protocol". Empty uses "cache_statement".
+	QueryExecMode string `json:"query_exec_mode"`
 }
 
 // LoadConfig reads configuration from environment variables and validates it.
@@ -50,6 +72,11 @@ func defaultConfig() *Config {
 		MaxConnIdleTime: 30 * time.Minute,
 		ConnectTimeout:  10 * time.Second,
 		QueryTimeout:    30 * time.Second,
+
+		HealthCheckPeriod:    time.Minute,
+		HealthAcquireTimeout: 2 * time.Second,
+		SlowQueryThreshold:   time.Second,
+		LogLevel:             DefaultLogLevel,
 	}
 }
 
@@ -72,6 +99,12 @@ func (c *Config) overrideFromEnv() error {
 	if v := strings.TrimSpace(os.Getenv("POSTGRES_SSL_MODE")); v != "" {
 		c.SSLMode = v
 	}
+	if v := strings.TrimSpace(os.Getenv("POSTGRES_LOG_LEVEL")); v != "" {
+		c.LogLevel = v
+	}
+	if v := strings.TrimSpace(os.Getenv("POSTGRES_QUERY_EXEC_MODE")); v != "" {
+		c.QueryExecMode = v
+	}
 
 	if port, err := parseIntEnv("POSTGRES_PORT"); err != nil {
 		return err
@@ -103,6 +136,18 @@ func (c *Config) overrideFromEnv() error {
 		c.MaxConnIdleTime = *d
 	}
 
+	if d, err := parseDurationEnv("POSTGRES_HEALTH_CHECK_PERIOD"); err != nil {
+		return err
+	} else if d != nil {
+		c.HealthCheckPeriod = *d
+	}
+
+	if d, err := parseDurationEnv("POSTGRES_HEALTH_ACQUIRE_TIMEOUT"); err != nil {
+		return err
+	} else if d != nil {
+		c.HealthAcquireTimeout = *d
+	}
+
 	if d, err := parseDurationEnv("POSTGRES_CONNECT_TIMEOUT"); err != nil {
 		return err
 	} else if d != nil {
@@ -115,6 +160,12 @@ func (c *Config) overrideFromEnv() error {
 		c.QueryTimeout = *d
 	}
 
+	if d, err := parseDurationEnv("POSTGRES_SLOW_QUERY_THRESHOLD"); err != nil {
+		return err
+	} else if d != nil {
+		c.SlowQueryThreshold = *d
+	}
+
 	return nil
 }
 
@@ -141,12 +192,36 @@ func (c *Config) Validate() error {
 	if c.MinConns > c.MaxConns {
 		return fmt.Errorf("%w: POSTGRES_MIN_CONNS cannot exceed POSTGRES_MAX_CONNS", ErrInvalidConfig)
 	}
+	if c.MaxConnLifetime < 0 {
+		return fmt.Errorf("%w: POSTGRES_MAX_CONN_LIFETIME cannot be negative", ErrInvalidConfig)
+	}
+	if c.MaxConnIdleTime < 0 {
+		return fmt.Errorf("%w: POSTGRES_MAX_CONN_IDLE_TIME cannot be negative", ErrInvalidConfig)
+	}
+	if c.MaxConnLifetime > 0 && c.MaxConnIdleTime > c.MaxConnLifetime {
+		return fmt.Errorf("%w: POSTGRES_MAX_CONN_IDLE_TIME cannot exceed POSTGRES_MAX_CONN_LIFETIME", ErrInvalidConfig)
+	}
+	if c.HealthCheckPeriod < 0 {
+		return fmt.Errorf("%w: POSTGRES_HEALTH_CHECK_PERIOD cannot be negative", ErrInvalidConfig)
+	}
+	if c.HealthAcquireTimeout < 0 {
+		return fmt.Errorf("%w: POSTGRES_HEALTH_ACQUIRE_TIMEOUT cannot be negative", ErrInvalidConfig)
+	}
 	if c.ConnectTimeout < time.Second {
 		return fmt.Errorf("%w: POSTGRES_CONNECT_TIMEOUT must be at least 1s", ErrInvalidConfig)
 	}
 	if c.QueryTimeout < time.Second {
 		return fmt.Errorf("%w: POSTGRES_QUERY_TIMEOUT must be at least 1s", ErrInvalidConfig)
 	}
+	if c.SlowQueryThreshold < 0 {
+		return fmt.Errorf("%w: POSTGRES_SLOW_QUERY_THRESHOLD cannot be negative", ErrInvalidConfig)
+	}
+	if _, err := parseLogLevel(c.LogLevel); err != nil {
+		return fmt.Errorf("%w: invalid POSTGRES_LOG_LEVEL: %s", ErrInvalidConfig, c.LogLevel)
+	}
+	if _, err := parseQueryExecMode(c.QueryExecMode); err != nil {
+		return fmt.Errorf("%w: invalid POSTGRES_QUERY_EXEC_MODE: %s", ErrInvalidConfig, c.QueryExecMode)
+	}
 
 	validSSLModes := map[string]bool{
 		"disable": true, "allow": true, "prefer": true,
@@ -183,6 +258,28 @@ func (c *Config) ConnectionURL() string {
 	return url
 }
 
+// queryExecModes maps Config.QueryExecMode values to pgx modes, using pgx's own names.
+var queryExecModes = map[string]pgx.QueryExecMode{
+	"cache_statement": pgx.QueryExecModeCacheStatement,
+	"cache_describe":  pgx.QueryExecModeCacheDescribe,
+	"describe_exec":   pgx.QueryExecModeDescribeExec,
+	"exec":            pgx.QueryExecModeExec,
+	"simple_protocol": pgx.QueryExecModeSimpleProtocol,
+}
+
+// parseQueryExecMode parses a pgx query exec mode name, treating an empty name as "cache_statement".
+func parseQueryExecMode(name string) (pgx.QueryExecMode, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return pgx.QueryExecModeCacheStatement, nil
+	}
+	mode, ok := queryExecModes[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown query exec mode %q", name)
+	}
+	return mode, nil
+}
+
 func parseIntEnv(key string) (*int, error) {
 	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
 		parsed, err := strconv.Atoi(v)
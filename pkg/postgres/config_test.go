@@ -1,9 +1,12 @@
 package postgres
 
 import (
+	"errors"
 	"os"
 	"testing"
 	"time"
+
+	"github.com/jackc/pgx/v5"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -140,6 +143,75 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "negative max conn lifetime",
+			config: Config{
+				Host:            "localhost",
+				Port:            5432,
+				User:            "testuser",
+				Password:        "testpass",
+				Database:        "testdb",
+				SSLMode:         "prefer",
+				MaxConns:        25,
+				MinConns:        5,
+				MaxConnLifetime: -time.Minute,
+				ConnectTimeout:  10 * time.Second,
+				QueryTimeout:    30 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "idle time exceeds lifetime",
+			config: Config{
+				Host:            "localhost",
+				Port:            5432,
+				User:            "testuser",
+				Password:        "testpass",
+				Database:        "testdb",
+				SSLMode:         "prefer",
+				MaxConns:        25,
+				MinConns:        5,
+				MaxConnLifetime: time.Minute,
+				MaxConnIdleTime: time.Hour,
+				ConnectTimeout:  10 * time.Second,
+				QueryTimeout:    30 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative health check period",
+			config: Config{
+				Host:              "localhost",
+				Port:              5432,
+				User:              "testuser",
+				Password:          "testpass",
+				Database:          "testdb",
+				SSLMode:           "prefer",
+				MaxConns:          25,
+				MinConns:          5,
+				HealthCheckPeriod: -time.Second,
+				ConnectTimeout:    10 * time.Second,
+				QueryTimeout:      30 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative slow query threshold",
+			config: Config{
+				Host:               "localhost",
+				Port:               5432,
+				User:               "testuser",
+				Password:           "testpass",
+				Database:           "testdb",
+				SSLMode:            "prefer",
+				MaxConns:           25,
+				MinConns:           5,
+				ConnectTimeout:     10 * time.Second,
+				QueryTimeout:       30 * time.Second,
+				SlowQueryThreshold: -time.Second,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -152,6 +224,40 @@ func TestConfig_Validate(t *testing.T) {
 	}
 }
 
+func TestConfig_ValidateLogLevel(t *testing.T) {
+	cfg := *defaultConfig()
+	cfg.User, cfg.Password, cfg.Database = "testuser", "testpass", "testdb"
+
+	for _, level := range []string{"", "trace", "debug", "info", "warn", "error", "none", "INFO"} {
+		cfg.LogLevel = level
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Validate() with log level %q error = %v", level, err)
+		}
+	}
+
+	cfg.LogLevel = "verbose"
+	if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Validate() with log level %q error = %v, want ErrInvalidConfig", cfg.LogLevel, err)
+	}
+}
+
+func TestConfig_ValidateQueryExecMode(t *testing.T) {
+	cfg := *defaultConfig()
+	cfg.User, cfg.Password, cfg.Database = "testuser", "testpass", "testdb"
+
+	for _, mode := range []string{"", "cache_statement", "cache_describe", "describe_exec", "exec", "simple_protocol", "EXEC"} {
+		cfg.QueryExecMode = mode
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Validate() with query exec mode %q error = %v", mode, err)
+		}
+	}
+
+	cfg.QueryExecMode = "prepared"
+	if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Validate() with query exec mode %q error = %v, want ErrInvalidConfig", cfg.QueryExecMode, err)
+	}
+}
+
 func TestConfig_ConnectionString(t *testing.T) {
 	cfg := Config{
 		Host:     "localhost",
@@ -211,6 +317,7 @@ func TestLoadConfig_FromEnv(t *testing.T) {
 	os.Setenv("POSTGRES_DATABASE", "envdb")
 	os.Setenv("POSTGRES_SCHEMA", "envschema")
 	os.Setenv("POSTGRES_SSL_MODE", "require")
+	os.Setenv("POSTGRES_QUERY_EXEC_MODE", "exec")
 	defer func() {
 		os.Unsetenv("POSTGRES_HOST")
 		os.Unsetenv("POSTGRES_PORT")
@@ -219,6 +326,7 @@ func TestLoadConfig_FromEnv(t *testing.T) {
 		os.Unsetenv("POSTGRES_DATABASE")
 		os.Unsetenv("POSTGRES_SCHEMA")
 		os.Unsetenv("POSTGRES_SSL_MODE")
+		os.Unsetenv("POSTGRES_QUERY_EXEC_MODE")
 	}()
 
 	cfg, err := LoadConfig()
@@ -244,4 +352,66 @@ func TestLoadConfig_FromEnv(t *testing.T) {
 	if cfg.SSLMode != "require" {
 		t.Errorf("expected sslmode require, got %s", cfg.SSLMode)
 	}
+	if cfg.QueryExecMode != "exec" {
+		t.Errorf("expected query exec mode exec, got %s", cfg.QueryExecMode)
+	}
+}
+
+func TestNewPoolConfig(t *testing.T) {
+	cfg := *defaultConfig()
+	cfg.User = "testuser"
+	cfg.Password = "testpass"
+	cfg.Database = "testdb"
+	cfg.MaxConns = 40
+	cfg.MinConns = 8
+	cfg.MaxConnLifetime = 2 * time.Hour
+	cfg.MaxConnIdleTime = 10 * time.Minute
+	cfg.HealthCheckPeriod = 15 * time.Second
+	cfg.ConnectTimeout = 3 * time.Second
+
+	poolConfig, err := newPoolConfig(cfg)
+	if err != nil {
+		t.Fatalf("newPoolConfig() error = %v", err)
+	}
+	if poolConfig.MaxConns != 40 || poolConfig.MinConns != 8 {
+		t.Errorf("conns = %d/%d, want 8/40", poolConfig.MinConns, poolConfig.MaxConns)
+	}
+	if poolConfig.MaxConnLifetime != 2*time.Hour {
+		t.Errorf("MaxConnLifetime = %v", poolConfig.MaxConnLifetime)
+	}
+	if poolConfig.MaxConnIdleTime != 10*time.Minute {
+		t.Errorf("MaxConnIdleTime = %v", poolConfig.MaxConnIdleTime)
+	}
+	if poolConfig.HealthCheckPeriod != 15*time.Second {
+		t.Errorf("HealthCheckPeriod = %v", poolConfig.HealthCheckPeriod)
+	}
+	if poolConfig.ConnConfig.ConnectTimeout != 3*time.Second {
+		t.Errorf("ConnectTimeout = %v", poolConfig.ConnConfig.ConnectTimeout)
+	}
+
+	cfg.HealthCheckPeriod = 0
+	poolConfig, err = newPoolConfig(cfg)
+	if err != nil {
+		t.Fatalf("newPoolConfig() error = %v", err)
+	}
+	if poolConfig.HealthCheckPeriod != time.Minute {
+		t.Errorf("expected default HealthCheckPeriod of 1m, got %v", poolConfig.HealthCheckPeriod)
+	}
+	if poolConfig.ConnConfig.DefaultQueryExecMode != pgx.QueryExecModeCacheStatement {
+		t.Errorf("expected default DefaultQueryExecMode of cache_statement, got %v", poolConfig.ConnConfig.DefaultQueryExecMode)
+	}
+
+	cfg.QueryExecMode = "simple_protocol"
+	poolConfig, err = newPoolConfig(cfg)
+	if err != nil {
+		t.Fatalf("newPoolConfig() error = %v", err)
+	}
+	if poolConfig.ConnConfig.DefaultQueryExecMode != pgx.QueryExecModeSimpleProtocol {
+		t.Errorf("DefaultQueryExecMode = %v, want simple_protocol", poolConfig.ConnConfig.DefaultQueryExecMode)
+	}
+
+	cfg.QueryExecMode = "prepared"
+	if _, err := newPoolConfig(cfg); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("newPoolConfig() with query exec mode %q error = %v, want ErrInvalidConfig", cfg.QueryExecMode, err)
+	}
 }
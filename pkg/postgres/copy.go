@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// copier is implemented by *pgxpool.Pool, *pgx.Conn, and pgx.Tx.
+type copier interface {
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+// CopyFrom bulk-loads rows into table using the PostgreSQL COPY protocol and returns the number of
+// rows copied. table may be schema-qualified ("audit.events"). Each row must have one value per column.
+// It runs inside the transaction carried by ctx, if any.
+func (c *Client) CopyFrom(ctx context.Context, table string, columns []string, rows [][]any) (int64, error) {
+	if tx, ok := TxFromContext(ctx); ok {
+		return copyFrom(ctx, tx, table, columns, rows)
+	}
+	return copyFrom(ctx, c.pool, table, columns, rows)
+}
+
+// CopyFromTx is like CopyFrom but runs inside tx, so the load commits or rolls back with it.
+func (c *Client) CopyFromTx(ctx context.Context, tx pgx.Tx, table string, columns []string, rows [][]any) (int64, error) {
+	return copyFrom(ctx, tx, table, columns, rows)
+}
+
+func copyFrom(ctx context.Context, dst copier, table string, columns []string, rows [][]any) (int64, error) {
+	if strings.TrimSpace(table) == "" {
+		return 0, fmt.Errorf("%w: copy: table name is required", ErrQueryFailed)
+	}
+	if len(columns) == 0 {
+		return 0, fmt.Errorf("%w: copy into %s: at least one column is required", ErrQueryFailed, table)
+	}
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			return 0, fmt.Errorf("%w: copy into %s: row %d has %d values, expected %d",
+				ErrQueryFailed, table, i, len(row), len(columns))
+		}
+	}
+
+	n, err := dst.CopyFrom(ctx, pgx.Identifier(strings.Split(table, ".")), columns, pgx.CopyFromRows(rows))
+	if err != nil {
+		return n, fmt.Errorf("%w: copy into %s (%s): %w", ErrQueryFailed, table, strings.Join(columns, ", "), err)
+	}
+	return n, nil
+}
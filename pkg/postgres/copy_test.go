@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// fakeCopier consumes the copy source and rejects values whose type differs from kinds.
+type fakeCopier struct {
+	table   pgx.Identifier
+	columns []string
+	kinds   []reflect.Kind
+	copied  [][]any
+}
+
+func (f *fakeCopier) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	f.table = tableName
+	f.columns = columnNames
+	for rowSrc.Next() {
+		values, err := rowSrc.Values()
+		if err != nil {
+			return 0, err
+		}
+		for i, v := range values {
+			if reflect.ValueOf(v).Kind() != f.kinds[i] {
+				return 0, fmt.Errorf("unable to encode %#v into column %s", v, columnNames[i])
+			}
+		}
+		f.copied = append(f.copied, values)
+	}
+	return int64(len(f.copied)), rowSrc.Err()
+}
+
+func TestCopyFrom(t *testing.T) {
+	dst := &fakeCopier{kinds: []reflect.Kind{reflect.Int, reflect.String}}
+	rows := make([][]any, 5000)
+	for i := range rows {
+		rows[i] = []any{i, fmt.Sprintf("user-%d", i)}
+	}
+
+	n, err := copyFrom(context.Background(), dst, "app.users", []string{"id", "name"}, rows)
+	if err != nil {
+		t.Fatalf("copyFrom() error = %v", err)
+	}
+	if n != int64(len(rows)) {
+		t.Errorf("copyFrom() = %d, want %d", n, len(rows))
+	}
+	if !reflect.DeepEqual(dst.table, pgx.Identifier{"app", "users"}) {
+		t.Errorf("table = %v, want schema-qualified identifier", dst.table)
+	}
+	if len(dst.copied) != len(rows) {
+		t.Errorf("copied %d rows, want %d", len(dst.copied), len(rows))
+	}
+}
+
+func TestCopyFrom_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []string
+		rows    [][]any
+		wantMsg string
+	}{
+		{
+			name:    "type mismatch",
+			columns: []string{"id", "name"},
+			rows:    [][]any{{1, "a"}, {"two", "b"}},
+			wantMsg: `copy into users (id, name): unable to encode "two" into column id`,
+		},
+		{
+			name:    "row length mismatch",
+			columns: []string{"id", "name"},
+			rows:    [][]any{{1, "a"}, {2}},
+			wantMsg: "copy into users: row 1 has 1 values, expected 2",
+		},
+		{
+			name:    "no columns",
+			rows:    [][]any{{1}},
+			wantMsg: "copy into users: at least one column is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := &fakeCopier{kinds: []reflect.Kind{reflect.Int, reflect.String}}
+			_, err := copyFrom(context.Background(), dst, "users", tt.columns, tt.rows)
+			if !errors.Is(err, ErrQueryFailed) {
+				t.Fatalf("copyFrom() error = %v, want ErrQueryFailed", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("copyFrom() error = %q, want it to contain %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
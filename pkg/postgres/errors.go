@@ -18,15 +18,20 @@ var (
 	ErrTimeout             = errors.New("postgres: timeout")
 	ErrPoolExhausted       = errors.New("postgres: connection pool exhausted")
 	ErrTxAlreadyClosed     = errors.New("postgres: transaction already closed")
+	ErrMissingParameter    = errors.New("postgres: missing named parameter")
+	ErrMigrationFailed     = errors.New("postgres: migration failed")
+	ErrSavepointNotFound   = errors.New("postgres: savepoint not found")
 )
 
 // PostgreSQL error codes
 // See: https://www.postgresql.org/docs/current/errcodes-appendix.html
 const (
-	uniqueViolationCode     = "23505"
-	foreignKeyViolationCode = "23503"
-	checkViolationCode      = "23514"
-	notNullViolationCode    = "23502"
+	uniqueViolationCode      = "23505"
+	foreignKeyViolationCode  = "23503"
+	checkViolationCode       = "23514"
+	notNullViolationCode     = "23502"
+	serializationFailureCode = "40001"
+	deadlockDetectedCode     = "40P01"
 )
 
 // IsUniqueViolation checks if the error is a unique constraint violation.
@@ -57,6 +62,21 @@ func IsConstraintViolation(err error) bool {
 		IsNotNullViolation(err)
 }
 
+// IsSerializationFailure checks if the error is a serialization failure under REPEATABLE READ or SERIALIZABLE.
+func IsSerializationFailure(err error) bool {
+	return hasErrorCode(err, serializationFailureCode)
+}
+
+// IsDeadlock checks if the error is a detected deadlock.
+func IsDeadlock(err error) bool {
+	return hasErrorCode(err, deadlockDetectedCode)
+}
+
+// IsRetryableTxError checks if the transaction that produced err can be safely retried from the start.
+func IsRetryableTxError(err error) bool {
+	return IsSerializationFailure(err) || IsDeadlock(err)
+}
+
 // hasErrorCode checks if the error contains a specific PostgreSQL error code.
 func hasErrorCode(err error, code string) bool {
 	if err == nil {
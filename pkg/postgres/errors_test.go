@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/jackc/pgx/v5/pgconn"
@@ -118,6 +119,48 @@ func TestIsConstraintViolation(t *testing.T) {
 	}
 }
 
+func TestIsRetryableTxError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "serialization failure",
+			err:  &pgconn.PgError{Code: "40001"},
+			want: true,
+		},
+		{
+			name: "deadlock",
+			err:  &pgconn.PgError{Code: "40P01"},
+			want: true,
+		},
+		{
+			name: "wrapped serialization failure",
+			err:  fmt.Errorf("%w: commit transaction: %w", ErrQueryFailed, &pgconn.PgError{Code: "40001"}),
+			want: true,
+		},
+		{
+			name: "unique violation",
+			err:  &pgconn.PgError{Code: "23505"},
+			want: false,
+		},
+		{
+			name: "nil error",
+			err:  nil,
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryableTxError(tt.err); got != tt.want {
+				t.Errorf("IsRetryableTxError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestErrorCode(t *testing.T) {
 	tests := []struct {
 		name string
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"github.com/rompi/core-backend/pkg/postgres"
 )
@@ -20,6 +21,15 @@ func main() {
 		Database: os.Getenv("MYAPP_DB_NAME"),
 		Schema:   getEnv("MYAPP_DB_SCHEMA", "public"),
 		SSLMode:  getEnv("MYAPP_DB_SSL_MODE", "prefer"),
+
+		// Pool tuning
+		MaxConns:          25,
+		MinConns:          5,
+		MaxConnLifetime:   time.Hour,
+		MaxConnIdleTime:   30 * time.Minute,
+		HealthCheckPeriod: time.Minute,
+		ConnectTimeout:    10 * time.Second,
+		QueryTimeout:      30 * time.Second,
 	}
 
 	if err := cfg.Validate(); err != nil {
@@ -2,41 +2,124 @@ package postgres
 
 import (
 	"context"
+	"fmt"
 	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// DefaultHealthAcquireTimeout bounds how long Health waits for a pool connection when
+// Config.HealthAcquireTimeout is zero.
+const DefaultHealthAcquireTimeout = 2 * time.Second
+
+// DegradedSaturationPercent is the pool saturation at which Health reports the pool as degraded.
+const DegradedSaturationPercent = 90.0
+
 // HealthStatus represents the health status of the PostgreSQL connection.
 type HealthStatus struct {
-	Healthy     bool          `json:"healthy"`
-	Message     string        `json:"message"`
+	Healthy bool `json:"healthy"`
+	// Degraded is true when the database is reachable but the pool is close to exhaustion.
+	Degraded bool   `json:"degraded"`
+	Message  string `json:"message"`
+	// Latency is the round-trip time of the ping.
 	Latency     time.Duration `json:"latency"`
 	ActiveConns int32         `json:"active_conns"`
 	IdleConns   int32         `json:"idle_conns"`
 	TotalConns  int32         `json:"total_conns"`
+	MaxConns    int32         `json:"max_conns"`
+	// PoolSaturation is the share of MaxConns currently acquired, in percent.
+	PoolSaturation float64      `json:"pool_saturation"`
+	Checks         HealthChecks `json:"checks"`
+
+	// Replicas reports each read replica separately; it does not affect Healthy, which reflects the primary.
+	Replicas []HealthStatus `json:"replicas,omitempty"`
 }
 
-// Health checks the health of the PostgreSQL connection.
+// HealthChecks breaks a health result down into its individual checks.
+type HealthChecks struct {
+	CanAcquireConn bool          `json:"can_acquire_conn"`
+	AcquireLatency time.Duration `json:"acquire_latency"`
+	CanPing        bool          `json:"can_ping"`
+}
+
+// Health checks the health of the PostgreSQL connection and of each replica.
 func (c *Client) Health(ctx context.Context) HealthStatus {
-	start := time.Now()
+	timeout := DefaultHealthAcquireTimeout
+	if c.config != nil && c.config.HealthAcquireTimeout > 0 {
+		timeout = c.config.HealthAcquireTimeout
+	}
+
+	status := checkHealth(ctx, newPoolProbe(c.pool), timeout)
+	for _, replica := range c.replicaPools {
+		status.Replicas = append(status.Replicas, checkHealth(ctx, newPoolProbe(replica), timeout))
+	}
+	return status
+}
+
+// healthProbe exposes the pool operations Health needs.
+type healthProbe struct {
+	stats   func() (acquired, idle, total, max int32)
+	acquire func(ctx context.Context) (ping func(ctx context.Context) error, release func(), err error)
+}
+
+func newPoolProbe(pool *pgxpool.Pool) healthProbe {
+	return healthProbe{
+		stats: func() (int32, int32, int32, int32) {
+			stat := pool.Stat()
+			return stat.AcquiredConns(), stat.IdleConns(), stat.TotalConns(), stat.MaxConns()
+		},
+		acquire: func(ctx context.Context) (func(ctx context.Context) error, func(), error) {
+			conn, err := pool.Acquire(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			return conn.Ping, conn.Release, nil
+		},
+	}
+}
 
+// checkHealth acquires a connection within timeout, pings it, and reports pool saturation.
+func checkHealth(ctx context.Context, probe healthProbe, timeout time.Duration) HealthStatus {
 	status := HealthStatus{
 		Healthy: true,
 		Message: "OK",
 	}
 
 	// Get pool stats
-	stats := c.pool.Stat()
-	status.ActiveConns = stats.AcquiredConns()
-	status.IdleConns = stats.IdleConns()
-	status.TotalConns = stats.TotalConns()
+	status.ActiveConns, status.IdleConns, status.TotalConns, status.MaxConns = probe.stats()
+	if status.MaxConns > 0 {
+		status.PoolSaturation = float64(status.ActiveConns) / float64(status.MaxConns) * 100
+	}
+
+	acquireCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	ping, release, err := probe.acquire(acquireCtx)
+	status.Checks.AcquireLatency = time.Since(start)
+	if err != nil {
+		status.Healthy = false
+		status.Message = fmt.Sprintf("acquire connection: %v", err)
+		return status
+	}
+	defer release()
+	status.Checks.CanAcquireConn = true
 
 	// Ping the database
-	if err := c.pool.Ping(ctx); err != nil {
+	start = time.Now()
+	err = ping(ctx)
+	status.Latency = time.Since(start)
+	if err != nil {
 		status.Healthy = false
 		status.Message = err.Error()
+		return status
 	}
+	status.Checks.CanPing = true
 
-	status.Latency = time.Since(start)
+	if status.PoolSaturation >= DegradedSaturationPercent {
+		status.Degraded = true
+		status.Message = fmt.Sprintf("connection pool %.0f%% saturated", status.PoolSaturation)
+	}
 
 	return status
 }
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func fakeProbe(acquired, max int32, acquireErr, pingErr error) (healthProbe, *bool) {
+	released := false
+	return healthProbe{
+		stats: func() (int32, int32, int32, int32) {
+			return acquired, max - acquired, max, max
+		},
+		acquire: func(ctx context.Context) (func(ctx context.Context) error, func(), error) {
+			if acquireErr != nil {
+				return nil, nil, acquireErr
+			}
+			ping := func(ctx context.Context) error {
+				time.Sleep(time.Millisecond)
+				return pingErr
+			}
+			return ping, func() { released = true }, nil
+		},
+	}, &released
+}
+
+func TestCheckHealth_Healthy(t *testing.T) {
+	probe, released := fakeProbe(2, 10, nil, nil)
+	status := checkHealth(context.Background(), probe, time.Second)
+
+	if !status.Healthy || status.Degraded {
+		t.Fatalf("expected healthy, got %+v", status)
+	}
+	if status.Latency <= 0 {
+		t.Errorf("expected ping latency to be populated, got %v", status.Latency)
+	}
+	if !status.Checks.CanAcquireConn || !status.Checks.CanPing {
+		t.Errorf("expected all checks to pass, got %+v", status.Checks)
+	}
+	if status.PoolSaturation != 20 {
+		t.Errorf("expected 20%% saturation, got %v", status.PoolSaturation)
+	}
+	if !*released {
+		t.Error("expected connection to be released")
+	}
+}
+
+func TestCheckHealth_SaturatedPoolIsDegraded(t *testing.T) {
+	probe, _ := fakeProbe(19, 20, nil, nil)
+	status := checkHealth(context.Background(), probe, time.Second)
+
+	if !status.Healthy {
+		t.Fatalf("expected reachable database to stay healthy, got %+v", status)
+	}
+	if !status.Degraded {
+		t.Errorf("expected degraded at %v%% saturation", status.PoolSaturation)
+	}
+}
+
+func TestCheckHealth_Failures(t *testing.T) {
+	t.Run("acquire timeout", func(t *testing.T) {
+		probe := healthProbe{
+			stats: func() (int32, int32, int32, int32) { return 10, 0, 10, 10 },
+			acquire: func(ctx context.Context) (func(ctx context.Context) error, func(), error) {
+				<-ctx.Done()
+				return nil, nil, ctx.Err()
+			},
+		}
+		status := checkHealth(context.Background(), probe, 10*time.Millisecond)
+		if status.Healthy || status.Checks.CanAcquireConn || status.Checks.CanPing {
+			t.Errorf("expected acquire failure, got %+v", status)
+		}
+	})
+
+	t.Run("ping failure", func(t *testing.T) {
+		probe, released := fakeProbe(1, 10, nil, errors.New("connection reset"))
+		status := checkHealth(context.Background(), probe, time.Second)
+		if status.Healthy || !status.Checks.CanAcquireConn || status.Checks.CanPing {
+			t.Errorf("expected ping failure, got %+v", status)
+		}
+		if status.Message != "connection reset" {
+			t.Errorf("unexpected message %q", status.Message)
+		}
+		if !*released {
+			t.Error("expected connection to be released")
+		}
+	})
+}
@@ -0,0 +1,292 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// migrationsTable records the applied migration versions.
+const migrationsTable = "schema_migrations"
+
+// migrationLockID is the advisory lock key serializing migrators across replicas.
+const migrationLockID = 7240913380151558917
+
+// Migration is a versioned schema change loaded from a pair of NNN_name.up.sql and NNN_name.down.sql files.
+type Migration struct {
+	Version int64
+	Name    string
+	UpSQL   string
+	// DownSQL is empty when the migration has no .down.sql file; such migrations cannot be rolled back.
+	DownSQL string
+}
+
+// LoadMigrations reads the NNN_name.up.sql and NNN_name.down.sql files in dir of fsys, sorted by version.
+// Other files are ignored.
+func LoadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("%w: read migrations: %v", ErrMigrationFailed, err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		version, name, up, ok := parseMigrationFilename(entry.Name())
+		if !ok {
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("%w: read %s: %v", ErrMigrationFailed, entry.Name(), err)
+		}
+
+		m, exists := byVersion[version]
+		if !exists {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		} else if m.Name != name {
+			return nil, fmt.Errorf("%w: version %d used by %q and %q", ErrMigrationFailed, version, m.Name, name)
+		}
+		if up {
+			m.UpSQL = string(data)
+		} else {
+			m.DownSQL = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.UpSQL == "" {
+			return nil, fmt.Errorf("%w: migration %d_%s has no .up.sql file", ErrMigrationFailed, m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// parseMigrationFilename splits "001_create_users.up.sql" into its version, name, and direction.
+func parseMigrationFilename(filename string) (version int64, name string, up bool, ok bool) {
+	var base string
+	switch {
+	case strings.HasSuffix(filename, ".up.sql"):
+		base, up = strings.TrimSuffix(filename, ".up.sql"), true
+	case strings.HasSuffix(filename, ".down.sql"):
+		base = strings.TrimSuffix(filename, ".down.sql")
+	default:
+		return 0, "", false, false
+	}
+
+	prefix, name, found := strings.Cut(base, "_")
+	if !found || name == "" {
+		return 0, "", false, false
+	}
+	version, err := strconv.ParseInt(prefix, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, "", false, false
+	}
+	return version, name, up, true
+}
+
+// migrationStore is the database side of a Migrator.
+type migrationStore interface {
+	// ensureTable creates the migrations table if needed.
+	ensureTable(ctx context.Context) error
+	// appliedVersions returns the recorded versions.
+	appliedVersions(ctx context.Context) (map[int64]bool, error)
+	// run executes sql and records (up) or removes (down) the version in one transaction.
+	// It reports false without running sql when another migrator already did so.
+	run(ctx context.Context, m Migration, sql string, up bool) (bool, error)
+}
+
+// Migrator applies and rolls back migrations from an fs.FS, such as an embed.FS.
+type Migrator struct {
+	store  migrationStore
+	fsys   fs.FS
+	dir    string
+	logger Logger
+}
+
+// NewMigrator returns a Migrator reading migrations from dir in fsys and tracking them in schema_migrations.
+func NewMigrator(client *Client, fsys fs.FS, dir string) *Migrator {
+	return &Migrator{
+		store:  &clientMigrationStore{client: client},
+		fsys:   fsys,
+		dir:    dir,
+		logger: client.logger,
+	}
+}
+
+// Migrate applies all pending migrations from dir in fsys. See Migrator.Up.
+func Migrate(ctx context.Context, client *Client, fsys fs.FS, dir string) error {
+	return NewMigrator(client, fsys, dir).Up(ctx)
+}
+
+// Up applies pending migrations in version order, each in its own transaction, and stops at the first failure.
+// Versions that are already applied are skipped, so calling Up repeatedly is safe.
+func (m *Migrator) Up(ctx context.Context) error {
+	migrations, err := LoadMigrations(m.fsys, m.dir)
+	if err != nil {
+		return err
+	}
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range migrations {
+		if applied[migration.Version] {
+			continue
+		}
+		if err := m.run(ctx, migration, migration.UpSQL, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rollback reverts the last steps applied migrations, newest first, using their .down.sql files.
+func (m *Migrator) Rollback(ctx context.Context, steps int) error {
+	if steps <= 0 {
+		return nil
+	}
+	migrations, err := LoadMigrations(m.fsys, m.dir)
+	if err != nil {
+		return err
+	}
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+
+	byVersion := make(map[int64]Migration, len(migrations))
+	for _, migration := range migrations {
+		byVersion[migration.Version] = migration
+	}
+	versions := make([]int64, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+
+	for i := 0; i < steps && i < len(versions); i++ {
+		migration, ok := byVersion[versions[i]]
+		if !ok {
+			return fmt.Errorf("%w: applied version %d has no migration files", ErrMigrationFailed, versions[i])
+		}
+		if migration.DownSQL == "" {
+			return fmt.Errorf("%w: migration %d_%s has no .down.sql file", ErrMigrationFailed, migration.Version, migration.Name)
+		}
+		if err := m.run(ctx, migration, migration.DownSQL, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int64]bool, error) {
+	if err := m.store.ensureTable(ctx); err != nil {
+		return nil, fmt.Errorf("%w: create %s: %w", ErrMigrationFailed, migrationsTable, err)
+	}
+	applied, err := m.store.appliedVersions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: read %s: %w", ErrMigrationFailed, migrationsTable, err)
+	}
+	return applied, nil
+}
+
+func (m *Migrator) run(ctx context.Context, migration Migration, sql string, up bool) error {
+	direction := "up"
+	if !up {
+		direction = "down"
+	}
+	ran, err := m.store.run(ctx, migration, sql, up)
+	if err != nil {
+		return fmt.Errorf("%w: %d_%s (%s): %w", ErrMigrationFailed, migration.Version, migration.Name, direction, err)
+	}
+	if ran {
+		m.logger.Info("migration applied",
+			"version", migration.Version,
+			"name", migration.Name,
+			"direction", direction,
+		)
+	}
+	return nil
+}
+
+// clientMigrationStore tracks migrations in the primary database of a Client.
+type clientMigrationStore struct {
+	client *Client
+}
+
+func (s *clientMigrationStore) ensureTable(ctx context.Context) error {
+	_, err := s.client.Exec(ctx, `CREATE TABLE IF NOT EXISTS `+migrationsTable+` (
+	version BIGINT PRIMARY KEY,
+	name TEXT NOT NULL,
+	applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+)`)
+	return err
+}
+
+func (s *clientMigrationStore) appliedVersions(ctx context.Context) (map[int64]bool, error) {
+	// Read from the primary; a lagging replica could report a migration as pending
+	rows, err := s.client.primary.Query(ctx, `SELECT version FROM `+migrationsTable)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return nil, err
+	}
+	applied := make(map[int64]bool, len(versions))
+	for _, version := range versions {
+		applied[version] = true
+	}
+	return applied, nil
+}
+
+func (s *clientMigrationStore) run(ctx context.Context, m Migration, sql string, up bool) (bool, error) {
+	ran := false
+	err := s.client.Transaction(ctx, func(tx pgx.Tx) error {
+		// Serialize concurrent migrators, then re-check under the lock
+		if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, int64(migrationLockID)); err != nil {
+			return err
+		}
+		var exists bool
+		if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM `+migrationsTable+` WHERE version = $1)`, m.Version).Scan(&exists); err != nil {
+			return err
+		}
+		if exists == up {
+			return nil
+		}
+
+		// No arguments, so pgx uses the simple protocol and files may hold several statements
+		if _, err := tx.Exec(ctx, sql); err != nil {
+			return err
+		}
+		var err error
+		if up {
+			_, err = tx.Exec(ctx, `INSERT INTO `+migrationsTable+` (version, name) VALUES ($1, $2)`, m.Version, m.Name)
+		} else {
+			_, err = tx.Exec(ctx, `DELETE FROM `+migrationsTable+` WHERE version = $1`, m.Version)
+		}
+		if err != nil {
+			return err
+		}
+		ran = true
+		return nil
+	})
+	return ran, err
+}
@@ -0,0 +1,227 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+// memoryMigrationStore is an in-memory migrationStore that records executed SQL.
+type memoryMigrationStore struct {
+	applied  map[int64]bool
+	executed []string
+	failOn   string
+}
+
+func newMemoryMigrationStore() *memoryMigrationStore {
+	return &memoryMigrationStore{applied: make(map[int64]bool)}
+}
+
+func (s *memoryMigrationStore) ensureTable(ctx context.Context) error {
+	return nil
+}
+
+func (s *memoryMigrationStore) appliedVersions(ctx context.Context) (map[int64]bool, error) {
+	applied := make(map[int64]bool, len(s.applied))
+	for version := range s.applied {
+		applied[version] = true
+	}
+	return applied, nil
+}
+
+func (s *memoryMigrationStore) run(ctx context.Context, m Migration, sql string, up bool) (bool, error) {
+	if s.applied[m.Version] == up {
+		return false, nil
+	}
+	if sql == s.failOn {
+		return false, errors.New("syntax error")
+	}
+	s.executed = append(s.executed, sql)
+	if up {
+		s.applied[m.Version] = true
+	} else {
+		delete(s.applied, m.Version)
+	}
+	return true, nil
+}
+
+func testMigrationsFS() fstest.MapFS {
+	return fstest.MapFS{
+		"migrations/001_create_users.up.sql":   {Data: []byte("CREATE TABLE users")},
+		"migrations/001_create_users.down.sql": {Data: []byte("DROP TABLE users")},
+		"migrations/002_add_email.up.sql":      {Data: []byte("ALTER TABLE users ADD email")},
+		"migrations/002_add_email.down.sql":    {Data: []byte("ALTER TABLE users DROP email")},
+		"migrations/010_create_orders.up.sql":  {Data: []byte("CREATE TABLE orders")},
+		"migrations/README.md":                 {Data: []byte("ignored")},
+	}
+}
+
+func newTestMigrator(store migrationStore, fsys fstest.MapFS) *Migrator {
+	return &Migrator{store: store, fsys: fsys, dir: "migrations", logger: &mockLogger{}}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := LoadMigrations(testMigrationsFS(), "migrations")
+	if err != nil {
+		t.Fatalf("LoadMigrations() error = %v", err)
+	}
+
+	var versions []int64
+	for _, m := range migrations {
+		versions = append(versions, m.Version)
+	}
+	if !reflect.DeepEqual(versions, []int64{1, 2, 10}) {
+		t.Errorf("versions = %v, want [1 2 10]", versions)
+	}
+	if migrations[0].Name != "create_users" || migrations[0].DownSQL != "DROP TABLE users" {
+		t.Errorf("unexpected first migration %+v", migrations[0])
+	}
+	if migrations[2].DownSQL != "" {
+		t.Errorf("expected no down SQL for 010, got %q", migrations[2].DownSQL)
+	}
+}
+
+func TestLoadMigrations_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{
+			name: "duplicate version",
+			fsys: fstest.MapFS{
+				"migrations/001_a.up.sql": {Data: []byte("A")},
+				"migrations/001_b.up.sql": {Data: []byte("B")},
+			},
+		},
+		{
+			name: "down without up",
+			fsys: fstest.MapFS{
+				"migrations/001_a.down.sql": {Data: []byte("A")},
+			},
+		},
+		{
+			name: "missing directory",
+			fsys: fstest.MapFS{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadMigrations(tt.fsys, "migrations"); !errors.Is(err, ErrMigrationFailed) {
+				t.Errorf("LoadMigrations() error = %v, want ErrMigrationFailed", err)
+			}
+		})
+	}
+}
+
+func TestMigrator_UpIsIdempotent(t *testing.T) {
+	store := newMemoryMigrationStore()
+	m := newTestMigrator(store, testMigrationsFS())
+	ctx := context.Background()
+
+	if err := m.Up(ctx); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	want := []string{"CREATE TABLE users", "ALTER TABLE users ADD email", "CREATE TABLE orders"}
+	if !reflect.DeepEqual(store.executed, want) {
+		t.Fatalf("executed = %v, want %v", store.executed, want)
+	}
+
+	if err := m.Up(ctx); err != nil {
+		t.Fatalf("second Up() error = %v", err)
+	}
+	if len(store.executed) != len(want) {
+		t.Errorf("re-run executed %d more statements", len(store.executed)-len(want))
+	}
+}
+
+func TestMigrator_UpStopsAtFailure(t *testing.T) {
+	store := newMemoryMigrationStore()
+	store.failOn = "ALTER TABLE users ADD email"
+	m := newTestMigrator(store, testMigrationsFS())
+
+	err := m.Up(context.Background())
+	if !errors.Is(err, ErrMigrationFailed) {
+		t.Fatalf("Up() error = %v, want ErrMigrationFailed", err)
+	}
+	if !reflect.DeepEqual(store.applied, map[int64]bool{1: true}) {
+		t.Errorf("applied = %v, want only version 1", store.applied)
+	}
+}
+
+func TestMigrator_Rollback(t *testing.T) {
+	store := newMemoryMigrationStore()
+	fsys := testMigrationsFS()
+	delete(fsys, "migrations/010_create_orders.up.sql")
+	m := newTestMigrator(store, fsys)
+	ctx := context.Background()
+
+	if err := m.Up(ctx); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	store.executed = nil
+
+	if err := m.Rollback(ctx, 1); err != nil {
+		t.Fatalf("Rollback(1) error = %v", err)
+	}
+	if !reflect.DeepEqual(store.executed, []string{"ALTER TABLE users DROP email"}) {
+		t.Fatalf("executed = %v", store.executed)
+	}
+
+	// Rolling back more steps than applied stops after the oldest
+	if err := m.Rollback(ctx, 5); err != nil {
+		t.Fatalf("Rollback(5) error = %v", err)
+	}
+	if len(store.applied) != 0 {
+		t.Errorf("applied = %v, want none", store.applied)
+	}
+
+	// Up after rollback re-applies everything
+	if err := m.Up(ctx); err != nil {
+		t.Fatalf("Up() after rollback error = %v", err)
+	}
+	if len(store.applied) != 2 {
+		t.Errorf("applied = %v, want 2 versions", store.applied)
+	}
+}
+
+func TestMigrator_RollbackWithoutDown(t *testing.T) {
+	store := newMemoryMigrationStore()
+	m := newTestMigrator(store, testMigrationsFS())
+	ctx := context.Background()
+
+	if err := m.Up(ctx); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if err := m.Rollback(ctx, 1); !errors.Is(err, ErrMigrationFailed) {
+		t.Fatalf("Rollback() error = %v, want ErrMigrationFailed", err)
+	}
+	if !store.applied[10] {
+		t.Error("migration 010 should remain applied")
+	}
+}
+
+func TestParseMigrationFilename(t *testing.T) {
+	tests := []struct {
+		filename string
+		version  int64
+		name     string
+		up       bool
+		ok       bool
+	}{
+		{"001_create_users.up.sql", 1, "create_users", true, true},
+		{"20240102_add_index.down.sql", 20240102, "add_index", false, true},
+		{"001.up.sql", 0, "", false, false},
+		{"abc_name.up.sql", 0, "", false, false},
+		{"001_name.sql", 0, "", false, false},
+	}
+
+	for _, tt := range tests {
+		version, name, up, ok := parseMigrationFilename(tt.filename)
+		if version != tt.version || name != tt.name || up != tt.up || ok != tt.ok {
+			t.Errorf("parseMigrationFilename(%q) = %d, %q, %v, %v", tt.filename, version, name, up, ok)
+		}
+	}
+}
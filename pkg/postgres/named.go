@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// NamedExec executes a query with sqlx-style :name placeholders taken from params.
+// Placeholders are rewritten to positional $N arguments; a name used twice reuses the same argument.
+func (c *Client) NamedExec(ctx context.Context, sql string, params map[string]any) (pgconn.CommandTag, error) {
+	query, args, err := BindNamed(sql, params)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	return c.Exec(ctx, query, args...)
+}
+
+// NamedQuery executes a query with sqlx-style :name placeholders taken from params.
+func (c *Client) NamedQuery(ctx context.Context, sql string, params map[string]any) (pgx.Rows, error) {
+	query, args, err := BindNamed(sql, params)
+	if err != nil {
+		return nil, err
+	}
+	return c.Query(ctx, query, args...)
+}
+
+// BindNamed rewrites :name placeholders in sql into $1, $2, ... in order of first appearance and
+// returns the matching positional arguments. Placeholders inside quoted strings, quoted identifiers,
+// dollar-quoted bodies, and -- or /* */ comments are left untouched, as are :: type casts.
+func BindNamed(sql string, params map[string]any) (string, []any, error) {
+	var (
+		out       strings.Builder
+		args      []any
+		positions = make(map[string]int)
+	)
+	out.Grow(len(sql))
+
+	for i := 0; i < len(sql); {
+		ch := sql[i]
+		switch {
+		case ch == '\'' || ch == '"':
+			end := skipQuoted(sql, i, ch)
+			out.WriteString(sql[i:end])
+			i = end
+		case ch == '-' && i+1 < len(sql) && sql[i+1] == '-':
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				end = len(sql) - i
+			}
+			out.WriteString(sql[i : i+end])
+			i += end
+		case ch == '/' && i+1 < len(sql) && sql[i+1] == '*':
+			end := skipBlockComment(sql, i)
+			out.WriteString(sql[i:end])
+			i = end
+		case ch == '$' && (i == 0 || !isNamePart(sql[i-1])) && dollarTag(sql, i) != "":
+			tag := dollarTag(sql, i)
+			end := len(sql)
+			if idx := strings.Index(sql[i+len(tag):], tag); idx >= 0 {
+				end = i + len(tag) + idx + len(tag)
+			}
+			out.WriteString(sql[i:end])
+			i = end
+		case ch == ':' && i+1 < len(sql) && sql[i+1] == ':':
+			out.WriteString("::")
+			i += 2
+		case ch == ':' && i+1 < len(sql) && isNameStart(sql[i+1]):
+			end := i + 1
+			for end < len(sql) && isNamePart(sql[end]) {
+				end++
+			}
+			name := sql[i+1 : end]
+			pos, ok := positions[name]
+			if !ok {
+				value, found := params[name]
+				if !found {
+					return "", nil, fmt.Errorf("%w: %s", ErrMissingParameter, name)
+				}
+				args = append(args, value)
+				pos = len(args)
+				positions[name] = pos
+			}
+			out.WriteByte('$')
+			out.WriteString(strconv.Itoa(pos))
+			i = end
+		default:
+			out.WriteByte(ch)
+			i++
+		}
+	}
+
+	return out.String(), args, nil
+}
+
+// skipQuoted returns the index just past the quoted section starting at start.
+// Doubled quote characters are treated as escapes.
+func skipQuoted(sql string, start int, quote byte) int {
+	for i := start + 1; i < len(sql); i++ {
+		if sql[i] != quote {
+			continue
+		}
+		if i+1 < len(sql) && sql[i+1] == quote {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(sql)
+}
+
+// skipBlockComment returns the index just past the /* */ comment starting at start.
+// Comments nest, as in PostgreSQL.
+func skipBlockComment(sql string, start int) int {
+	depth := 0
+	for i := start; i+1 < len(sql); i++ {
+		switch {
+		case sql[i] == '/' && sql[i+1] == '*':
+			depth++
+			i++
+		case sql[i] == '*' && sql[i+1] == '/':
+			depth--
+			i++
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(sql)
+}
+
+// dollarTag returns the $tag$ or $$ delimiter opening a dollar-quoted string at start, or ""
+// when the $ starts something else, such as a $1 parameter.
+func dollarTag(sql string, start int) string {
+	i := start + 1
+	if i < len(sql) && isNameStart(sql[i]) {
+		for i < len(sql) && isNamePart(sql[i]) {
+			i++
+		}
+	}
+	if i < len(sql) && sql[i] == '$' {
+		return sql[start : i+1]
+	}
+	return ""
+}
+
+func isNameStart(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isNamePart(ch byte) bool {
+	return isNameStart(ch) || (ch >= '0' && ch <= '9')
+}
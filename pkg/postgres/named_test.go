@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBindNamed(t *testing.T) {
+	tests := []struct {
+		name     string
+		sql      string
+		params   map[string]any
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name:     "ordered by first appearance",
+			sql:      "INSERT INTO users (name, email, age) VALUES (:name, :email, :age)",
+			params:   map[string]any{"age": 30, "email": "a@example.com", "name": "Alice"},
+			wantSQL:  "INSERT INTO users (name, email, age) VALUES ($1, $2, $3)",
+			wantArgs: []any{"Alice", "a@example.com", 30},
+		},
+		{
+			name:     "same name reused",
+			sql:      "SELECT * FROM users WHERE created_by = :id OR updated_by = :id AND tenant = :tenant",
+			params:   map[string]any{"id": 7, "tenant": "acme"},
+			wantSQL:  "SELECT * FROM users WHERE created_by = $1 OR updated_by = $1 AND tenant = $2",
+			wantArgs: []any{7, "acme"},
+		},
+		{
+			name:     "casts, strings, and comments untouched",
+			sql:      "SELECT ':skip', \"col:x\", :id::bigint -- :comment\nFROM t",
+			params:   map[string]any{"id": "42"},
+			wantSQL:  "SELECT ':skip', \"col:x\", $1::bigint -- :comment\nFROM t",
+			wantArgs: []any{"42"},
+		},
+		{
+			name:     "block comments untouched",
+			sql:      "SELECT /* :a /* :nested */ :b */ :id",
+			params:   map[string]any{"id": 1},
+			wantSQL:  "SELECT /* :a /* :nested */ :b */ $1",
+			wantArgs: []any{1},
+		},
+		{
+			name:     "dollar-quoted bodies untouched",
+			sql:      "SELECT $$ :a $$, $fn$ it's :b $fn$, :id, $1",
+			params:   map[string]any{"id": 1},
+			wantSQL:  "SELECT $$ :a $$, $fn$ it's :b $fn$, $1, $1",
+			wantArgs: []any{1},
+		},
+		{
+			name:    "no placeholders",
+			sql:     "SELECT 1",
+			params:  nil,
+			wantSQL: "SELECT 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotArgs, err := BindNamed(tt.sql, tt.params)
+			if err != nil {
+				t.Fatalf("BindNamed() error = %v", err)
+			}
+			if gotSQL != tt.wantSQL {
+				t.Errorf("BindNamed() sql = %q, want %q", gotSQL, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("BindNamed() args = %v, want %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestBindNamed_MissingParameter(t *testing.T) {
+	_, _, err := BindNamed("UPDATE users SET name = :name WHERE id = :id", map[string]any{"name": "Bob"})
+	if !errors.Is(err, ErrMissingParameter) {
+		t.Fatalf("BindNamed() error = %v, want ErrMissingParameter", err)
+	}
+	if got := err.Error(); got != "postgres: missing named parameter: id" {
+		t.Errorf("BindNamed() error message = %q", got)
+	}
+}
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"time"
+)
+
+// Query operations reported in QueryEvent.Operation.
+const (
+	OperationQuery    = "query"
+	OperationQueryRow = "query_row"
+	OperationExec     = "exec"
+)
+
+// QueryEvent describes a single executed statement. It carries the SQL template only; argument
+// values are never included so observers cannot leak sensitive data.
+type QueryEvent struct {
+	Operation string
+	SQL       string
+	Duration  time.Duration
+	// RowsAffected is reported for Exec; reads stream their rows and report 0.
+	RowsAffected int64
+	Err          error
+}
+
+// QueryObserver receives a QueryEvent after every Query, QueryRow, and Exec, e.g. to record metrics.
+type QueryObserver interface {
+	ObserveQuery(ctx context.Context, event QueryEvent)
+}
+
+// QueryObserverFunc adapts a function to the QueryObserver interface.
+type QueryObserverFunc func(ctx context.Context, event QueryEvent)
+
+// ObserveQuery calls f(ctx, event).
+func (f QueryObserverFunc) ObserveQuery(ctx context.Context, event QueryEvent) {
+	f(ctx, event)
+}
+
+// observeQuery reports a finished statement to the observer and logs it when slower than
+// Config.SlowQueryThreshold.
+func (c *Client) observeQuery(ctx context.Context, operation, sql string, start time.Time, rowsAffected int64, err error) {
+	duration := time.Since(start)
+
+	if c.observer != nil {
+		c.observer.ObserveQuery(ctx, QueryEvent{
+			Operation:    operation,
+			SQL:          sql,
+			Duration:     duration,
+			RowsAffected: rowsAffected,
+			Err:          err,
+		})
+	}
+
+	if c.config != nil && c.config.SlowQueryThreshold > 0 && duration >= c.config.SlowQueryThreshold {
+		c.logger.Warn("slow query",
+			"operation", operation,
+			"sql", sql,
+			"duration", duration,
+			"threshold", c.config.SlowQueryThreshold,
+		)
+	}
+}
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClient_ObserveQuery(t *testing.T) {
+	var events []QueryEvent
+	logger := &mockLogger{}
+	client := &Client{
+		config: &Config{SlowQueryThreshold: time.Hour},
+		logger: logger,
+		observer: QueryObserverFunc(func(ctx context.Context, event QueryEvent) {
+			events = append(events, event)
+		}),
+	}
+
+	queryErr := errors.New("boom")
+	client.observeQuery(context.Background(), OperationExec, "UPDATE users SET name = $1", time.Now(), 3, queryErr)
+
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	event := events[0]
+	if event.Operation != OperationExec || event.SQL != "UPDATE users SET name = $1" {
+		t.Errorf("unexpected event %+v", event)
+	}
+	if event.RowsAffected != 3 {
+		t.Errorf("expected 3 rows affected, got %d", event.RowsAffected)
+	}
+	if !errors.Is(event.Err, queryErr) {
+		t.Errorf("expected query error, got %v", event.Err)
+	}
+	if len(logger.messages) != 0 {
+		t.Errorf("expected no slow query log under threshold, got %+v", logger.messages)
+	}
+}
+
+func TestClient_ObserveQuery_SlowQueryLog(t *testing.T) {
+	logger := &mockLogger{}
+	client := &Client{
+		config: &Config{SlowQueryThreshold: 100 * time.Millisecond},
+		logger: logger,
+	}
+
+	client.observeQuery(context.Background(), OperationQuery, "SELECT * FROM users WHERE email = $1", time.Now().Add(-time.Second), 0, nil)
+
+	if len(logger.messages) != 1 {
+		t.Fatalf("expected 1 log message, got %d", len(logger.messages))
+	}
+	msg := logger.messages[0]
+	if msg.level != "warn" || msg.msg != "slow query" {
+		t.Errorf("unexpected log %+v", msg)
+	}
+	fields := make(map[string]any)
+	for i := 0; i+1 < len(msg.fields); i += 2 {
+		fields[msg.fields[i].(string)] = msg.fields[i+1]
+	}
+	if fields["sql"] != "SELECT * FROM users WHERE email = $1" {
+		t.Errorf("expected SQL template in log, got %v", fields["sql"])
+	}
+	if _, ok := fields["args"]; ok {
+		t.Error("query arguments must not be logged")
+	}
+}
+
+func TestClient_ObserveQuery_ThresholdDisabled(t *testing.T) {
+	logger := &mockLogger{}
+	client := &Client{config: &Config{}, logger: logger}
+
+	client.observeQuery(context.Background(), OperationQuery, "SELECT 1", time.Now().Add(-time.Hour), 0, nil)
+
+	if len(logger.messages) != 0 {
+		t.Errorf("expected no log with threshold disabled, got %d", len(logger.messages))
+	}
+}
@@ -24,3 +24,10 @@ func WithQueryHook(hook QueryHook) Option {
 		c.queryHook = hook
 	}
 }
+
+// WithQueryObserver sets an observer that receives timing for every statement.
+func WithQueryObserver(observer QueryObserver) Option {
+	return func(c *Client) {
+		c.observer = observer
+	}
+}
@@ -0,0 +1,30 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Querier runs statements. It is satisfied by *Client, pgx.Tx, *SavepointTx, and *pgxpool.Pool, so a
+// repository function that takes a Querier runs on the pool or inside a transaction, whichever the
+// caller passes.
+//
+//	func CreateUser(ctx context.Context, q postgres.Querier, name string) error {
+//		_, err := q.Exec(ctx, "INSERT INTO users (name) VALUES ($1)", name)
+//		return err
+//	}
+type Querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+var (
+	_ Querier = (*Client)(nil)
+	_ Querier = (pgx.Tx)(nil)
+	_ Querier = (*SavepointTx)(nil)
+	_ Querier = (*pgxpool.Pool)(nil)
+)
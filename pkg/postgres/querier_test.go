@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// createUser is a repository function that works on whichever Querier it is given.
+func createUser(ctx context.Context, q Querier, name string) error {
+	_, err := q.Exec(ctx, "INSERT INTO users (name) VALUES ($1)", name)
+	return err
+}
+
+func TestQuerier_ClientAndTx(t *testing.T) {
+	ctx := context.Background()
+	client, primary, _ := newRoutingClient()
+	table := &fakeTable{}
+	tx := &fakeTx{table: table}
+
+	if err := createUser(ctx, client, "alice"); err != nil {
+		t.Fatalf("createUser() on client error = %v", err)
+	}
+	if err := createUser(ctx, tx, "bob"); err != nil {
+		t.Fatalf("createUser() on tx error = %v", err)
+	}
+	if err := createUser(ctx, NewSavepointTx(tx), "carol"); err != nil {
+		t.Fatalf("createUser() on savepoint tx error = %v", err)
+	}
+
+	if want := []string{"exec:INSERT INTO users (name) VALUES ($1)"}; !reflect.DeepEqual(primary.calls, want) {
+		t.Errorf("primary calls = %v, want %v", primary.calls, want)
+	}
+	if want := []any{"bob", "carol"}; !reflect.DeepEqual(table.rows, want) {
+		t.Errorf("transaction rows = %v, want %v", table.rows, want)
+	}
+}
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// fakePool records the statements routed to it.
+type fakePool struct {
+	name  string
+	calls []string
+}
+
+func (f *fakePool) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	f.calls = append(f.calls, "query:"+sql)
+	return nil, nil
+}
+
+func (f *fakePool) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	f.calls = append(f.calls, "query_row:"+sql)
+	return nil
+}
+
+func (f *fakePool) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	f.calls = append(f.calls, "exec:"+sql)
+	return pgconn.NewCommandTag("UPDATE 1"), nil
+}
+
+func newRoutingClient() (*Client, *fakePool, []*fakePool) {
+	primary := &fakePool{name: "primary"}
+	replicas := []*fakePool{{name: "replica-0"}, {name: "replica-1"}}
+	client := &Client{
+		logger:      NewNoopLogger(),
+		primary:     primary,
+		replicas:    []Querier{replicas[0], replicas[1]},
+		nextReplica: new(atomic.Uint64),
+	}
+	return client, primary, replicas
+}
+
+func TestClient_ReplicaRouting(t *testing.T) {
+	client, primary, replicas := newRoutingClient()
+	ctx := context.Background()
+
+	for i := 0; i < 4; i++ {
+		if _, err := client.Query(ctx, "SELECT 1"); err != nil {
+			t.Fatalf("Query() error = %v", err)
+		}
+	}
+	client.QueryRow(ctx, "SELECT 2")
+	if _, err := client.Exec(ctx, "UPDATE users SET active = true"); err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+
+	if got := len(replicas[0].calls); got != 3 {
+		t.Errorf("replica-0 received %d reads, want 3", got)
+	}
+	if got := len(replicas[1].calls); got != 2 {
+		t.Errorf("replica-1 received %d reads, want 2", got)
+	}
+	if len(primary.calls) != 1 || primary.calls[0] != "exec:UPDATE users SET active = true" {
+		t.Errorf("primary calls = %v, want only the write", primary.calls)
+	}
+}
+
+func TestClient_SideEffectsUsePrimary(t *testing.T) {
+	client, primary, replicas := newRoutingClient()
+	ctx := context.Background()
+
+	client.QueryRow(ctx, "INSERT INTO users (email) VALUES ($1) RETURNING id")
+	client.QueryRow(ctx, "WITH moved AS (DELETE FROM queue RETURNING *) SELECT count(*) FROM moved")
+	if _, err := client.Query(ctx, "SELECT id FROM jobs FOR UPDATE SKIP LOCKED"); err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+
+	if len(primary.calls) != 3 {
+		t.Errorf("primary calls = %v, want all three statements", primary.calls)
+	}
+	for _, replica := range replicas {
+		if len(replica.calls) != 0 {
+			t.Errorf("%s received %v, want no calls", replica.name, replica.calls)
+		}
+	}
+}
+
+func TestIsReadOnly(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want bool
+	}{
+		{"SELECT 1", true},
+		{"  -- leading comment\n select * from users", true},
+		{"WITH recent AS (SELECT * FROM orders) SELECT * FROM recent", true},
+		{"SELECT 'update' AS word, \"insert\" FROM t /* delete */", true},
+		{"SELECT $$ returning $$", true},
+		{"UPDATE users SET name = $1 RETURNING id", false},
+		{"insert into users default values returning id", false},
+		{"SELECT * INTO backup FROM users", false},
+		{"SELECT * FROM users FOR SHARE", false},
+		{"CALL refresh()", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := isReadOnly(tt.sql); got != tt.want {
+			t.Errorf("isReadOnly(%q) = %v, want %v", tt.sql, got, tt.want)
+		}
+	}
+}
+
+func TestClient_PrimaryForcesReads(t *testing.T) {
+	client, primary, replicas := newRoutingClient()
+	ctx := context.Background()
+
+	if _, err := client.Primary().Query(ctx, "SELECT balance FROM accounts"); err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	client.Primary().QueryRow(ctx, "SELECT 1")
+
+	if len(primary.calls) != 2 {
+		t.Errorf("primary calls = %v, want both reads", primary.calls)
+	}
+	for _, replica := range replicas {
+		if len(replica.calls) != 0 {
+			t.Errorf("%s received %v, want no calls", replica.name, replica.calls)
+		}
+	}
+}
+
+func TestClient_ReadsUsePrimaryWithoutReplicas(t *testing.T) {
+	primary := &fakePool{name: "primary"}
+	client := &Client{logger: NewNoopLogger(), primary: primary, nextReplica: new(atomic.Uint64)}
+
+	if _, err := client.Query(context.Background(), "SELECT 1"); err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if len(primary.calls) != 1 {
+		t.Errorf("primary calls = %v, want the read", primary.calls)
+	}
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Default retry settings used by TransactionWithRetry for zero-valued RetryOptions fields.
+const (
+	DefaultTxMaxRetries     = 3
+	DefaultTxInitialBackoff = 10 * time.Millisecond
+	DefaultTxMaxBackoff     = time.Second
+)
+
+// NoTxRetries disables retries when set as RetryOptions.MaxRetries; the transaction runs once.
+const NoTxRetries = -1
+
+// RetryOptions configures TransactionWithRetry.
+type RetryOptions struct {
+	// TxOptions sets the isolation level and access mode, e.g. pgx.Serializable.
+	TxOptions pgx.TxOptions
+	// MaxRetries is the number of retries after the first attempt. Zero means DefaultTxMaxRetries;
+	// use NoTxRetries (or any negative value) to disable retries.
+	MaxRetries int
+	// InitialBackoff is the wait before the first retry; it doubles on each retry up to MaxBackoff.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between retries.
+	MaxBackoff time.Duration
+}
+
+func (o RetryOptions) withDefaults() RetryOptions {
+	switch {
+	case o.MaxRetries < 0:
+		o.MaxRetries = 0
+	case o.MaxRetries == 0:
+		o.MaxRetries = DefaultTxMaxRetries
+	}
+	if o.InitialBackoff <= 0 {
+		o.InitialBackoff = DefaultTxInitialBackoff
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = DefaultTxMaxBackoff
+	}
+	if o.MaxBackoff < o.InitialBackoff {
+		o.MaxBackoff = o.InitialBackoff
+	}
+	return o
+}
+
+// TransactionWithRetry runs fn in a transaction and re-runs the whole transaction when it fails
+// with a serialization failure (40001) or deadlock (40P01), waiting with exponential backoff between
+// attempts. fn must be safe to execute more than once. Other errors are returned immediately.
+func (c *Client) TransactionWithRetry(ctx context.Context, opts RetryOptions, fn func(tx pgx.Tx) error) error {
+	return retryTransaction(ctx, opts, c.logger, func() error {
+		return c.TransactionWithOptions(ctx, opts.TxOptions, fn)
+	})
+}
+
+// retryTransaction calls run until it succeeds, fails with a non-retryable error, or retries are exhausted.
+func retryTransaction(ctx context.Context, opts RetryOptions, logger Logger, run func() error) error {
+	opts = opts.withDefaults()
+	backoff := opts.InitialBackoff
+
+	for attempt := 0; ; attempt++ {
+		err := run()
+		if err == nil || !IsRetryableTxError(err) || attempt >= opts.MaxRetries {
+			return err
+		}
+
+		logger.Warn("retrying transaction",
+			"attempt", attempt+1,
+			"code", ErrorCode(err),
+			"backoff", backoff,
+		)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if backoff > opts.MaxBackoff {
+			backoff = opts.MaxBackoff
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestRetryTransaction_RetriesSerializationFailures(t *testing.T) {
+	logger := &mockLogger{}
+	calls := 0
+	err := retryTransaction(context.Background(), RetryOptions{InitialBackoff: time.Millisecond}, logger, func() error {
+		calls++
+		switch calls {
+		case 1:
+			return &pgconn.PgError{Code: "40001"}
+		case 2:
+			return fmt.Errorf("%w: commit transaction: %w", ErrQueryFailed, &pgconn.PgError{Code: "40P01"})
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("retryTransaction() error = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("fn ran %d times, want 3", calls)
+	}
+	if len(logger.messages) != 2 {
+		t.Errorf("expected 2 retry warnings, got %d", len(logger.messages))
+	}
+}
+
+func TestRetryTransaction_StopsAfterMaxRetries(t *testing.T) {
+	calls := 0
+	serialization := &pgconn.PgError{Code: "40001"}
+	err := retryTransaction(context.Background(), RetryOptions{MaxRetries: 2, InitialBackoff: time.Millisecond}, NewNoopLogger(), func() error {
+		calls++
+		return serialization
+	})
+	if !errors.Is(err, serialization) {
+		t.Fatalf("retryTransaction() error = %v, want serialization failure", err)
+	}
+	if calls != 3 {
+		t.Errorf("fn ran %d times, want 3", calls)
+	}
+}
+
+func TestRetryTransaction_NoTxRetries(t *testing.T) {
+	calls := 0
+	serialization := &pgconn.PgError{Code: "40001"}
+	err := retryTransaction(context.Background(), RetryOptions{MaxRetries: NoTxRetries}, NewNoopLogger(), func() error {
+		calls++
+		return serialization
+	})
+	if !errors.Is(err, serialization) {
+		t.Fatalf("retryTransaction() error = %v, want serialization failure", err)
+	}
+	if calls != 1 {
+		t.Errorf("fn ran %d times, want 1", calls)
+	}
+}
+
+func TestRetryTransaction_DoesNotRetryOtherErrors(t *testing.T) {
+	calls := 0
+	want := &pgconn.PgError{Code: "23505"}
+	err := retryTransaction(context.Background(), RetryOptions{}, NewNoopLogger(), func() error {
+		calls++
+		return want
+	})
+	if !errors.Is(err, want) {
+		t.Fatalf("retryTransaction() error = %v, want %v", err, want)
+	}
+	if calls != 1 {
+		t.Errorf("fn ran %d times, want 1", calls)
+	}
+}
+
+func TestRetryTransaction_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := retryTransaction(ctx, RetryOptions{InitialBackoff: time.Hour}, NewNoopLogger(), func() error {
+		calls++
+		cancel()
+		return &pgconn.PgError{Code: "40001"}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("retryTransaction() error = %v, want context.Canceled", err)
+	}
+	if calls != 1 {
+		t.Errorf("fn ran %d times, want 1", calls)
+	}
+}
@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SavepointTx is a pgx.Tx that can set named savepoints, so part of a transaction can be undone
+// without aborting the rest. Savepoints are pgx nested transactions started with Begin.
+// It is not safe for concurrent use, like the pgx.Tx it wraps.
+type SavepointTx struct {
+	pgx.Tx
+	savepoints []savepoint
+}
+
+type savepoint struct {
+	name string
+	tx   pgx.Tx
+}
+
+// NewSavepointTx wraps tx, e.g. one obtained from Transaction, with named savepoint support.
+func NewSavepointTx(tx pgx.Tx) *SavepointTx {
+	return &SavepointTx{Tx: tx}
+}
+
+// TransactionWithSavepoints is like Transaction but passes a SavepointTx to fn.
+//
+//	err := client.TransactionWithSavepoints(ctx, func(tx *postgres.SavepointTx) error {
+//		for _, item := range items {
+//			if err := tx.Savepoint(ctx, "item"); err != nil {
+//				return err
+//			}
+//			if _, err := tx.Exec(ctx, insertItem, item.ID); err != nil {
+//				if err := tx.RollbackTo(ctx, "item"); err != nil {
+//					return err
+//				}
+//				continue
+//			}
+//			if err := tx.Release(ctx, "item"); err != nil {
+//				return err
+//			}
+//		}
+//		return nil
+//	})
+func (c *Client) TransactionWithSavepoints(ctx context.Context, fn func(tx *SavepointTx) error) error {
+	return c.Transaction(ctx, func(tx pgx.Tx) error {
+		return fn(NewSavepointTx(tx))
+	})
+}
+
+// Savepoint sets a savepoint called name at the current point in the transaction. Reusing the name
+// of an active savepoint shadows it until the newer one is rolled back or released.
+func (t *SavepointTx) Savepoint(ctx context.Context, name string) error {
+	nested, err := t.Tx.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: savepoint %s: %w", ErrQueryFailed, name, err)
+	}
+	t.savepoints = append(t.savepoints, savepoint{name: name, tx: nested})
+	return nil
+}
+
+// RollbackTo undoes all work done since savepoint name was set and removes it, along with any
+// savepoints set after it. The transaction stays usable. Call Savepoint again to reuse the name.
+func (t *SavepointTx) RollbackTo(ctx context.Context, name string) error {
+	i, err := t.find(name)
+	if err != nil {
+		return err
+	}
+	sp := t.savepoints[i]
+	t.savepoints = t.savepoints[:i]
+	if err := sp.tx.Rollback(ctx); err != nil {
+		return fmt.Errorf("%w: rollback to savepoint %s: %w", ErrQueryFailed, name, err)
+	}
+	return nil
+}
+
+// Release keeps the work done since savepoint name was set and removes it, along with any
+// savepoints set after it. The work still commits or rolls back with the transaction.
+func (t *SavepointTx) Release(ctx context.Context, name string) error {
+	i, err := t.find(name)
+	if err != nil {
+		return err
+	}
+	sp := t.savepoints[i]
+	t.savepoints = t.savepoints[:i]
+	if err := sp.tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%w: release savepoint %s: %w", ErrQueryFailed, name, err)
+	}
+	return nil
+}
+
+// find returns the index of the most recent savepoint called name.
+func (t *SavepointTx) find(name string) (int, error) {
+	for i := len(t.savepoints) - 1; i >= 0; i-- {
+		if t.savepoints[i].name == name {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrSavepointNotFound, name)
+}
@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// fakeTable is the state shared by a fakeTx and its savepoints.
+type fakeTable struct {
+	rows      []any
+	committed []any
+}
+
+// fakeTx records inserted rows and emulates savepoints by truncating them on rollback.
+type fakeTx struct {
+	pgx.Tx
+	table  *fakeTable
+	mark   int
+	nested bool
+	closed bool
+}
+
+func (tx *fakeTx) Begin(ctx context.Context) (pgx.Tx, error) {
+	if tx.closed {
+		return nil, pgx.ErrTxClosed
+	}
+	return &fakeTx{table: tx.table, mark: len(tx.table.rows), nested: true}, nil
+}
+
+func (tx *fakeTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	if args[0] == "invalid" {
+		return pgconn.CommandTag{}, &pgconn.PgError{Code: checkViolationCode}
+	}
+	tx.table.rows = append(tx.table.rows, args[0])
+	return pgconn.NewCommandTag("INSERT 0 1"), nil
+}
+
+func (tx *fakeTx) Commit(ctx context.Context) error {
+	if tx.closed {
+		return pgx.ErrTxClosed
+	}
+	tx.closed = true
+	if !tx.nested {
+		tx.table.committed = append([]any(nil), tx.table.rows...)
+	}
+	return nil
+}
+
+func (tx *fakeTx) Rollback(ctx context.Context) error {
+	if tx.closed {
+		return pgx.ErrTxClosed
+	}
+	tx.closed = true
+	tx.table.rows = tx.table.rows[:tx.mark]
+	return nil
+}
+
+func TestSavepointTx_RollbackToKeepsOtherRows(t *testing.T) {
+	ctx := context.Background()
+	table := &fakeTable{}
+	root := &fakeTx{table: table}
+	tx := NewSavepointTx(root)
+
+	for _, name := range []any{"alice", "invalid", "bob", "carol"} {
+		if err := tx.Savepoint(ctx, "item"); err != nil {
+			t.Fatalf("Savepoint() error = %v", err)
+		}
+		if _, err := tx.Exec(ctx, "INSERT INTO users (name) VALUES ($1)", name); err != nil {
+			if err := tx.RollbackTo(ctx, "item"); err != nil {
+				t.Fatalf("RollbackTo() error = %v", err)
+			}
+			continue
+		}
+		// Roll back a row that was inserted successfully, too
+		if name == "bob" {
+			if err := tx.RollbackTo(ctx, "item"); err != nil {
+				t.Fatalf("RollbackTo() error = %v", err)
+			}
+			continue
+		}
+		if err := tx.Release(ctx, "item"); err != nil {
+			t.Fatalf("Release() error = %v", err)
+		}
+	}
+	if err := root.Commit(ctx); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	if want := []any{"alice", "carol"}; !reflect.DeepEqual(table.committed, want) {
+		t.Errorf("committed rows = %v, want %v", table.committed, want)
+	}
+}
+
+func TestSavepointTx_RollbackToDiscardsLaterSavepoints(t *testing.T) {
+	ctx := context.Background()
+	table := &fakeTable{}
+	tx := NewSavepointTx(&fakeTx{table: table})
+
+	mustExec := func(name string) {
+		t.Helper()
+		if _, err := tx.Exec(ctx, "INSERT INTO users (name) VALUES ($1)", name); err != nil {
+			t.Fatalf("Exec() error = %v", err)
+		}
+	}
+	mustExec("alice")
+	if err := tx.Savepoint(ctx, "outer"); err != nil {
+		t.Fatalf("Savepoint() error = %v", err)
+	}
+	mustExec("bob")
+	if err := tx.Savepoint(ctx, "inner"); err != nil {
+		t.Fatalf("Savepoint() error = %v", err)
+	}
+	mustExec("carol")
+
+	if err := tx.RollbackTo(ctx, "outer"); err != nil {
+		t.Fatalf("RollbackTo() error = %v", err)
+	}
+	if want := []any{"alice"}; !reflect.DeepEqual(table.rows, want) {
+		t.Errorf("rows = %v, want %v", table.rows, want)
+	}
+	for _, name := range []string{"outer", "inner"} {
+		if err := tx.Release(ctx, name); !errors.Is(err, ErrSavepointNotFound) {
+			t.Errorf("Release(%q) error = %v, want ErrSavepointNotFound", name, err)
+		}
+	}
+}
+
+func TestSavepointTx_ShadowedName(t *testing.T) {
+	ctx := context.Background()
+	table := &fakeTable{}
+	tx := NewSavepointTx(&fakeTx{table: table})
+
+	for _, name := range []any{"alice", "bob"} {
+		if err := tx.Savepoint(ctx, "sp"); err != nil {
+			t.Fatalf("Savepoint() error = %v", err)
+		}
+		if _, err := tx.Exec(ctx, "INSERT INTO users (name) VALUES ($1)", name); err != nil {
+			t.Fatalf("Exec() error = %v", err)
+		}
+	}
+
+	// The first RollbackTo targets the newest "sp", the second the one it shadowed
+	if err := tx.RollbackTo(ctx, "sp"); err != nil {
+		t.Fatalf("RollbackTo() error = %v", err)
+	}
+	if want := []any{"alice"}; !reflect.DeepEqual(table.rows, want) {
+		t.Errorf("rows = %v, want %v", table.rows, want)
+	}
+	if err := tx.RollbackTo(ctx, "sp"); err != nil {
+		t.Fatalf("RollbackTo() error = %v", err)
+	}
+	if len(table.rows) != 0 {
+		t.Errorf("rows = %v, want none", table.rows)
+	}
+}
+
+func TestSavepointTx_BeginError(t *testing.T) {
+	tx := NewSavepointTx(&fakeTx{table: &fakeTable{}, closed: true})
+	err := tx.Savepoint(context.Background(), "sp")
+	if !errors.Is(err, ErrQueryFailed) || !errors.Is(err, pgx.ErrTxClosed) {
+		t.Errorf("Savepoint() error = %v, want ErrQueryFailed wrapping pgx.ErrTxClosed", err)
+	}
+}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
@@ -30,6 +31,26 @@ func ScanAll[T any](rows pgx.Rows) ([]T, error) {
 	return results, nil
 }
 
+// QueryStruct runs sql and maps the first row to a T, matching columns to fields by their `db` tag
+// (or field name). It returns ErrNoRows when the query returns no rows.
+func QueryStruct[T any](ctx context.Context, client *Client, sql string, args ...any) (*T, error) {
+	rows, err := client.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	return ScanOne[T](rows)
+}
+
+// QueryStructs runs sql and maps every row to a T, matching columns to fields by their `db` tag
+// (or field name). A query without rows returns an empty, non-nil slice.
+func QueryStructs[T any](ctx context.Context, client *Client, sql string, args ...any) ([]T, error) {
+	rows, err := client.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	return ScanAll[T](rows)
+}
+
 // ScanMap scans a single row into a map[string]any.
 func ScanMap(rows pgx.Rows) (map[string]any, error) {
 	if !rows.Next() {
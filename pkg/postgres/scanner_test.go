@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// fakeRows is an in-memory pgx.Rows over fixed columns and values.
+type fakeRows struct {
+	columns []string
+	values  [][]any
+	index   int
+	closed  bool
+}
+
+func (r *fakeRows) Close()                        { r.closed = true }
+func (r *fakeRows) Err() error                    { return nil }
+func (r *fakeRows) CommandTag() pgconn.CommandTag { return pgconn.NewCommandTag("SELECT") }
+func (r *fakeRows) RawValues() [][]byte           { return nil }
+func (r *fakeRows) Conn() *pgx.Conn               { return nil }
+
+func (r *fakeRows) FieldDescriptions() []pgconn.FieldDescription {
+	fields := make([]pgconn.FieldDescription, len(r.columns))
+	for i, column := range r.columns {
+		fields[i] = pgconn.FieldDescription{Name: column}
+	}
+	return fields
+}
+
+func (r *fakeRows) Next() bool {
+	if r.closed || r.index >= len(r.values) {
+		r.closed = true
+		return false
+	}
+	r.index++
+	return true
+}
+
+func (r *fakeRows) Values() ([]any, error) {
+	return r.values[r.index-1], nil
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	row := r.values[r.index-1]
+	if len(dest) != len(row) {
+		return fmt.Errorf("scan: got %d destinations for %d columns", len(dest), len(row))
+	}
+	for i, d := range dest {
+		reflect.ValueOf(d).Elem().Set(reflect.ValueOf(row[i]))
+	}
+	return nil
+}
+
+// rowsPool returns rows from Query and records the statement.
+type rowsPool struct {
+	fakePool
+	rows *fakeRows
+}
+
+func (p *rowsPool) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	p.calls = append(p.calls, "query:"+sql)
+	return p.rows, nil
+}
+
+func newRowsClient(columns []string, values ...[]any) *Client {
+	return &Client{
+		logger:      NewNoopLogger(),
+		primary:     &rowsPool{rows: &fakeRows{columns: columns, values: values}},
+		nextReplica: new(atomic.Uint64),
+	}
+}
+
+type scannedUser struct {
+	ID     int64  `db:"id"`
+	Name   string `db:"name"`
+	Email  string `db:"email_address"`
+	Active bool   `db:"active"`
+}
+
+func TestQueryStructs(t *testing.T) {
+	client := newRowsClient([]string{"id", "name", "email_address", "active"},
+		[]any{int64(1), "Ada", "ada@example.com", true},
+		[]any{int64(2), "Grace", "grace@example.com", false},
+	)
+
+	users, err := QueryStructs[scannedUser](context.Background(), client, "SELECT id, name, email_address, active FROM users")
+	if err != nil {
+		t.Fatalf("QueryStructs() error = %v", err)
+	}
+	want := []scannedUser{
+		{ID: 1, Name: "Ada", Email: "ada@example.com", Active: true},
+		{ID: 2, Name: "Grace", Email: "grace@example.com", Active: false},
+	}
+	if !reflect.DeepEqual(users, want) {
+		t.Errorf("QueryStructs() = %+v, want %+v", users, want)
+	}
+}
+
+func TestQueryStructs_NoRows(t *testing.T) {
+	client := newRowsClient([]string{"id", "name", "email_address", "active"})
+
+	users, err := QueryStructs[scannedUser](context.Background(), client, "SELECT * FROM users WHERE false")
+	if err != nil {
+		t.Fatalf("QueryStructs() error = %v", err)
+	}
+	if users == nil || len(users) != 0 {
+		t.Errorf("QueryStructs() = %#v, want empty non-nil slice", users)
+	}
+}
+
+func TestQueryStruct(t *testing.T) {
+	client := newRowsClient([]string{"id", "name", "email_address", "active"},
+		[]any{int64(7), "Linus", "linus@example.com", true},
+	)
+
+	user, err := QueryStruct[scannedUser](context.Background(), client, "SELECT * FROM users WHERE id = $1", 7)
+	if err != nil {
+		t.Fatalf("QueryStruct() error = %v", err)
+	}
+	if user.ID != 7 || user.Email != "linus@example.com" {
+		t.Errorf("QueryStruct() = %+v", user)
+	}
+}
+
+func TestQueryStruct_NoRows(t *testing.T) {
+	client := newRowsClient([]string{"id", "name", "email_address", "active"})
+
+	user, err := QueryStruct[scannedUser](context.Background(), client, "SELECT * FROM users WHERE id = $1", 404)
+	if !errors.Is(err, ErrNoRows) {
+		t.Fatalf("QueryStruct() error = %v, want ErrNoRows", err)
+	}
+	if user != nil {
+		t.Errorf("QueryStruct() = %+v, want nil", user)
+	}
+}
+
+func TestQueryStructs_UnknownColumn(t *testing.T) {
+	client := newRowsClient([]string{"id", "nickname"}, []any{int64(1), "ada"})
+
+	if _, err := QueryStructs[scannedUser](context.Background(), client, "SELECT id, nickname FROM users"); !errors.Is(err, ErrQueryFailed) {
+		t.Fatalf("QueryStructs() error = %v, want ErrQueryFailed", err)
+	}
+}
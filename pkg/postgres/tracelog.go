@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5/tracelog"
+)
+
+// DefaultLogLevel is the pgx log level used when Config.LogLevel is empty.
+const DefaultLogLevel = "warn"
+
+// newTraceLog returns a pgx tracer that writes driver events at or above cfg.LogLevel to cfg.Logger,
+// or nil when cfg.Logger is not set.
+func newTraceLog(cfg Config) (*tracelog.TraceLog, error) {
+	if cfg.Logger == nil {
+		return nil, nil
+	}
+	level, err := parseLogLevel(cfg.LogLevel)
+	if err != nil {
+		return nil, err
+	}
+	return &tracelog.TraceLog{
+		Logger:   traceLogger{logger: cfg.Logger},
+		LogLevel: level,
+	}, nil
+}
+
+// parseLogLevel parses a pgx log level name, treating an empty name as DefaultLogLevel.
+func parseLogLevel(name string) (tracelog.LogLevel, error) {
+	if strings.TrimSpace(name) == "" {
+		name = DefaultLogLevel
+	}
+	return tracelog.LogLevelFromString(strings.ToLower(strings.TrimSpace(name)))
+}
+
+// traceLogger adapts a Logger to the pgx tracelog.Logger interface.
+type traceLogger struct {
+	logger Logger
+}
+
+// Log writes a pgx event, such as "Connect" or "Query", as "pgx connect" with its data as sorted
+// key-value pairs. pgx trace events are logged at debug level. The "args" of query events are
+// dropped so that parameter values, which may hold credentials or personal data, never reach the log.
+func (l traceLogger) Log(ctx context.Context, level tracelog.LogLevel, msg string, data map[string]any) {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		if key == "args" {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	keysAndValues := make([]any, 0, 2*len(keys))
+	for _, key := range keys {
+		keysAndValues = append(keysAndValues, key, data[key])
+	}
+
+	msg = "pgx " + strings.ToLower(msg)
+	switch level {
+	case tracelog.LogLevelError:
+		l.logger.Error(msg, keysAndValues...)
+	case tracelog.LogLevelWarn:
+		l.logger.Warn(msg, keysAndValues...)
+	case tracelog.LogLevelInfo:
+		l.logger.Info(msg, keysAndValues...)
+	default:
+		l.logger.Debug(msg, keysAndValues...)
+	}
+}
@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// traceEvents replays a connect, a pool acquire, and a failed acquire through the tracer of the pool
+// configuration built for cfg, as pgx would on a real connection.
+func traceEvents(t *testing.T, cfg Config) *mockLogger {
+	t.Helper()
+	logger := &mockLogger{}
+	cfg.User, cfg.Password, cfg.Database = "testuser", "testpass", "testdb"
+	cfg.Logger = logger
+
+	poolConfig, err := newPoolConfig(cfg)
+	if err != nil {
+		t.Fatalf("newPoolConfig() error = %v", err)
+	}
+	connectTracer, ok := poolConfig.ConnConfig.Tracer.(pgx.ConnectTracer)
+	if !ok {
+		t.Fatal("pool config has no connect tracer")
+	}
+	acquireTracer, ok := poolConfig.ConnConfig.Tracer.(pgxpool.AcquireTracer)
+	if !ok {
+		t.Fatal("pool config has no acquire tracer")
+	}
+
+	ctx := connectTracer.TraceConnectStart(context.Background(), pgx.TraceConnectStartData{ConnConfig: poolConfig.ConnConfig})
+	connectTracer.TraceConnectEnd(ctx, pgx.TraceConnectEndData{Conn: &pgx.Conn{}})
+
+	ctx = acquireTracer.TraceAcquireStart(context.Background(), nil, pgxpool.TraceAcquireStartData{})
+	acquireTracer.TraceAcquireEnd(ctx, nil, pgxpool.TraceAcquireEndData{Conn: &pgx.Conn{}})
+
+	ctx = acquireTracer.TraceAcquireStart(context.Background(), nil, pgxpool.TraceAcquireStartData{})
+	acquireTracer.TraceAcquireEnd(ctx, nil, pgxpool.TraceAcquireEndData{Err: errors.New("pool closed")})
+
+	return logger
+}
+
+func loggedEvents(logger *mockLogger) []string {
+	var events []string
+	for _, m := range logger.messages {
+		events = append(events, m.level+" "+m.msg)
+	}
+	return events
+}
+
+func TestTraceLog_ReceivesDriverEvents(t *testing.T) {
+	cfg := *defaultConfig()
+	cfg.LogLevel = "debug"
+	logger := traceEvents(t, cfg)
+
+	want := []string{"info pgx connect", "debug pgx acquire", "error pgx acquire"}
+	if got := loggedEvents(logger); !reflect.DeepEqual(got, want) {
+		t.Fatalf("logged events = %v, want %v", got, want)
+	}
+	connect := logger.messages[0].fields
+	if len(connect) != 8 || connect[0] != "database" || connect[1] != "testdb" || connect[2] != "host" {
+		t.Errorf("connect fields = %v, want sorted key-value pairs", connect)
+	}
+}
+
+func TestTraceLog_OmitsQueryArgs(t *testing.T) {
+	logger := &mockLogger{}
+	cfg := *defaultConfig()
+	cfg.User, cfg.Password, cfg.Database = "testuser", "testpass", "testdb"
+	cfg.Logger = logger
+
+	poolConfig, err := newPoolConfig(cfg)
+	if err != nil {
+		t.Fatalf("newPoolConfig() error = %v", err)
+	}
+	queryTracer, ok := poolConfig.ConnConfig.Tracer.(pgx.QueryTracer)
+	if !ok {
+		t.Fatal("pool config has no query tracer")
+	}
+
+	sql := "INSERT INTO users (email, password_hash) VALUES ($1, $2)"
+	ctx := queryTracer.TraceQueryStart(context.Background(), &pgx.Conn{}, pgx.TraceQueryStartData{
+		SQL:  sql,
+		Args: []any{"user@example.com", "secret-hash"},
+	})
+	queryTracer.TraceQueryEnd(ctx, &pgx.Conn{}, pgx.TraceQueryEndData{Err: errors.New("unique violation")})
+
+	if got := loggedEvents(logger); !reflect.DeepEqual(got, []string{"error pgx query"}) {
+		t.Fatalf("logged events = %v, want the failed query", got)
+	}
+	fields := map[any]any{}
+	for i := 0; i+1 < len(logger.messages[0].fields); i += 2 {
+		fields[logger.messages[0].fields[i]] = logger.messages[0].fields[i+1]
+	}
+	if fields["sql"] != sql {
+		t.Errorf("sql field = %v, want %q", fields["sql"], sql)
+	}
+	if _, ok := fields["args"]; ok {
+		t.Errorf("query event logged args: %v", fields["args"])
+	}
+}
+
+func TestTraceLog_LevelFilter(t *testing.T) {
+	tests := []struct {
+		level string
+		want  []string
+	}{
+		{level: "info", want: []string{"info pgx connect", "error pgx acquire"}},
+		{level: "", want: []string{"error pgx acquire"}},
+		{level: "none", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			cfg := *defaultConfig()
+			cfg.LogLevel = tt.level
+			if got := loggedEvents(traceEvents(t, cfg)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("logged events = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPoolConfig_WithoutLoggerHasNoTracer(t *testing.T) {
+	cfg := *defaultConfig()
+	cfg.User, cfg.Password, cfg.Database = "testuser", "testpass", "testdb"
+
+	poolConfig, err := newPoolConfig(cfg)
+	if err != nil {
+		t.Fatalf("newPoolConfig() error = %v", err)
+	}
+	if poolConfig.ConnConfig.Tracer != nil {
+		t.Errorf("Tracer = %T, want none without Config.Logger", poolConfig.ConnConfig.Tracer)
+	}
+}
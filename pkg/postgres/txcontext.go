@@ -0,0 +1,31 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// txContextKey is the context key under which WithTx stores a transaction.
+type txContextKey struct{}
+
+// WithTx returns a copy of ctx that carries tx. Query, QueryRow, Exec, and CopyFrom called with the
+// returned context run inside tx instead of on the pool, so repository methods built on a Client
+// join the caller's transaction without taking a pgx.Tx parameter.
+//
+//	err := client.Transaction(ctx, func(tx pgx.Tx) error {
+//		ctx := postgres.WithTx(ctx, tx)
+//		if err := users.Create(ctx, user); err != nil {
+//			return err
+//		}
+//		return audit.Record(ctx, "user.created", user.ID)
+//	})
+func WithTx(ctx context.Context, tx pgx.Tx) context.Context {
+	return context.WithValue(ctx, txContextKey{}, tx)
+}
+
+// TxFromContext returns the transaction stored in ctx by WithTx, if any.
+func TxFromContext(ctx context.Context) (pgx.Tx, bool) {
+	tx, ok := ctx.Value(txContextKey{}).(pgx.Tx)
+	return tx, ok && tx != nil
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// userRepo is a repository that is unaware of transactions.
+type userRepo struct {
+	client *Client
+}
+
+func (r *userRepo) Create(ctx context.Context, name string) error {
+	_, err := r.client.Exec(ctx, "INSERT INTO users (name) VALUES ($1)", name)
+	return err
+}
+
+func TestTxFromContext(t *testing.T) {
+	ctx := context.Background()
+	if _, ok := TxFromContext(ctx); ok {
+		t.Fatal("TxFromContext() on a plain context should report no transaction")
+	}
+
+	tx := &fakeTx{table: &fakeTable{}}
+	got, ok := TxFromContext(WithTx(ctx, tx))
+	if !ok || got != tx {
+		t.Fatalf("TxFromContext() = %v, %v, want the stored transaction", got, ok)
+	}
+}
+
+func TestClient_UsesTransactionFromContext(t *testing.T) {
+	client, primary, replicas := newRoutingClient()
+	repo := &userRepo{client: client}
+	ctx := context.Background()
+	table := &fakeTable{}
+
+	if err := repo.Create(WithTx(ctx, &fakeTx{table: table}), "alice"); err != nil {
+		t.Fatalf("Create() inside transaction error = %v", err)
+	}
+	if !reflect.DeepEqual(table.rows, []any{"alice"}) {
+		t.Errorf("transaction rows = %v, want [alice]", table.rows)
+	}
+	if len(primary.calls) != 0 {
+		t.Errorf("primary calls = %v, want none inside the transaction", primary.calls)
+	}
+
+	if err := repo.Create(ctx, "bob"); err != nil {
+		t.Fatalf("Create() outside transaction error = %v", err)
+	}
+	if len(primary.calls) != 1 {
+		t.Errorf("primary calls = %v, want the insert outside the transaction", primary.calls)
+	}
+	for _, replica := range replicas {
+		if len(replica.calls) != 0 {
+			t.Errorf("%s received %v, want no calls", replica.name, replica.calls)
+		}
+	}
+}
+
+func TestClient_TransactionJoinsContextTransaction(t *testing.T) {
+	client, primary, _ := newRoutingClient()
+	repo := &userRepo{client: client}
+	table := &fakeTable{}
+	root := &fakeTx{table: table}
+	ctx := WithTx(context.Background(), root)
+
+	errProfile := errors.New("create profile failed")
+	err := client.Transaction(ctx, func(tx pgx.Tx) error {
+		ctx := WithTx(ctx, tx)
+		if err := repo.Create(ctx, "alice"); err != nil {
+			return err
+		}
+		return errProfile
+	})
+	if !errors.Is(err, errProfile) {
+		t.Fatalf("Transaction() error = %v, want %v", err, errProfile)
+	}
+	if len(table.rows) != 0 {
+		t.Errorf("rows after rollback = %v, want the repository insert rolled back", table.rows)
+	}
+
+	err = client.Transaction(ctx, func(tx pgx.Tx) error {
+		return repo.Create(WithTx(ctx, tx), "bob")
+	})
+	if err != nil {
+		t.Fatalf("Transaction() error = %v", err)
+	}
+	if len(table.committed) != 0 {
+		t.Errorf("committed = %v before the outer transaction commits, want none", table.committed)
+	}
+	if err := root.Commit(context.Background()); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	if !reflect.DeepEqual(table.committed, []any{"bob"}) {
+		t.Errorf("committed = %v, want [bob]", table.committed)
+	}
+	if len(primary.calls) != 0 {
+		t.Errorf("primary calls = %v, want every statement inside the transaction", primary.calls)
+	}
+}
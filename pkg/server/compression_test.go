@@ -0,0 +1,106 @@
+package server
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestServer_Compression(t *testing.T) {
+	items := make([]map[string]string, 200)
+	for i := range items {
+		items[i] = map[string]string{"name": "item", "description": "a reasonably repetitive description"}
+	}
+	payload, err := json.Marshal(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := newTestServer(t)
+	s.HandleFunc("/large", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(payload)
+	})
+	s.HandleFunc("/small", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true}`))
+	})
+	s.HandleFunc("/image", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(payload)
+	})
+
+	t.Run("large JSON is gzipped", func(t *testing.T) {
+		rec := serve(s, http.MethodGet, "/large", http.Header{"Accept-Encoding": {"gzip, deflate"}})
+
+		if got := rec.Header().Get("Content-Encoding"); got != "gzip" {
+			t.Fatalf("Content-Encoding = %q, want gzip", got)
+		}
+		if got := rec.Header().Get("Vary"); got != "Accept-Encoding" {
+			t.Errorf("Vary = %q, want Accept-Encoding", got)
+		}
+		if got := rec.Header().Get("Content-Type"); got != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", got)
+		}
+		if rec.Body.Len() >= len(payload) {
+			t.Errorf("compressed size %d not smaller than %d", rec.Body.Len(), len(payload))
+		}
+
+		zr, err := gzip.NewReader(rec.Body)
+		if err != nil {
+			t.Fatalf("gzip.NewReader() error = %v", err)
+		}
+		body, err := io.ReadAll(zr)
+		if err != nil {
+			t.Fatalf("read gzip body: %v", err)
+		}
+		if string(body) != string(payload) {
+			t.Error("decompressed body does not match payload")
+		}
+	})
+
+	tests := []struct {
+		name   string
+		path   string
+		accept string
+	}{
+		{"not accepted", "/large", ""},
+		{"gzip refused", "/large", "gzip;q=0, identity"},
+		{"below minimum size", "/small", "gzip"},
+		{"already compressed type", "/image", "gzip"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.accept != "" {
+				header.Set("Accept-Encoding", tt.accept)
+			}
+			rec := serve(s, http.MethodGet, tt.path, header)
+
+			if got := rec.Header().Get("Content-Encoding"); got != "" {
+				t.Errorf("Content-Encoding = %q, want none", got)
+			}
+			if rec.Code != http.StatusOK {
+				t.Errorf("status = %d, want 200", rec.Code)
+			}
+			if tt.path == "/large" && rec.Body.String() != string(payload) {
+				t.Error("plain body does not match payload")
+			}
+		})
+	}
+}
+
+func TestServer_CompressionDisabled(t *testing.T) {
+	s := newTestServer(t, WithCompression(false))
+	s.HandleFunc("/large", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("a", 4096)))
+	})
+
+	rec := serve(s, http.MethodGet, "/large", http.Header{"Accept-Encoding": {"gzip"}})
+	if got := rec.Header().Get("Content-Encoding"); got != "" {
+		t.Errorf("Content-Encoding = %q, want none", got)
+	}
+}
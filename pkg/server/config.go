@@ -23,11 +23,16 @@ type Config struct {
 
 	// Shutdown
 	ShutdownTimeout time.Duration
+	// ShutdownDrainDelay is how long Shutdown waits after flipping readiness to not-ready
+	// before closing listeners, giving load balancers time to deregister the instance.
+	ShutdownDrainDelay time.Duration
 
 	// TLS (applies to both gRPC and HTTP)
 	TLSEnabled  bool
 	TLSCertFile string
 	TLSKeyFile  string
+	// tlsConfigured is set by WithTLSConfig, which supplies certificates in place of the files.
+	tlsConfigured bool
 
 	// Health Checks
 	HealthEnabled     bool
@@ -49,6 +54,8 @@ type Config struct {
 	RateLimitRate    float64
 	RateLimitBurst   int
 	RateLimitExpiry  time.Duration
+	// RateLimitTrustedProxies lists proxy IPs or CIDR ranges whose X-Forwarded-For is believed
+	RateLimitTrustedProxies []string
 
 	// Compression (HTTP only)
 	CompressionEnabled bool
@@ -80,7 +87,8 @@ func DefaultConfig() *Config {
 		HTTPIdleTimeout:  120 * time.Second,
 
 		// Shutdown
-		ShutdownTimeout: 30 * time.Second,
+		ShutdownTimeout:    30 * time.Second,
+		ShutdownDrainDelay: 0,
 
 		// TLS
 		TLSEnabled:  false,
@@ -141,6 +149,7 @@ func LoadConfig() (*Config, error) {
 
 	// Shutdown
 	cfg.ShutdownTimeout = getEnvDuration("SHUTDOWN_TIMEOUT", cfg.ShutdownTimeout)
+	cfg.ShutdownDrainDelay = getEnvDuration("SHUTDOWN_DRAIN_DELAY", cfg.ShutdownDrainDelay)
 
 	// TLS
 	cfg.TLSEnabled = getEnvBool("TLS_ENABLED", cfg.TLSEnabled)
@@ -167,6 +176,7 @@ func LoadConfig() (*Config, error) {
 	cfg.RateLimitRate = getEnvFloat64("RATE_LIMIT_RATE", cfg.RateLimitRate)
 	cfg.RateLimitBurst = getEnvInt("RATE_LIMIT_BURST", cfg.RateLimitBurst)
 	cfg.RateLimitExpiry = getEnvDuration("RATE_LIMIT_EXPIRY", cfg.RateLimitExpiry)
+	cfg.RateLimitTrustedProxies = getEnvStringSlice("RATE_LIMIT_TRUSTED_PROXIES", cfg.RateLimitTrustedProxies)
 
 	// Compression
 	cfg.CompressionEnabled = getEnvBool("COMPRESSION_ENABLED", cfg.CompressionEnabled)
@@ -199,7 +209,11 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid HTTP port: %d", c.HTTPPort)
 	}
 
-	if c.TLSEnabled {
+	if c.ShutdownDrainDelay < 0 {
+		return fmt.Errorf("shutdown drain delay must not be negative")
+	}
+
+	if c.TLSEnabled && !c.tlsConfigured {
 		if c.TLSCertFile == "" {
 			return fmt.Errorf("TLS cert file is required when TLS is enabled")
 		}
@@ -215,6 +229,9 @@ func (c *Config) Validate() error {
 		if c.RateLimitBurst <= 0 {
 			return fmt.Errorf("rate limit burst must be positive")
 		}
+		if _, err := parseTrustedProxies(c.RateLimitTrustedProxies); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -1,9 +1,7 @@
 package gateway
 
 import (
-	"net/http"
-	"strconv"
-	"strings"
+	"github.com/rompi/core-backend/pkg/server/internal/middleware"
 )
 
 // CORSConfig configures the CORS middleware.
@@ -37,66 +35,17 @@ func DefaultCORSConfig() CORSConfig {
 	}
 }
 
-// CORSMiddleware creates CORS middleware with the given config.
+// CORSMiddleware creates CORS middleware with the given config. It is the same implementation the
+// server installs when CORSEnabled is set.
 func CORSMiddleware(config CORSConfig) Middleware {
-	// Pre-compute header values
-	allowMethods := strings.Join(config.AllowMethods, ", ")
-	allowHeaders := strings.Join(config.AllowHeaders, ", ")
-	exposeHeaders := strings.Join(config.ExposeHeaders, ", ")
-	maxAge := strconv.Itoa(config.MaxAge)
-
-	// Build origin checker
-	allowAllOrigins := len(config.AllowOrigins) == 1 && config.AllowOrigins[0] == "*"
-	originMap := make(map[string]bool)
-	for _, o := range config.AllowOrigins {
-		originMap[o] = true
-	}
-
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			origin := r.Header.Get("Origin")
-
-			// Check if origin is allowed
-			var allowedOrigin string
-			if allowAllOrigins {
-				if config.AllowCredentials {
-					allowedOrigin = origin
-				} else {
-					allowedOrigin = "*"
-				}
-			} else if originMap[origin] {
-				allowedOrigin = origin
-			}
-
-			if allowedOrigin != "" {
-				w.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
-
-				if config.AllowCredentials {
-					w.Header().Set("Access-Control-Allow-Credentials", "true")
-				}
-
-				if exposeHeaders != "" {
-					w.Header().Set("Access-Control-Expose-Headers", exposeHeaders)
-				}
-			}
-
-			// Handle preflight request
-			if r.Method == http.MethodOptions {
-				if allowedOrigin != "" {
-					w.Header().Set("Access-Control-Allow-Methods", allowMethods)
-					w.Header().Set("Access-Control-Allow-Headers", allowHeaders)
-					if config.MaxAge > 0 {
-						w.Header().Set("Access-Control-Max-Age", maxAge)
-					}
-				}
-				w.Header().Set("Content-Length", "0")
-				w.WriteHeader(http.StatusNoContent)
-				return
-			}
-
-			next.ServeHTTP(w, r)
-		})
-	}
+	return middleware.CORS(middleware.CORSConfig{
+		AllowOrigins:     config.AllowOrigins,
+		AllowMethods:     config.AllowMethods,
+		AllowHeaders:     config.AllowHeaders,
+		ExposeHeaders:    config.ExposeHeaders,
+		AllowCredentials: config.AllowCredentials,
+		MaxAge:           config.MaxAge,
+	})
 }
 
 // CORSWithOrigins creates a simple CORS middleware with specified origins.
@@ -3,12 +3,10 @@ package gateway
 import (
 	"net"
 	"net/http"
-	"strconv"
 	"strings"
-	"sync"
 	"time"
 
-	"golang.org/x/time/rate"
+	"github.com/rompi/core-backend/pkg/server/internal/ratelimit"
 )
 
 // RateLimitConfig defines HTTP rate limiting configuration.
@@ -41,123 +39,23 @@ func DefaultRateLimitConfig() RateLimitConfig {
 	}
 }
 
-// rateLimiterStore stores rate limiters per key.
-type httpRateLimiterStore struct {
-	limiters map[string]*httpRateLimiterEntry
-	mu       sync.RWMutex
-	rate     rate.Limit
-	burst    int
-	expiry   time.Duration
-}
-
-type httpRateLimiterEntry struct {
-	limiter  *rate.Limiter
-	lastSeen time.Time
-}
-
-func newHTTPRateLimiterStore(r float64, burst int, expiry time.Duration) *httpRateLimiterStore {
-	store := &httpRateLimiterStore{
-		limiters: make(map[string]*httpRateLimiterEntry),
-		rate:     rate.Limit(r),
-		burst:    burst,
-		expiry:   expiry,
-	}
-
-	// Start cleanup goroutine
-	go store.cleanup()
-
-	return store
-}
-
-func (s *httpRateLimiterStore) getLimiter(key string) *rate.Limiter {
-	s.mu.RLock()
-	entry, exists := s.limiters[key]
-	s.mu.RUnlock()
-
-	if exists {
-		entry.lastSeen = time.Now()
-		return entry.limiter
-	}
-
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	// Double-check after acquiring write lock
-	if entry, exists := s.limiters[key]; exists {
-		entry.lastSeen = time.Now()
-		return entry.limiter
-	}
-
-	limiter := rate.NewLimiter(s.rate, s.burst)
-	s.limiters[key] = &httpRateLimiterEntry{
-		limiter:  limiter,
-		lastSeen: time.Now(),
-	}
-
-	return limiter
-}
-
-func (s *httpRateLimiterStore) cleanup() {
-	ticker := time.NewTicker(time.Minute)
-	defer ticker.Stop()
-
-	for range ticker.C {
-		s.mu.Lock()
-		for key, entry := range s.limiters {
-			if time.Since(entry.lastSeen) > s.expiry {
-				delete(s.limiters, key)
-			}
-		}
-		s.mu.Unlock()
-	}
-}
+// rateLimitExpiry is how long an idle client's bucket is kept.
+const rateLimitExpiry = 3 * time.Minute
 
 // RateLimitMiddleware creates rate limiting middleware.
 func RateLimitMiddleware(config RateLimitConfig) Middleware {
-	store := newHTTPRateLimiterStore(config.Rate, config.Burst, 3*time.Minute)
-
 	keyFunc := config.KeyFunc
 	if keyFunc == nil {
 		keyFunc = defaultHTTPKeyFunc
 	}
 
-	exceededHandler := config.ExceededHandler
-	if exceededHandler == nil {
-		exceededHandler = func(w http.ResponseWriter, r *http.Request) {
-			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
-		}
-	}
-
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Check if we should skip
-			if config.SkipFunc != nil && config.SkipFunc(r) {
-				next.ServeHTTP(w, r)
-				return
-			}
-
-			key := keyFunc(r)
-			limiter := store.getLimiter(key)
-
-			if !limiter.Allow() {
-				if config.Headers {
-					w.Header().Set("X-RateLimit-Limit", strconv.FormatFloat(config.Rate, 'f', -1, 64))
-					w.Header().Set("X-RateLimit-Remaining", "0")
-					w.Header().Set("Retry-After", "1")
-				}
-				exceededHandler(w, r)
-				return
-			}
-
-			if config.Headers {
-				w.Header().Set("X-RateLimit-Limit", strconv.FormatFloat(config.Rate, 'f', -1, 64))
-				// Note: This is an approximation
-				w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(int(limiter.Tokens())))
-			}
-
-			next.ServeHTTP(w, r)
-		})
-	}
+	return ratelimit.Middleware(ratelimit.HTTPConfig{
+		Limiter:         ratelimit.New(config.Rate, config.Burst, rateLimitExpiry),
+		KeyFunc:         keyFunc,
+		SkipFunc:        config.SkipFunc,
+		ExceededHandler: config.ExceededHandler,
+		Headers:         config.Headers,
+	})
 }
 
 // RateLimitWithRate creates a simple rate limiter.
@@ -198,48 +96,25 @@ type PerPathRateLimits map[string]RateLimitConfig
 // RateLimitPerPath creates middleware with per-path limits.
 // Key format: "METHOD /path" (e.g., "POST /api/v1/login")
 func RateLimitPerPath(limits PerPathRateLimits, defaultConfig RateLimitConfig) Middleware {
-	stores := make(map[string]*httpRateLimiterStore)
+	middlewares := make(map[string]Middleware, len(limits))
 	for path, cfg := range limits {
-		stores[path] = newHTTPRateLimiterStore(cfg.Rate, cfg.Burst, 3*time.Minute)
+		middlewares[path] = RateLimitMiddleware(cfg)
 	}
-
-	defaultStore := newHTTPRateLimiterStore(defaultConfig.Rate, defaultConfig.Burst, 3*time.Minute)
+	defaultMiddleware := RateLimitMiddleware(defaultConfig)
 
 	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			pathKey := r.Method + " " + r.URL.Path
-
-			var store *httpRateLimiterStore
-			var config RateLimitConfig
-
-			if s, ok := stores[pathKey]; ok {
-				store = s
-				config = limits[pathKey]
-			} else {
-				store = defaultStore
-				config = defaultConfig
-			}
-
-			// Check if we should skip
-			if config.SkipFunc != nil && config.SkipFunc(r) {
-				next.ServeHTTP(w, r)
-				return
-			}
-
-			keyFunc := config.KeyFunc
-			if keyFunc == nil {
-				keyFunc = defaultHTTPKeyFunc
-			}
-
-			key := keyFunc(r)
-			limiter := store.getLimiter(key)
+		handlers := make(map[string]http.Handler, len(middlewares))
+		for path, mw := range middlewares {
+			handlers[path] = mw(next)
+		}
+		defaultHandler := defaultMiddleware(next)
 
-			if !limiter.Allow() {
-				http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if h, ok := handlers[r.Method+" "+r.URL.Path]; ok {
+				h.ServeHTTP(w, r)
 				return
 			}
-
-			next.ServeHTTP(w, r)
+			defaultHandler.ServeHTTP(w, r)
 		})
 	}
 }
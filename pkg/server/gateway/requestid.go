@@ -4,13 +4,15 @@ import (
 	"context"
 	"net/http"
 
-	"github.com/google/uuid"
+	"github.com/rompi/core-backend/pkg/server"
+	"github.com/rompi/core-backend/pkg/server/internal/middleware"
 )
 
 // RequestIDHeader is the default header name for request ID.
 const RequestIDHeader = "X-Request-ID"
 
-type requestIDKey struct{}
+// requestIDKey is the context key shared with the server's built-in request ID middleware.
+type requestIDKey = middleware.RequestIDKey
 
 // RequestIDMiddleware adds a request ID to the request context.
 // If a request ID is provided in the header, it uses that; otherwise generates a new one.
@@ -19,22 +21,9 @@ func RequestIDMiddleware() Middleware {
 }
 
 // RequestIDMiddlewareWithHeader creates request ID middleware with a custom header name.
+// It is the same implementation the server installs when RequestIDEnabled is set.
 func RequestIDMiddlewareWithHeader(header string) Middleware {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			requestID := r.Header.Get(header)
-			if requestID == "" {
-				requestID = uuid.New().String()
-			}
-
-			// Add to response header
-			w.Header().Set(header, requestID)
-
-			// Add to context
-			ctx := context.WithValue(r.Context(), requestIDKey{}, requestID)
-			next.ServeHTTP(w, r.WithContext(ctx))
-		})
-	}
+	return middleware.RequestID(header)
 }
 
 // GetRequestID extracts request ID from the request context.
@@ -44,8 +33,5 @@ func GetRequestID(r *http.Request) string {
 
 // GetRequestIDFromContext extracts request ID from context.
 func GetRequestIDFromContext(ctx context.Context) string {
-	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
-		return id
-	}
-	return ""
+	return server.RequestIDFromContext(ctx)
 }
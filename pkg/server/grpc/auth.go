@@ -2,96 +2,78 @@ package grpc
 
 import (
 	"context"
-	"strings"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"github.com/rompi/core-backend/pkg/server"
 )
 
+// AuthRequirement describes what a method requires of its caller; see AuthRulesInterceptor.
+// The zero value requires a valid token.
+type AuthRequirement = server.AuthRequirement
+
+// AuthPublic marks a method callable without a token.
+var AuthPublic = server.AuthPublic
+
+// AuthRequired marks a method that needs a valid token but no particular role or permission.
+var AuthRequired = server.AuthRequired
+
+// AuthRules maps full method names ("/pkg.Service/Method") to their requirement.
+type AuthRules map[string]AuthRequirement
+
 // AuthInterceptor creates an authentication interceptor.
 // Requires valid token, returns Unauthenticated error if invalid.
 func AuthInterceptor(auth server.Authenticator) grpc.UnaryServerInterceptor {
-	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		token := extractToken(ctx)
-		if token == "" {
-			return nil, status.Error(codes.Unauthenticated, "missing authentication token")
-		}
-
-		user, err := auth.ValidateToken(ctx, token)
-		if err != nil {
-			return nil, status.Error(codes.Unauthenticated, "invalid authentication token")
-		}
-		if user == nil {
-			return nil, status.Error(codes.Unauthenticated, "invalid authentication token")
-		}
-
-		ctx = server.ContextWithUser(ctx, user)
-		return handler(ctx, req)
-	}
+	return AuthRulesInterceptor(auth, nil, AuthRequired)
 }
 
 // AuthStreamInterceptor creates a streaming auth interceptor.
 func AuthStreamInterceptor(auth server.Authenticator) grpc.StreamServerInterceptor {
-	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		ctx := ss.Context()
-		token := extractToken(ctx)
-		if token == "" {
-			return status.Error(codes.Unauthenticated, "missing authentication token")
-		}
+	return AuthRulesStreamInterceptor(auth, nil, AuthRequired)
+}
 
-		user, err := auth.ValidateToken(ctx, token)
-		if err != nil {
-			return status.Error(codes.Unauthenticated, "invalid authentication token")
-		}
-		if user == nil {
-			return status.Error(codes.Unauthenticated, "invalid authentication token")
-		}
+// OptionalAuthInterceptor sets user if token present but doesn't require it.
+func OptionalAuthInterceptor(auth server.Authenticator) grpc.UnaryServerInterceptor {
+	return AuthRulesInterceptor(auth, nil, AuthPublic)
+}
 
-		ctx = server.ContextWithUser(ctx, user)
-		wrapped := &wrappedServerStream{
-			ServerStream: ss,
-			ctx:          ctx,
-		}
+// OptionalAuthStreamInterceptor sets user if token present but doesn't require it (streaming).
+func OptionalAuthStreamInterceptor(auth server.Authenticator) grpc.StreamServerInterceptor {
+	return AuthRulesStreamInterceptor(auth, nil, AuthPublic)
+}
 
-		return handler(srv, wrapped)
-	}
+// AuthRulesInterceptor enforces per-method auth rules. Methods not listed in rules follow defaultRule.
+// Calls through the gateway are checked too, so list health and reflection methods as AuthPublic
+// if they should stay open. Servers built with server.WithGRPCAuthRules already install it.
+func AuthRulesInterceptor(auth server.Authenticator, rules AuthRules, defaultRule AuthRequirement) grpc.UnaryServerInterceptor {
+	return server.GRPCAuthUnaryInterceptor(auth, rules, defaultRule)
 }
 
-// OptionalAuthInterceptor sets user if token present but doesn't require it.
-func OptionalAuthInterceptor(auth server.Authenticator) grpc.UnaryServerInterceptor {
-	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		token := extractToken(ctx)
-		if token != "" {
-			user, err := auth.ValidateToken(ctx, token)
-			if err == nil && user != nil {
-				ctx = server.ContextWithUser(ctx, user)
-			}
+// AuthRulesStreamInterceptor enforces per-method auth rules for streams.
+func AuthRulesStreamInterceptor(auth server.Authenticator, rules AuthRules, defaultRule AuthRequirement) grpc.StreamServerInterceptor {
+	return server.GRPCAuthStreamInterceptor(auth, rules, defaultRule)
+}
+
+// hasAnyRole reports whether user has any of roles.
+func hasAnyRole(user server.User, roles []string) bool {
+	for _, role := range roles {
+		if user.HasRole(role) {
+			return true
 		}
-		return handler(ctx, req)
 	}
+	return false
 }
 
-// OptionalAuthStreamInterceptor sets user if token present but doesn't require it (streaming).
-func OptionalAuthStreamInterceptor(auth server.Authenticator) grpc.StreamServerInterceptor {
-	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		ctx := ss.Context()
-		token := extractToken(ctx)
-		if token != "" {
-			user, err := auth.ValidateToken(ctx, token)
-			if err == nil && user != nil {
-				ctx = server.ContextWithUser(ctx, user)
-				ss = &wrappedServerStream{
-					ServerStream: ss,
-					ctx:          ctx,
-				}
-			}
+// hasAllPermissions reports whether user has every one of permissions.
+func hasAllPermissions(user server.User, permissions []string) bool {
+	for _, permission := range permissions {
+		if !user.HasPermission(permission) {
+			return false
 		}
-		return handler(srv, ss)
 	}
+	return true
 }
 
 // RequireRoleInterceptor creates an interceptor that requires specific roles.
@@ -103,13 +85,11 @@ func RequireRoleInterceptor(roles ...string) grpc.UnaryServerInterceptor {
 			return nil, status.Error(codes.Unauthenticated, "authentication required")
 		}
 
-		for _, role := range roles {
-			if user.HasRole(role) {
-				return handler(ctx, req)
-			}
+		if !hasAnyRole(user, roles) {
+			return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
 		}
 
-		return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
+		return handler(ctx, req)
 	}
 }
 
@@ -121,13 +101,11 @@ func RequireRoleStreamInterceptor(roles ...string) grpc.StreamServerInterceptor
 			return status.Error(codes.Unauthenticated, "authentication required")
 		}
 
-		for _, role := range roles {
-			if user.HasRole(role) {
-				return handler(srv, ss)
-			}
+		if !hasAnyRole(user, roles) {
+			return status.Error(codes.PermissionDenied, "insufficient permissions")
 		}
 
-		return status.Error(codes.PermissionDenied, "insufficient permissions")
+		return handler(srv, ss)
 	}
 }
 
@@ -140,10 +118,8 @@ func RequirePermissionInterceptor(permissions ...string) grpc.UnaryServerInterce
 			return nil, status.Error(codes.Unauthenticated, "authentication required")
 		}
 
-		for _, permission := range permissions {
-			if !user.HasPermission(permission) {
-				return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
-			}
+		if !hasAllPermissions(user, permissions) {
+			return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
 		}
 
 		return handler(ctx, req)
@@ -158,10 +134,8 @@ func RequirePermissionStreamInterceptor(permissions ...string) grpc.StreamServer
 			return status.Error(codes.Unauthenticated, "authentication required")
 		}
 
-		for _, permission := range permissions {
-			if !user.HasPermission(permission) {
-				return status.Error(codes.PermissionDenied, "insufficient permissions")
-			}
+		if !hasAllPermissions(user, permissions) {
+			return status.Error(codes.PermissionDenied, "insufficient permissions")
 		}
 
 		return handler(srv, ss)
@@ -173,46 +147,11 @@ func GetUser(ctx context.Context) server.User {
 	return server.UserFromContext(ctx)
 }
 
-// extractToken extracts the bearer token from gRPC metadata.
-func extractToken(ctx context.Context) string {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return ""
-	}
-
-	// Try "authorization" header first
-	authHeaders := md.Get("authorization")
-	if len(authHeaders) > 0 {
-		auth := authHeaders[0]
-		// Handle "Bearer <token>" format
-		if strings.HasPrefix(strings.ToLower(auth), "bearer ") {
-			return strings.TrimSpace(auth[7:])
-		}
-		return auth
-	}
-
-	// Try "token" header as fallback
-	tokens := md.Get("token")
-	if len(tokens) > 0 {
-		return tokens[0]
-	}
-
-	return ""
-}
-
 // SkipAuthMethods creates an interceptor that skips auth for specified methods.
 func SkipAuthMethods(auth server.Authenticator, skipMethods ...string) grpc.UnaryServerInterceptor {
-	skipMap := make(map[string]bool)
+	rules := make(AuthRules, len(skipMethods))
 	for _, m := range skipMethods {
-		skipMap[m] = true
-	}
-
-	authInterceptor := AuthInterceptor(auth)
-
-	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		if skipMap[info.FullMethod] {
-			return handler(ctx, req)
-		}
-		return authInterceptor(ctx, req, info, handler)
+		rules[m] = AuthPublic
 	}
+	return AuthRulesInterceptor(auth, rules, AuthRequired)
 }
@@ -2,14 +2,12 @@ package grpc
 
 import (
 	"context"
-	"sync"
 	"time"
 
-	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
-	"google.golang.org/grpc/status"
+
+	"github.com/rompi/core-backend/pkg/server/internal/ratelimit"
 )
 
 // RateLimitConfig defines per-method rate limiting configuration.
@@ -35,129 +33,30 @@ func DefaultRateLimitConfig() RateLimitConfig {
 	}
 }
 
-// rateLimiterStore stores rate limiters per key.
-type rateLimiterStore struct {
-	limiters map[string]*rateLimiterEntry
-	mu       sync.RWMutex
-	rate     rate.Limit
-	burst    int
-	expiry   time.Duration
-}
-
-type rateLimiterEntry struct {
-	limiter  *rate.Limiter
-	lastSeen time.Time
-}
-
-func newRateLimiterStore(r float64, burst int, expiry time.Duration) *rateLimiterStore {
-	store := &rateLimiterStore{
-		limiters: make(map[string]*rateLimiterEntry),
-		rate:     rate.Limit(r),
-		burst:    burst,
-		expiry:   expiry,
-	}
-
-	// Start cleanup goroutine
-	go store.cleanup()
-
-	return store
-}
-
-func (s *rateLimiterStore) getLimiter(key string) *rate.Limiter {
-	s.mu.RLock()
-	entry, exists := s.limiters[key]
-	s.mu.RUnlock()
-
-	if exists {
-		entry.lastSeen = time.Now()
-		return entry.limiter
-	}
-
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	// Double-check after acquiring write lock
-	if entry, exists := s.limiters[key]; exists {
-		entry.lastSeen = time.Now()
-		return entry.limiter
-	}
+// rateLimitExpiry is how long an idle client's bucket is kept.
+const rateLimitExpiry = 3 * time.Minute
 
-	limiter := rate.NewLimiter(s.rate, s.burst)
-	s.limiters[key] = &rateLimiterEntry{
-		limiter:  limiter,
-		lastSeen: time.Now(),
+// rateLimitGRPCConfig builds the shared limiter configuration for config.
+func rateLimitGRPCConfig(config RateLimitConfig) ratelimit.GRPCConfig {
+	keyFunc := config.KeyFunc
+	if keyFunc == nil {
+		keyFunc = defaultKeyFunc
 	}
-
-	return limiter
-}
-
-func (s *rateLimiterStore) cleanup() {
-	ticker := time.NewTicker(time.Minute)
-	defer ticker.Stop()
-
-	for range ticker.C {
-		s.mu.Lock()
-		for key, entry := range s.limiters {
-			if time.Since(entry.lastSeen) > s.expiry {
-				delete(s.limiters, key)
-			}
-		}
-		s.mu.Unlock()
+	return ratelimit.GRPCConfig{
+		Limiter:  ratelimit.New(config.Rate, config.Burst, rateLimitExpiry),
+		KeyFunc:  keyFunc,
+		SkipFunc: config.SkipFunc,
 	}
 }
 
 // RateLimitInterceptor creates a rate limiting interceptor.
 func RateLimitInterceptor(config RateLimitConfig) grpc.UnaryServerInterceptor {
-	store := newRateLimiterStore(config.Rate, config.Burst, 3*time.Minute)
-
-	keyFunc := config.KeyFunc
-	if keyFunc == nil {
-		keyFunc = defaultKeyFunc
-	}
-
-	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		// Check if we should skip
-		if config.SkipFunc != nil && config.SkipFunc(ctx, info.FullMethod) {
-			return handler(ctx, req)
-		}
-
-		key := keyFunc(ctx, info.FullMethod)
-		limiter := store.getLimiter(key)
-
-		if !limiter.Allow() {
-			return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
-		}
-
-		return handler(ctx, req)
-	}
+	return ratelimit.UnaryInterceptor(rateLimitGRPCConfig(config))
 }
 
 // RateLimitStreamInterceptor creates a streaming rate limiting interceptor.
 func RateLimitStreamInterceptor(config RateLimitConfig) grpc.StreamServerInterceptor {
-	store := newRateLimiterStore(config.Rate, config.Burst, 3*time.Minute)
-
-	keyFunc := config.KeyFunc
-	if keyFunc == nil {
-		keyFunc = defaultKeyFunc
-	}
-
-	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		ctx := ss.Context()
-
-		// Check if we should skip
-		if config.SkipFunc != nil && config.SkipFunc(ctx, info.FullMethod) {
-			return handler(srv, ss)
-		}
-
-		key := keyFunc(ctx, info.FullMethod)
-		limiter := store.getLimiter(key)
-
-		if !limiter.Allow() {
-			return status.Error(codes.ResourceExhausted, "rate limit exceeded")
-		}
-
-		return handler(srv, ss)
-	}
+	return ratelimit.StreamInterceptor(rateLimitGRPCConfig(config))
 }
 
 // --- Preset Rate Limiters ---
@@ -189,43 +88,17 @@ type PerMethodRateLimits map[string]RateLimitConfig
 
 // RateLimitPerMethod creates an interceptor with per-method limits.
 func RateLimitPerMethod(limits PerMethodRateLimits, defaultConfig RateLimitConfig) grpc.UnaryServerInterceptor {
-	stores := make(map[string]*rateLimiterStore)
+	interceptors := make(map[string]grpc.UnaryServerInterceptor, len(limits))
 	for method, cfg := range limits {
-		stores[method] = newRateLimiterStore(cfg.Rate, cfg.Burst, 3*time.Minute)
+		interceptors[method] = RateLimitInterceptor(cfg)
 	}
-
-	defaultStore := newRateLimiterStore(defaultConfig.Rate, defaultConfig.Burst, 3*time.Minute)
+	defaultInterceptor := RateLimitInterceptor(defaultConfig)
 
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		var store *rateLimiterStore
-		var config RateLimitConfig
-
-		if s, ok := stores[info.FullMethod]; ok {
-			store = s
-			config = limits[info.FullMethod]
-		} else {
-			store = defaultStore
-			config = defaultConfig
-		}
-
-		// Check if we should skip
-		if config.SkipFunc != nil && config.SkipFunc(ctx, info.FullMethod) {
-			return handler(ctx, req)
-		}
-
-		keyFunc := config.KeyFunc
-		if keyFunc == nil {
-			keyFunc = defaultKeyFunc
+		if interceptor, ok := interceptors[info.FullMethod]; ok {
+			return interceptor(ctx, req, info, handler)
 		}
-
-		key := keyFunc(ctx, info.FullMethod)
-		limiter := store.getLimiter(key)
-
-		if !limiter.Allow() {
-			return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
-		}
-
-		return handler(ctx, req)
+		return defaultInterceptor(ctx, req, info, handler)
 	}
 }
 
@@ -286,39 +286,6 @@ func TestUserKeyFunc(t *testing.T) {
 	})
 }
 
-func TestRateLimiterStore(t *testing.T) {
-	t.Run("creates new limiters", func(t *testing.T) {
-		store := newRateLimiterStore(10, 20, 0)
-
-		limiter := store.getLimiter("key1")
-		if limiter == nil {
-			t.Error("getLimiter should return a limiter")
-		}
-	})
-
-	t.Run("reuses existing limiters", func(t *testing.T) {
-		store := newRateLimiterStore(10, 20, 0)
-
-		limiter1 := store.getLimiter("key1")
-		limiter2 := store.getLimiter("key1")
-
-		if limiter1 != limiter2 {
-			t.Error("should return same limiter for same key")
-		}
-	})
-
-	t.Run("different keys get different limiters", func(t *testing.T) {
-		store := newRateLimiterStore(10, 20, 0)
-
-		limiter1 := store.getLimiter("key1")
-		limiter2 := store.getLimiter("key2")
-
-		if limiter1 == limiter2 {
-			t.Error("different keys should get different limiters")
-		}
-	})
-}
-
 // Helper functions
 
 func contextWithPeer(addr string) context.Context {
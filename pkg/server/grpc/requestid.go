@@ -6,19 +6,23 @@ import (
 	"github.com/google/uuid"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+
+	"github.com/rompi/core-backend/pkg/server"
+	"github.com/rompi/core-backend/pkg/server/internal/middleware"
 )
 
 // RequestIDKey is the metadata key for request ID.
 const RequestIDKey = "x-request-id"
 
-type requestIDKey struct{}
+// requestIDKey is the context key shared with the server's built-in request ID middleware.
+type requestIDKey = middleware.RequestIDKey
 
 // RequestIDInterceptor adds a request ID to context.
 // If a request ID is provided in metadata, it uses that; otherwise generates a new one.
 func RequestIDInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		requestID := extractOrGenerateRequestID(ctx)
-		ctx = context.WithValue(ctx, requestIDKey{}, requestID)
+		ctx = server.ContextWithRequestID(ctx, requestID)
 
 		// Add request ID to outgoing metadata
 		ctx = metadata.AppendToOutgoingContext(ctx, RequestIDKey, requestID)
@@ -37,7 +41,7 @@ func RequestIDStreamInterceptor() grpc.StreamServerInterceptor {
 	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 		ctx := ss.Context()
 		requestID := extractOrGenerateRequestID(ctx)
-		ctx = context.WithValue(ctx, requestIDKey{}, requestID)
+		ctx = server.ContextWithRequestID(ctx, requestID)
 
 		// Set response header
 		if err := grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, requestID)); err != nil {
@@ -55,10 +59,7 @@ func RequestIDStreamInterceptor() grpc.StreamServerInterceptor {
 
 // GetRequestID extracts request ID from context.
 func GetRequestID(ctx context.Context) string {
-	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
-		return id
-	}
-	return ""
+	return middleware.RequestIDFromContext(ctx)
 }
 
 // extractOrGenerateRequestID extracts request ID from metadata or generates a new one.
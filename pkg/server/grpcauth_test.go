@@ -0,0 +1,109 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// tokenAuthenticator accepts the tokens it maps to users.
+type tokenAuthenticator map[string]User
+
+func (a tokenAuthenticator) ValidateToken(ctx context.Context, token string) (User, error) {
+	if user, ok := a[token]; ok {
+		return user, nil
+	}
+	return nil, errors.New("unknown token")
+}
+
+func newGRPCAuthServer(t *testing.T, opts ...Option) *Server {
+	t.Helper()
+	auth := tokenAuthenticator{
+		"user-token":  &DefaultUser{ID: "u1", Role: "user", Permissions: []string{"orders:read"}},
+		"admin-token": &DefaultUser{ID: "a1", Role: "admin"},
+	}
+	return newTestServer(t, append([]Option{
+		WithAuthenticator(auth),
+		WithGRPCAuthRules(map[string]AuthRequirement{
+			"/test.Service/Public": AuthPublic,
+			"/test.Service/Admin":  {Roles: []string{"admin"}},
+			"/test.Service/Orders": {Permissions: []string{"orders:read"}},
+		}),
+	}, opts...)...)
+}
+
+func withToken(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func TestServer_GRPCAuthRules(t *testing.T) {
+	s := newGRPCAuthServer(t)
+	interceptor := s.grpcAuthUnaryInterceptor()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		want   codes.Code
+		userID string
+	}{
+		{"public without token", context.Background(), "/test.Service/Public", codes.OK, ""},
+		{"public with token sets user", withToken("user-token"), "/test.Service/Public", codes.OK, "u1"},
+		{"public with invalid token", withToken("bogus"), "/test.Service/Public", codes.OK, ""},
+		{"protected without token", context.Background(), "/test.Service/Admin", codes.Unauthenticated, ""},
+		{"protected with invalid token", withToken("bogus"), "/test.Service/Admin", codes.Unauthenticated, ""},
+		{"protected without role", withToken("user-token"), "/test.Service/Admin", codes.PermissionDenied, ""},
+		{"protected with role", withToken("admin-token"), "/test.Service/Admin", codes.OK, "a1"},
+		{"permission granted", withToken("user-token"), "/test.Service/Orders", codes.OK, "u1"},
+		{"permission missing", withToken("admin-token"), "/test.Service/Orders", codes.PermissionDenied, ""},
+		{"unlisted requires token by default", context.Background(), "/test.Service/Other", codes.Unauthenticated, ""},
+		{"unlisted with token", withToken("user-token"), "/test.Service/Other", codes.OK, "u1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var userID string
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				if user := UserFromContext(ctx); user != nil {
+					userID = user.GetID()
+				}
+				return "ok", nil
+			}
+			_, err := interceptor(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if code := status.Code(err); code != tt.want {
+				t.Fatalf("code = %v, want %v (err = %v)", code, tt.want, err)
+			}
+			if userID != tt.userID {
+				t.Errorf("user in handler = %q, want %q", userID, tt.userID)
+			}
+		})
+	}
+}
+
+func TestServer_GRPCAuthDefaultPublic(t *testing.T) {
+	s := newGRPCAuthServer(t, WithGRPCAuthDefault(AuthPublic))
+	interceptor := s.grpcAuthUnaryInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	if _, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Other"}, handler); err != nil {
+		t.Fatalf("unlisted method with public default error = %v", err)
+	}
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Admin"}, handler)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("listed protected method code = %v, want Unauthenticated", status.Code(err))
+	}
+}
+
+func TestWithGRPCAuthRules_Validation(t *testing.T) {
+	if _, err := NewServer(WithLogger(NoopLogger{}), WithGRPCAuthRules(map[string]AuthRequirement{"Method": AuthPublic}), WithAuthenticator(NoopAuthenticator{})); err == nil {
+		t.Error("NewServer() with a method that is not a full method name should fail")
+	}
+	if _, err := NewServer(WithLogger(NoopLogger{}), WithGRPCAuthRules(map[string]AuthRequirement{"/test.Service/Public": AuthPublic})); err == nil {
+		t.Error("NewServer() with gRPC auth rules and no authenticator should fail")
+	}
+}
@@ -13,22 +13,48 @@ type CheckerFunc func(ctx context.Context) error
 
 // Checker manages health checks.
 type Checker struct {
-	checks map[string]CheckerFunc
+	checks map[string]*check
 	mu     sync.RWMutex
 }
 
+// check is a registered health check and, when cached, its last result.
+type check struct {
+	fn  CheckerFunc
+	ttl time.Duration
+
+	// mu serializes runs of a cached check so concurrent scrapes share one result.
+	mu     sync.Mutex
+	result *CheckResult
+}
+
+// CheckOption configures a health check added with Add.
+type CheckOption func(*check)
+
+// WithCacheTTL reuses a check's result for ttl after it runs, so frequent scrapes do not hit an
+// expensive dependency, such as a database ping or a downstream HTTP call, on every request.
+func WithCacheTTL(ttl time.Duration) CheckOption {
+	return func(c *check) {
+		c.ttl = ttl
+	}
+}
+
 // NewChecker creates a new health checker.
 func NewChecker() *Checker {
 	return &Checker{
-		checks: make(map[string]CheckerFunc),
+		checks: make(map[string]*check),
 	}
 }
 
-// Add adds a named health check.
-func (c *Checker) Add(name string, check CheckerFunc) {
+// Add adds a named health check, replacing any check with the same name.
+func (c *Checker) Add(name string, fn CheckerFunc, opts ...CheckOption) {
+	entry := &check{fn: fn}
+	for _, opt := range opts {
+		opt(entry)
+	}
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.checks[name] = check
+	c.checks[name] = entry
 }
 
 // Remove removes a health check by name.
@@ -38,12 +64,13 @@ func (c *Checker) Remove(name string) {
 	delete(c.checks, name)
 }
 
-// Check runs all health checks and returns the status.
+// Check runs all health checks and returns the status. Checks added with WithCacheTTL report their
+// cached result while it is fresh.
 func (c *Checker) Check(ctx context.Context) *Status {
 	c.mu.RLock()
-	checks := make(map[string]CheckerFunc, len(c.checks))
-	for name, fn := range c.checks {
-		checks[name] = fn
+	checks := make(map[string]*check, len(c.checks))
+	for name, entry := range c.checks {
+		checks[name] = entry
 	}
 	c.mu.RUnlock()
 
@@ -54,24 +81,12 @@ func (c *Checker) Check(ctx context.Context) *Status {
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 
-	for name, fn := range checks {
+	for name, entry := range checks {
 		wg.Add(1)
-		go func(name string, fn CheckerFunc) {
+		go func(name string, entry *check) {
 			defer wg.Done()
 
-			checkStart := time.Now()
-			err := fn(ctx)
-			duration := time.Since(checkStart)
-
-			result := CheckResult{
-				Status:   StatusHealthy,
-				Duration: duration.String(),
-			}
-
-			if err != nil {
-				result.Status = StatusUnhealthy
-				result.Error = err.Error()
-			}
+			result := entry.run(ctx)
 
 			mu.Lock()
 			results[name] = result
@@ -79,7 +94,7 @@ func (c *Checker) Check(ctx context.Context) *Status {
 				status = StatusUnhealthy
 			}
 			mu.Unlock()
-		}(name, fn)
+		}(name, entry)
 	}
 
 	wg.Wait()
@@ -92,6 +107,39 @@ func (c *Checker) Check(ctx context.Context) *Status {
 	}
 }
 
+// run returns the cached result of the check if it is still fresh and runs the check otherwise.
+func (c *check) run(ctx context.Context) CheckResult {
+	if c.ttl <= 0 {
+		return c.execute(ctx)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.result != nil && time.Since(c.result.LastChecked) < c.ttl {
+		return *c.result
+	}
+	result := c.execute(ctx)
+	c.result = &result
+	return result
+}
+
+// execute runs the check function and records its outcome.
+func (c *check) execute(ctx context.Context) CheckResult {
+	checkStart := time.Now()
+	err := c.fn(ctx)
+
+	result := CheckResult{
+		Status:      StatusHealthy,
+		Duration:    time.Since(checkStart).String(),
+		LastChecked: checkStart,
+	}
+	if err != nil {
+		result.Status = StatusUnhealthy
+		result.Error = err.Error()
+	}
+	return result
+}
+
 // IsHealthy returns true if all checks pass.
 func (c *Checker) IsHealthy(ctx context.Context) bool {
 	status := c.Check(ctx)
@@ -108,9 +156,10 @@ type Status struct {
 
 // CheckResult represents the result of a single health check.
 type CheckResult struct {
-	Status   string `json:"status"`
-	Duration string `json:"duration"`
-	Error    string `json:"error,omitempty"`
+	Status      string    `json:"status"`
+	Duration    string    `json:"duration"`
+	Error       string    `json:"error,omitempty"`
+	LastChecked time.Time `json:"last_checked"`
 }
 
 // Health status constants.
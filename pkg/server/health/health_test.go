@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -170,6 +171,63 @@ func TestChecker_Check_Concurrent(t *testing.T) {
 	}
 }
 
+func TestChecker_CacheTTL(t *testing.T) {
+	checker := NewChecker()
+
+	var calls atomic.Int32
+	checker.Add("db", func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	}, WithCacheTTL(50*time.Millisecond))
+
+	first := checker.Check(context.Background())
+	for i := 0; i < 5; i++ {
+		status := checker.Check(context.Background())
+		if !status.Checks["db"].LastChecked.Equal(first.Checks["db"].LastChecked) {
+			t.Error("cached result should keep the time the check last ran")
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("check ran %d times within the TTL, want 1", got)
+	}
+	if first.Checks["db"].LastChecked.IsZero() {
+		t.Error("LastChecked not set")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	checker.Check(context.Background())
+	if got := calls.Load(); got != 2 {
+		t.Errorf("check ran %d times after the TTL expired, want 2", got)
+	}
+}
+
+func TestChecker_CacheTTL_ConcurrentChecks(t *testing.T) {
+	checker := NewChecker()
+
+	var calls atomic.Int32
+	checker.Add("downstream", func(ctx context.Context) error {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return errors.New("unavailable")
+	}, WithCacheTTL(time.Minute))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if status := checker.Check(context.Background()); status.Status != StatusUnhealthy {
+				t.Errorf("Status = %q, want %q", status.Status, StatusUnhealthy)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("check ran %d times for concurrent scrapes, want 1", got)
+	}
+}
+
 func TestChecker_IsHealthy(t *testing.T) {
 	t.Run("healthy", func(t *testing.T) {
 		checker := NewChecker()
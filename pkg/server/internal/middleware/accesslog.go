@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Logger is the subset of server.Logger used for access logs.
+type Logger interface {
+	Debug(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+}
+
+// AccessLogConfig configures AccessLog.
+type AccessLogConfig struct {
+	Logger    Logger
+	SkipPaths []string
+
+	// Debug logs successful requests at debug instead of info level.
+	Debug bool
+
+	// RequestID returns the request ID to include in each line.
+	RequestID func(ctx context.Context) string
+}
+
+// AccessLog logs one line per request, skipping the paths in SkipPaths. Server errors are logged
+// at error level and client errors at warn level.
+func AccessLog(cfg AccessLogConfig) func(http.Handler) http.Handler {
+	skip := make(map[string]bool, len(cfg.SkipPaths))
+	for _, path := range cfg.SkipPaths {
+		skip[path] = true
+	}
+	requestIDFunc := cfg.RequestID
+	if requestIDFunc == nil {
+		requestIDFunc = RequestIDFromContext
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if skip[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			fields := []interface{}{
+				"method", r.Method,
+				"path", r.URL.Path,
+				"status", rec.status,
+				"bytes", rec.bytes,
+				"duration", time.Since(start),
+			}
+			if r.URL.RawQuery != "" {
+				fields = append(fields, "query", r.URL.RawQuery)
+			}
+			if requestID := requestIDFunc(r.Context()); requestID != "" {
+				fields = append(fields, "request_id", requestID)
+			}
+
+			switch {
+			case rec.status >= 500:
+				cfg.Logger.Error("http request", fields...)
+			case rec.status >= 400:
+				cfg.Logger.Warn("http request", fields...)
+			case cfg.Debug:
+				cfg.Logger.Debug("http request", fields...)
+			default:
+				cfg.Logger.Info("http request", fields...)
+			}
+		})
+	}
+}
+
+// statusRecorder captures the status code and body size written through an http.ResponseWriter.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int
+	wroteHeader bool
+}
+
+func (w *statusRecorder) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.status = code
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusRecorder) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
+
+// Unwrap returns the original ResponseWriter for http.ResponseController.
+func (w *statusRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
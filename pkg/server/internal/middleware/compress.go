@@ -0,0 +1,280 @@
+package middleware
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// defaultCompressionMinSize is the smallest response body compressed when CompressionConfig.MinSize is unset.
+// Smaller bodies are sent as-is since the encoding overhead outweighs the savings.
+const defaultCompressionMinSize = 1024
+
+// compressor is implemented by *gzip.Writer and *flate.Writer.
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// CompressionConfig configures response compression.
+type CompressionConfig struct {
+	// Level is the gzip/deflate level; 0 selects the default level.
+	Level int
+
+	// MinSize is the smallest body that is compressed; 0 selects 1024 bytes.
+	MinSize int
+
+	// ContentTypes restricts compression to these media types. If empty, every
+	// type that is not already compressed (images, video, archives, ...) is compressed.
+	ContentTypes []string
+}
+
+// Compression compresses response bodies with gzip or deflate as negotiated by Accept-Encoding.
+func Compression(cfg CompressionConfig) func(http.Handler) http.Handler {
+	level := cfg.Level
+	if level == 0 || level < flate.HuffmanOnly || level > flate.BestCompression {
+		level = flate.DefaultCompression
+	}
+	minSize := cfg.MinSize
+	if minSize <= 0 {
+		minSize = defaultCompressionMinSize
+	}
+	var contentTypes map[string]bool
+	if len(cfg.ContentTypes) > 0 {
+		contentTypes = make(map[string]bool, len(cfg.ContentTypes))
+		for _, ct := range cfg.ContentTypes {
+			contentTypes[strings.ToLower(ct)] = true
+		}
+	}
+
+	pools := map[string]*sync.Pool{
+		"gzip": {New: func() any {
+			w, _ := gzip.NewWriterLevel(io.Discard, level)
+			return w
+		}},
+		"deflate": {New: func() any {
+			w, _ := flate.NewWriter(io.Discard, level)
+			return w
+		}},
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if encoding == "" || r.Method == http.MethodHead || r.Header.Get("Range") != "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressWriter{
+				ResponseWriter: w,
+				encoding:       encoding,
+				pool:           pools[encoding],
+				minSize:        minSize,
+				contentTypes:   contentTypes,
+				status:         http.StatusOK,
+			}
+			defer cw.Close()
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// negotiateEncoding returns the preferred supported encoding in an Accept-Encoding header,
+// or "" when the client accepts neither gzip nor deflate.
+func negotiateEncoding(header string) string {
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		accepted[name] = q > 0
+	}
+
+	switch {
+	case accepted["gzip"]:
+		return "gzip"
+	case accepted["deflate"]:
+		return "deflate"
+	case accepted["*"]:
+		return "gzip"
+	default:
+		return ""
+	}
+}
+
+// isCompressedContentType reports whether bodies of contentType are already compressed.
+func isCompressedContentType(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+
+	switch {
+	case mediaType == "image/svg+xml":
+		return false
+	case strings.HasPrefix(mediaType, "image/"),
+		strings.HasPrefix(mediaType, "video/"),
+		strings.HasPrefix(mediaType, "audio/"):
+		return true
+	}
+
+	switch mediaType {
+	case "application/gzip", "application/x-gzip", "application/zip", "application/zstd",
+		"application/x-bzip2", "application/x-7z-compressed", "application/x-rar-compressed",
+		"font/woff", "font/woff2":
+		return true
+	}
+	return false
+}
+
+// compressWriter buffers the start of the body until it can decide whether to compress,
+// then either streams through an encoder or writes the body unchanged.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding     string
+	pool         *sync.Pool
+	minSize      int
+	contentTypes map[string]bool
+
+	status     int
+	buf        []byte
+	decided    bool
+	compressor compressor
+}
+
+func (w *compressWriter) WriteHeader(code int) {
+	if w.decided {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	// Informational responses are sent immediately and don't affect the body
+	if code >= 100 && code < 200 {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.status = code
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if !w.decided {
+		w.buf = append(w.buf, b...)
+		if len(w.buf) < w.minSize {
+			return len(b), nil
+		}
+		if err := w.start(true); err != nil {
+			return 0, err
+		}
+		return len(b), nil
+	}
+	if w.compressor != nil {
+		return w.compressor.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// start commits the response headers and writes any buffered body.
+func (w *compressWriter) start(compress bool) error {
+	w.decided = true
+	header := w.Header()
+
+	if header.Get("Content-Type") == "" && len(w.buf) > 0 {
+		// Sniff now; otherwise net/http would sniff the compressed bytes
+		header.Set("Content-Type", http.DetectContentType(w.buf))
+	}
+
+	if compress && w.shouldCompress() {
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+		header.Del("Accept-Ranges")
+
+		w.compressor = w.pool.Get().(compressor)
+		w.compressor.Reset(w.ResponseWriter)
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	if w.compressor != nil {
+		_, err := w.compressor.Write(buf)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buf)
+	return err
+}
+
+func (w *compressWriter) shouldCompress() bool {
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	if w.status < http.StatusOK || w.status == http.StatusNoContent || w.status == http.StatusNotModified {
+		return false
+	}
+	contentType := header.Get("Content-Type")
+	if w.contentTypes != nil {
+		mediaType, _, _ := strings.Cut(contentType, ";")
+		return w.contentTypes[strings.ToLower(strings.TrimSpace(mediaType))]
+	}
+	return !isCompressedContentType(contentType)
+}
+
+// Close flushes a buffered small body or finishes the compressed stream.
+func (w *compressWriter) Close() error {
+	if !w.decided {
+		return w.start(false)
+	}
+	if w.compressor == nil {
+		return nil
+	}
+	err := w.compressor.Close()
+	w.compressor.Reset(io.Discard)
+	w.pool.Put(w.compressor)
+	w.compressor = nil
+	return err
+}
+
+// Flush sends buffered data to the client, compressing it when possible, so streaming responses keep working.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		if err := w.start(true); err != nil {
+			return
+		}
+	}
+	if w.compressor != nil {
+		if err := w.compressor.Flush(); err != nil {
+			return
+		}
+	}
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack lets protocol upgrades such as WebSockets bypass compression.
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.decided = true
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+// Unwrap returns the original ResponseWriter for http.ResponseController.
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestCompression_ContentTypes(t *testing.T) {
+	body := strings.Repeat("a", 4096)
+	handler := Compression(CompressionConfig{ContentTypes: []string{"application/json"}})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", r.URL.Query().Get("type"))
+			w.Write([]byte(body))
+		}))
+
+	tests := []struct {
+		contentType string
+		want        string
+	}{
+		{"application/json; charset=utf-8", "gzip"},
+		{"text/plain", ""},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/?type="+url.QueryEscape(tt.contentType), nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if got := rec.Header().Get("Content-Encoding"); got != tt.want {
+			t.Errorf("%s: Content-Encoding = %q, want %q", tt.contentType, got, tt.want)
+		}
+	}
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", ""},
+		{"gzip", "gzip"},
+		{"deflate, gzip;q=0.5", "gzip"},
+		{"deflate", "deflate"},
+		{"gzip;q=0, deflate", "deflate"},
+		{"br", ""},
+		{"*", "gzip"},
+		{"identity", ""},
+	}
+	for _, tt := range tests {
+		if got := negotiateEncoding(tt.header); got != tt.want {
+			t.Errorf("negotiateEncoding(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Package middleware holds the HTTP middleware shared by the server's built-in chain and the
+// gateway package, so both expose the same behavior from one implementation.
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CORSConfig configures CORS.
+type CORSConfig struct {
+	AllowOrigins     []string
+	AllowMethods     []string
+	AllowHeaders     []string
+	ExposeHeaders    []string
+	AllowCredentials bool
+	MaxAge           int
+}
+
+// CORS applies cfg and answers preflight requests directly. An origin of "*" allows any origin;
+// with credentials the request origin is echoed instead, since browsers reject "*" together with credentials.
+func CORS(cfg CORSConfig) func(http.Handler) http.Handler {
+	allowMethods := strings.Join(cfg.AllowMethods, ", ")
+	allowHeaders := strings.Join(cfg.AllowHeaders, ", ")
+	exposeHeaders := strings.Join(cfg.ExposeHeaders, ", ")
+	maxAge := strconv.Itoa(cfg.MaxAge)
+
+	allowAll := false
+	origins := make(map[string]bool, len(cfg.AllowOrigins))
+	for _, origin := range cfg.AllowOrigins {
+		if origin == "*" {
+			allowAll = true
+		}
+		origins[origin] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			var allowedOrigin string
+			switch {
+			case allowAll && !cfg.AllowCredentials:
+				allowedOrigin = "*"
+			case allowAll || origins[origin]:
+				allowedOrigin = origin
+				w.Header().Add("Vary", "Origin")
+			}
+
+			preflight := r.Method == http.MethodOptions
+
+			if allowedOrigin != "" {
+				w.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
+				if cfg.AllowCredentials {
+					w.Header().Set("Access-Control-Allow-Credentials", "true")
+				}
+				if preflight {
+					w.Header().Set("Access-Control-Allow-Methods", allowMethods)
+					w.Header().Set("Access-Control-Allow-Headers", allowHeaders)
+					if cfg.MaxAge > 0 {
+						w.Header().Set("Access-Control-Max-Age", maxAge)
+					}
+				} else if exposeHeaders != "" {
+					w.Header().Set("Access-Control-Expose-Headers", exposeHeaders)
+				}
+			}
+
+			if preflight {
+				w.Header().Set("Content-Length", "0")
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
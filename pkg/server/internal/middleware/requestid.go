@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// RequestIDKey is the context key for the request ID. The server, gateway, and grpc packages all
+// store the ID under it, so each can read an ID set by the others.
+type RequestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the request ID.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, RequestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, or "" if there is none.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(RequestIDKey{}).(string)
+	return id
+}
+
+// RequestID reuses the ID from header or generates one, storing it in the request context and
+// echoing it in the response.
+func RequestID(header string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get(header)
+			if requestID == "" {
+				requestID = uuid.NewString()
+			}
+			w.Header().Set(header, requestID)
+			next.ServeHTTP(w, r.WithContext(ContextWithRequestID(r.Context(), requestID)))
+		})
+	}
+}
@@ -0,0 +1,198 @@
+// Package ratelimit holds the per-client token bucket limiter shared by the server's built-in
+// rate limiting, the gateway middleware and the gRPC interceptors.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Limiter keeps a token bucket per client key and evicts buckets idle for longer than expiry.
+type Limiter struct {
+	rate   rate.Limit
+	burst  int
+	expiry time.Duration
+
+	// Now is the time source; tests replace it to control refills and eviction.
+	Now func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// New returns a limiter refilling r tokens per second up to burst. An expiry of zero keeps idle buckets.
+func New(r float64, burst int, expiry time.Duration) *Limiter {
+	return &Limiter{
+		rate:    rate.Limit(r),
+		burst:   burst,
+		expiry:  expiry,
+		Now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Limit returns the refill rate in tokens per second.
+func (l *Limiter) Limit() float64 {
+	return float64(l.rate)
+}
+
+// Allow takes a token for key. When none is left it reports how long until the next one is available.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	now := l.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	reservation := l.bucket(key, now).ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// Tokens returns the number of tokens currently available to key.
+func (l *Limiter) Tokens(key string) float64 {
+	now := l.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.bucket(key, now).TokensAt(now)
+}
+
+// bucket returns the limiter for key, creating it if needed. l.mu must be held.
+func (l *Limiter) bucket(key string, now time.Time) *rate.Limiter {
+	// Sweep idle buckets on access instead of running a background goroutine
+	if l.expiry > 0 && now.Sub(l.lastSweep) > l.expiry {
+		for k, b := range l.buckets {
+			if now.Sub(b.lastSeen) > l.expiry {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(l.rate, l.burst)}
+		l.buckets[key] = b
+	}
+	b.lastSeen = now
+	return b.limiter
+}
+
+// HTTPConfig configures Middleware.
+type HTTPConfig struct {
+	Limiter *Limiter
+
+	// KeyFunc groups requests into buckets.
+	KeyFunc func(r *http.Request) string
+
+	// SkipFunc, if set, exempts requests from the limit.
+	SkipFunc func(r *http.Request) bool
+
+	// ExceededHandler writes the rejection; defaults to a plain 429.
+	ExceededHandler http.HandlerFunc
+
+	// Headers adds X-RateLimit-Limit and X-RateLimit-Remaining to responses.
+	Headers bool
+}
+
+// Middleware rejects requests over the limit. A Retry-After header is always set on rejections.
+func Middleware(cfg HTTPConfig) func(http.Handler) http.Handler {
+	exceeded := cfg.ExceededHandler
+	if exceeded == nil {
+		exceeded = func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+		}
+	}
+	limit := strconv.FormatFloat(cfg.Limiter.Limit(), 'f', -1, 64)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if cfg.SkipFunc != nil && cfg.SkipFunc(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := cfg.KeyFunc(r)
+			ok, retryAfter := cfg.Limiter.Allow(key)
+			if cfg.Headers {
+				w.Header().Set("X-RateLimit-Limit", limit)
+				remaining := 0
+				if ok {
+					remaining = int(cfg.Limiter.Tokens(key))
+				}
+				w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+			}
+			if !ok {
+				w.Header().Set("Retry-After", strconv.Itoa(RetryAfterSeconds(retryAfter)))
+				exceeded(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RetryAfterSeconds rounds d up to whole seconds, as required by the Retry-After header.
+func RetryAfterSeconds(d time.Duration) int {
+	return int(math.Max(1, math.Ceil(d.Seconds())))
+}
+
+// GRPCConfig configures UnaryInterceptor and StreamInterceptor.
+type GRPCConfig struct {
+	Limiter *Limiter
+
+	// KeyFunc groups calls into buckets.
+	KeyFunc func(ctx context.Context, method string) string
+
+	// SkipFunc, if set, exempts calls from the limit.
+	SkipFunc func(ctx context.Context, method string) bool
+}
+
+// UnaryInterceptor rejects calls over the limit with codes.ResourceExhausted.
+func UnaryInterceptor(cfg GRPCConfig) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := cfg.check(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor rejects streams over the limit with codes.ResourceExhausted.
+func StreamInterceptor(cfg GRPCConfig) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := cfg.check(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (cfg GRPCConfig) check(ctx context.Context, method string) error {
+	if cfg.SkipFunc != nil && cfg.SkipFunc(ctx, method) {
+		return nil
+	}
+	if ok, retryAfter := cfg.Limiter.Allow(cfg.KeyFunc(ctx, method)); !ok {
+		return status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry after %s", retryAfter.Round(time.Millisecond))
+	}
+	return nil
+}
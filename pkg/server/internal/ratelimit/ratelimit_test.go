@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := New(1, 2, 0)
+	l.Now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := l.Allow("a"); !ok {
+			t.Fatalf("call %d denied, want allowed", i+1)
+		}
+	}
+	ok, retryAfter := l.Allow("a")
+	if ok {
+		t.Fatal("call past burst allowed, want denied")
+	}
+	if retryAfter != time.Second {
+		t.Errorf("retry after = %v, want 1s", retryAfter)
+	}
+	if ok, _ := l.Allow("b"); !ok {
+		t.Error("other key denied, want allowed")
+	}
+}
+
+func TestLimiter_EvictsIdleBuckets(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := New(1, 1, time.Minute)
+	l.Now = func() time.Time { return now }
+
+	l.Allow("a")
+	now = now.Add(2 * time.Minute)
+	l.Allow("b")
+
+	if _, ok := l.buckets["a"]; ok {
+		t.Error("idle bucket should be evicted")
+	}
+	if _, ok := l.buckets["b"]; !ok {
+		t.Error("active bucket should be kept")
+	}
+}
+
+func TestRetryAfterSeconds(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want int
+	}{
+		{0, 1},
+		{300 * time.Millisecond, 1},
+		{time.Second, 1},
+		{1500 * time.Millisecond, 2},
+	}
+	for _, tt := range tests {
+		if got := RetryAfterSeconds(tt.d); got != tt.want {
+			t.Errorf("RetryAfterSeconds(%v) = %d, want %d", tt.d, got, tt.want)
+		}
+	}
+}
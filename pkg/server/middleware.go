@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/rompi/core-backend/pkg/server/internal/middleware"
+)
+
+// requestIDMetadataKey is the gRPC metadata key carrying the request ID.
+const requestIDMetadataKey = "x-request-id"
+
+// ContextWithRequestID returns a copy of ctx carrying the request ID.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return middleware.ContextWithRequestID(ctx, requestID)
+}
+
+// RequestIDFromContext returns the request ID set by the server's request ID middleware, or by
+// the gateway and grpc packages' request ID middleware, which share its context key.
+// In gRPC handlers it falls back to the x-request-id incoming metadata forwarded by the gateway.
+func RequestIDFromContext(ctx context.Context) string {
+	if id := middleware.RequestIDFromContext(ctx); id != "" {
+		return id
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(requestIDMetadataKey); len(ids) > 0 {
+			return ids[0]
+		}
+	}
+	return ""
+}
+
+// builtinMiddleware returns the middleware enabled by the config, outermost first.
+// They wrap the middleware added with WithHTTPMiddleware.
+func (s *Server) builtinMiddleware() []Middleware {
+	var chain []Middleware
+	if s.config.RequestIDEnabled {
+		header := s.config.RequestIDHeader
+		if header == "" {
+			header = "X-Request-ID"
+		}
+		chain = append(chain, middleware.RequestID(header))
+	}
+	if s.config.LogRequests {
+		chain = append(chain, middleware.AccessLog(middleware.AccessLogConfig{
+			Logger:    s.logger,
+			SkipPaths: s.config.LogSkipPaths,
+			RequestID: RequestIDFromContext,
+		}))
+	}
+	if s.config.CORSEnabled {
+		chain = append(chain, middleware.CORS(middleware.CORSConfig{
+			AllowOrigins:     s.config.CORSAllowOrigins,
+			AllowMethods:     s.config.CORSAllowMethods,
+			AllowHeaders:     s.config.CORSAllowHeaders,
+			ExposeHeaders:    s.config.CORSExposeHeaders,
+			AllowCredentials: s.config.CORSAllowCredentials,
+			MaxAge:           s.config.CORSMaxAge,
+		}))
+	}
+	if s.rateLimiter != nil {
+		chain = append(chain, s.rateLimitMiddleware())
+	}
+	if s.config.CompressionEnabled {
+		chain = append(chain, middleware.Compression(middleware.CompressionConfig{}))
+	}
+	return chain
+}
+
+// requestIDMetadata forwards the request ID to gRPC services behind the gateway.
+func requestIDMetadata(ctx context.Context, r *http.Request) metadata.MD {
+	if id := RequestIDFromContext(ctx); id != "" {
+		return metadata.Pairs(requestIDMetadataKey, id)
+	}
+	return nil
+}
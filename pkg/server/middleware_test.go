@@ -0,0 +1,287 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func TestServer_CORS(t *testing.T) {
+	s := newTestServer(t, WithCORS("https://app.example.com"))
+	s.HandleFunc("/api/items", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("preflight", func(t *testing.T) {
+		rec := serve(s, http.MethodOptions, "/api/items", http.Header{
+			"Origin":                        {"https://app.example.com"},
+			"Access-Control-Request-Method": {"POST"},
+		})
+
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("status = %d, want 204", rec.Code)
+		}
+		if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://app.example.com" {
+			t.Errorf("Access-Control-Allow-Origin = %q", got)
+		}
+		if got := rec.Header().Get("Access-Control-Allow-Methods"); got != "GET, POST, PUT, PATCH, DELETE, OPTIONS" {
+			t.Errorf("Access-Control-Allow-Methods = %q", got)
+		}
+		if got := rec.Header().Get("Access-Control-Allow-Headers"); got == "" {
+			t.Error("Access-Control-Allow-Headers not set")
+		}
+		if got := rec.Header().Get("Access-Control-Max-Age"); got != "86400" {
+			t.Errorf("Access-Control-Max-Age = %q", got)
+		}
+	})
+
+	t.Run("simple GET", func(t *testing.T) {
+		rec := serve(s, http.MethodGet, "/api/items", http.Header{"Origin": {"https://app.example.com"}})
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("status = %d, want 200", rec.Code)
+		}
+		if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://app.example.com" {
+			t.Errorf("Access-Control-Allow-Origin = %q", got)
+		}
+		if got := rec.Header().Get("Vary"); got != "Origin" {
+			t.Errorf("Vary = %q, want Origin", got)
+		}
+		if rec.Header().Get("Access-Control-Allow-Methods") != "" {
+			t.Error("Access-Control-Allow-Methods should only be set on preflight")
+		}
+	})
+
+	t.Run("disallowed origin", func(t *testing.T) {
+		rec := serve(s, http.MethodGet, "/api/items", http.Header{"Origin": {"https://evil.example.com"}})
+
+		if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+			t.Errorf("Access-Control-Allow-Origin = %q, want empty", got)
+		}
+	})
+}
+
+func TestServer_CORSDisabled(t *testing.T) {
+	s := newTestServer(t)
+	s.HandleFunc("/api/items", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rec := serve(s, http.MethodGet, "/api/items", http.Header{"Origin": {"https://app.example.com"}})
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want empty when CORS is disabled", got)
+	}
+}
+
+func TestServer_RequestID(t *testing.T) {
+	var seen string
+	s := newTestServer(t)
+	s.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestIDFromContext(r.Context())
+	})
+
+	t.Run("preserves existing ID", func(t *testing.T) {
+		rec := serve(s, http.MethodGet, "/echo", http.Header{"X-Request-Id": {"req-123"}})
+
+		if seen != "req-123" {
+			t.Errorf("RequestIDFromContext() = %q, want req-123", seen)
+		}
+		if got := rec.Header().Get("X-Request-ID"); got != "req-123" {
+			t.Errorf("response X-Request-ID = %q, want req-123", got)
+		}
+	})
+
+	t.Run("generates and echoes ID", func(t *testing.T) {
+		rec := serve(s, http.MethodGet, "/echo", nil)
+
+		got := rec.Header().Get("X-Request-ID")
+		if got == "" {
+			t.Fatal("expected generated X-Request-ID in response")
+		}
+		if seen != got {
+			t.Errorf("context ID = %q, response ID = %q", seen, got)
+		}
+	})
+}
+
+func TestServer_RequestIDCustomHeader(t *testing.T) {
+	var seen string
+	s := newTestServer(t, WithRequestIDHeader("X-Correlation-ID"))
+	s.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestIDFromContext(r.Context())
+	})
+
+	rec := serve(s, http.MethodGet, "/echo", http.Header{"X-Correlation-Id": {"corr-1"}})
+	if seen != "corr-1" || rec.Header().Get("X-Correlation-ID") != "corr-1" {
+		t.Errorf("context = %q, response = %q, want corr-1", seen, rec.Header().Get("X-Correlation-ID"))
+	}
+}
+
+func TestRequestIDFromContext_GRPCMetadata(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "grpc-1"))
+	if got := RequestIDFromContext(ctx); got != "grpc-1" {
+		t.Errorf("RequestIDFromContext() = %q, want grpc-1", got)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	md := requestIDMetadata(ContextWithRequestID(context.Background(), "http-1"), req)
+	if ids := md.Get("x-request-id"); len(ids) != 1 || ids[0] != "http-1" {
+		t.Errorf("gateway metadata = %v, want x-request-id=http-1", md)
+	}
+	if RequestIDFromContext(context.Background()) != "" {
+		t.Error("expected empty ID for bare context")
+	}
+}
+
+func TestServer_HandleWithMiddleware(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+	requireAdmin := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, "admin")
+			if r.Header.Get("X-Admin") == "" {
+				http.Error(w, "forbidden", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "handler")
+	})
+
+	s := newTestServer(t, WithHTTPMiddleware(record("global")))
+	s.HandleWithMiddleware("/admin/", ok, requireAdmin)
+	s.Handle("/public", ok)
+
+	tests := []struct {
+		name   string
+		path   string
+		header http.Header
+		status int
+		calls  []string
+	}{
+		{"scoped route rejected", "/admin/users", nil, http.StatusForbidden, []string{"global", "admin"}},
+		{"scoped route allowed", "/admin/users", http.Header{"X-Admin": {"1"}}, http.StatusOK, []string{"global", "admin", "handler"}},
+		{"other route unaffected", "/public", nil, http.StatusOK, []string{"global", "handler"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			rec := serve(s, http.MethodGet, tt.path, tt.header)
+
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if strings.Join(calls, ",") != strings.Join(tt.calls, ",") {
+				t.Errorf("calls = %v, want %v", calls, tt.calls)
+			}
+		})
+	}
+}
+
+// recordingLogger captures log entries for assertions.
+type recordingLogger struct {
+	NoopLogger
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+type logEntry struct {
+	level  string
+	msg    string
+	fields map[string]interface{}
+}
+
+func (l *recordingLogger) record(level, msg string, keysAndValues []interface{}) {
+	fields := make(map[string]interface{})
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		fields[keysAndValues[i].(string)] = keysAndValues[i+1]
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, logEntry{level: level, msg: msg, fields: fields})
+}
+
+func (l *recordingLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.record("info", msg, keysAndValues)
+}
+
+func (l *recordingLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.record("warn", msg, keysAndValues)
+}
+
+func (l *recordingLogger) requests() []logEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var out []logEntry
+	for _, e := range l.entries {
+		if e.msg == "http request" {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func TestServer_AccessLog(t *testing.T) {
+	logger := &recordingLogger{}
+	s := newTestServer(t,
+		WithLogger(logger),
+		WithLogging(true),
+		WithLogSkipPaths("/health/live"),
+	)
+	s.HandleFunc("/hello", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	})
+
+	serve(s, http.MethodGet, "/health/live", nil)
+	if got := logger.requests(); len(got) != 0 {
+		t.Fatalf("skipped path logged: %+v", got)
+	}
+
+	serve(s, http.MethodGet, "/hello", http.Header{"X-Request-Id": {"req-1"}})
+	serve(s, http.MethodGet, "/missing", nil)
+
+	got := logger.requests()
+	if len(got) != 2 {
+		t.Fatalf("logged %d requests, want 2", len(got))
+	}
+
+	hello := got[0]
+	if hello.level != "info" || hello.fields["method"] != http.MethodGet || hello.fields["path"] != "/hello" ||
+		hello.fields["status"] != http.StatusOK || hello.fields["bytes"] != 5 || hello.fields["request_id"] != "req-1" {
+		t.Errorf("unexpected entry for /hello: %+v", hello)
+	}
+	if _, ok := hello.fields["duration"].(time.Duration); !ok {
+		t.Errorf("duration field missing: %+v", hello)
+	}
+
+	notFound := got[1]
+	if notFound.level != "warn" || notFound.fields["status"] != http.StatusNotFound {
+		t.Errorf("unexpected entry for /missing: %+v", notFound)
+	}
+}
+
+func TestServer_AccessLogDisabled(t *testing.T) {
+	logger := &recordingLogger{}
+	s := newTestServer(t, WithLogger(logger), WithLogging(false))
+
+	serve(s, http.MethodGet, "/missing", nil)
+	if got := logger.requests(); len(got) != 0 {
+		t.Errorf("logged %d requests with LogRequests disabled", len(got))
+	}
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"html/template"
+	"net/http"
+	"time"
+)
+
+// DefaultOpenAPIUIPath is where WithOpenAPI serves the Swagger UI page unless WithOpenAPIUIPath changes it.
+const DefaultOpenAPIUIPath = "/docs"
+
+// registerOpenAPIEndpoints serves the OpenAPI document and, unless disabled, the Swagger UI page.
+func (s *Server) registerOpenAPIEndpoints() {
+	s.logger.Debug("registering OpenAPI endpoints", "spec", s.openAPIPath, "ui", s.openAPIUIPath)
+
+	s.httpMux.Handle(s.openAPIPath, openAPISpecHandler(s.openAPISpec))
+	if s.openAPIUIPath != "" {
+		s.httpMux.Handle(s.openAPIUIPath, swaggerUIHandler(s.openAPIPath))
+	}
+}
+
+// openAPISpecHandler serves spec as JSON. Clients revalidate on every use with the ETag, so a
+// redeployed spec is picked up immediately while unchanged specs cost a 304.
+func openAPISpecHandler(spec []byte) http.Handler {
+	sum := sha256.Sum256(spec)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("ETag", etag)
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(spec))
+	})
+}
+
+// swaggerUITemplate loads Swagger UI from a CDN and points it at the spec.
+var swaggerUITemplate = template.Must(template.New("swagger-ui").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>API Documentation</title>
+<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+<script>
+window.onload = function () {
+  window.ui = SwaggerUIBundle({ url: {{.}}, dom_id: "#swagger-ui" });
+};
+</script>
+</body>
+</html>
+`))
+
+// swaggerUIHandler serves a Swagger UI page that loads the spec from specPath.
+func swaggerUIHandler(specPath string) http.Handler {
+	var page bytes.Buffer
+	if err := swaggerUITemplate.Execute(&page, specPath); err != nil {
+		panic(err) // the template and its string input cannot fail
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(page.Bytes()))
+	})
+}
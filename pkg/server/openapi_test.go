@@ -0,0 +1,74 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const testOpenAPISpec = `{"openapi":"3.0.0","info":{"title":"Users","version":"1.0"},"paths":{}}`
+
+func TestServer_OpenAPISpec(t *testing.T) {
+	s := newTestServer(t, WithOpenAPI([]byte(testOpenAPISpec), "/api/openapi.json"))
+
+	rec := serve(s, http.MethodGet, "/api/openapi.json", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", got)
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "no-cache" {
+		t.Errorf("Cache-Control = %q, want no-cache", got)
+	}
+	if rec.Body.String() != testOpenAPISpec {
+		t.Errorf("body = %q, want the spec", rec.Body.String())
+	}
+
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("ETag header missing")
+	}
+	rec = serve(s, http.MethodGet, "/api/openapi.json", http.Header{"If-None-Match": {etag}})
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("conditional request status = %d, want 304", rec.Code)
+	}
+
+	if rec := serve(s, http.MethodPost, "/api/openapi.json", nil); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST status = %d, want 405", rec.Code)
+	}
+}
+
+func TestServer_OpenAPIUI(t *testing.T) {
+	s := newTestServer(t, WithOpenAPI([]byte(testOpenAPISpec), "/api/openapi.json"))
+
+	rec := serve(s, http.MethodGet, DefaultOpenAPIUIPath, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "text/html; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want text/html; charset=utf-8", got)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, "SwaggerUIBundle") || !strings.Contains(body, `"/api/openapi.json"`) {
+		t.Errorf("body does not load Swagger UI with the spec path:\n%s", body)
+	}
+}
+
+func TestServer_OpenAPIUIPath(t *testing.T) {
+	s := newTestServer(t,
+		WithOpenAPIUIPath("/swagger"),
+		WithOpenAPI([]byte(testOpenAPISpec), ""),
+	)
+	if rec := serve(s, http.MethodGet, "/swagger", nil); rec.Code != http.StatusOK {
+		t.Errorf("custom UI path status = %d, want 200", rec.Code)
+	}
+	if rec := serve(s, http.MethodGet, "/openapi.json", nil); rec.Code != http.StatusOK {
+		t.Errorf("default spec path status = %d, want 200", rec.Code)
+	}
+
+	s = newTestServer(t, WithOpenAPI([]byte(testOpenAPISpec), ""), WithOpenAPIUIPath(""))
+	if rec := serve(s, http.MethodGet, DefaultOpenAPIUIPath, nil); rec.Code != http.StatusNotFound {
+		t.Errorf("disabled UI status = %d, want 404", rec.Code)
+	}
+}
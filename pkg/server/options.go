@@ -1,11 +1,19 @@
 package server
 
 import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/rompi/core-backend/pkg/server/health"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 )
 
 // Option is a functional option for configuring the Server.
@@ -75,6 +83,39 @@ func WithGRPCServerOption(opts ...grpc.ServerOption) Option {
 	}
 }
 
+// WithGRPCKeepalive sets the gRPC server keepalive parameters and the policy it enforces on client
+// pings. Raise params.MaxConnectionIdle and params.MaxConnectionAge for long-lived streams, and allow
+// the client keepalive interval in enforcement so such clients are not disconnected.
+func WithGRPCKeepalive(params keepalive.ServerParameters, enforcement keepalive.EnforcementPolicy) Option {
+	return func(s *Server) error {
+		s.grpcServerOptions = append(s.grpcServerOptions,
+			grpc.KeepaliveParams(params),
+			grpc.KeepaliveEnforcementPolicy(enforcement),
+		)
+		return nil
+	}
+}
+
+// WithMaxMessageSize sets the largest message in bytes the gRPC server receives and sends.
+// Zero keeps the gRPC default for that direction. The gateway's connection to the gRPC server
+// is configured with the same limits.
+func WithMaxMessageSize(recv, send int) Option {
+	return func(s *Server) error {
+		if recv < 0 || send < 0 {
+			return errors.New("max message size must not be negative")
+		}
+		if recv > 0 {
+			s.grpcServerOptions = append(s.grpcServerOptions, grpc.MaxRecvMsgSize(recv))
+		}
+		if send > 0 {
+			s.grpcServerOptions = append(s.grpcServerOptions, grpc.MaxSendMsgSize(send))
+		}
+		s.maxRecvMsgSize = recv
+		s.maxSendMsgSize = send
+		return nil
+	}
+}
+
 // --- HTTP/Gateway Options ---
 
 // WithHTTPAddr sets the HTTP server address.
@@ -113,6 +154,35 @@ func WithTLS(certFile, keyFile string) Option {
 	}
 }
 
+// WithTLSConfig enables TLS for both gRPC and HTTP servers using cfg, e.g. with in-memory
+// certificates or a custom GetCertificate. It takes precedence over WithTLS and WithCertReloader.
+// MinVersion defaults to TLS 1.2. The gateway and DialGRPC trust exactly the certificates in cfg,
+// so self-signed ones work; with only GetCertificate they verify against the system roots.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(s *Server) error {
+		s.tlsConfig = cfg
+		s.config.TLSEnabled = true
+		s.config.tlsConfigured = true
+		return nil
+	}
+}
+
+// WithCertReloader enables TLS like WithTLS but re-reads the keypair every interval
+// (default DefaultCertReloadInterval), so rotated certificates are served without a restart.
+// A failed reload is logged and the previous certificate stays in use.
+func WithCertReloader(certFile, keyFile string, interval time.Duration) Option {
+	return func(s *Server) error {
+		if interval <= 0 {
+			interval = DefaultCertReloadInterval
+		}
+		s.config.TLSEnabled = true
+		s.config.TLSCertFile = certFile
+		s.config.TLSKeyFile = keyFile
+		s.certReloadInterval = interval
+		return nil
+	}
+}
+
 // WithCORS enables CORS with the specified origins.
 func WithCORS(origins ...string) Option {
 	return func(s *Server) error {
@@ -124,7 +194,8 @@ func WithCORS(origins ...string) Option {
 	}
 }
 
-// WithRateLimit enables global rate limiting.
+// WithRateLimit enables per-client rate limiting of HTTP requests and gRPC calls.
+// Each client gets a token bucket refilled at rate tokens per second holding up to burst tokens.
 func WithRateLimit(rate float64, burst int) Option {
 	return func(s *Server) error {
 		s.config.RateLimitEnabled = true
@@ -134,6 +205,58 @@ func WithRateLimit(rate float64, burst int) Option {
 	}
 }
 
+// WithRateLimitTrustedProxies sets the proxy IPs or CIDR ranges whose X-Forwarded-For header or
+// metadata is used to identify the client. Forwarded addresses from any other peer are ignored.
+func WithRateLimitTrustedProxies(proxies ...string) Option {
+	return func(s *Server) error {
+		s.config.RateLimitTrustedProxies = proxies
+		return nil
+	}
+}
+
+// WithRateLimitKeyFunc sets how HTTP requests are grouped for rate limiting.
+// Defaults to the client IP address; see WithRateLimitTrustedProxies. nil restores the default.
+func WithRateLimitKeyFunc(fn func(*http.Request) string) Option {
+	return func(s *Server) error {
+		s.rateLimitKeyFunc = fn
+		return nil
+	}
+}
+
+// WithGRPCRateLimitKeyFunc sets how gRPC calls are grouped for rate limiting.
+// Defaults to the client IP address; see WithRateLimitTrustedProxies. nil restores the default.
+func WithGRPCRateLimitKeyFunc(fn func(ctx context.Context, method string) string) Option {
+	return func(s *Server) error {
+		s.grpcRateLimitKeyFunc = fn
+		return nil
+	}
+}
+
+// WithOpenAPI serves spec, an OpenAPI document in JSON such as one generated by protoc-gen-openapiv2,
+// at path (default "/openapi.json") along with a Swagger UI page at DefaultOpenAPIUIPath.
+func WithOpenAPI(spec []byte, path string) Option {
+	return func(s *Server) error {
+		if !json.Valid(spec) {
+			return errors.New("openapi spec is not valid JSON")
+		}
+		if path == "" {
+			path = "/openapi.json"
+		}
+		s.openAPISpec = spec
+		s.openAPIPath = path
+		return nil
+	}
+}
+
+// WithOpenAPIUIPath sets where the Swagger UI page is served. An empty path disables the page.
+// It has no effect without WithOpenAPI.
+func WithOpenAPIUIPath(path string) Option {
+	return func(s *Server) error {
+		s.openAPIUIPath = path
+		return nil
+	}
+}
+
 // WithShutdownTimeout sets the graceful shutdown timeout.
 func WithShutdownTimeout(timeout time.Duration) Option {
 	return func(s *Server) error {
@@ -142,6 +265,14 @@ func WithShutdownTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithShutdownDrainDelay sets how long Shutdown reports not-ready before closing listeners.
+func WithShutdownDrainDelay(delay time.Duration) Option {
+	return func(s *Server) error {
+		s.config.ShutdownDrainDelay = delay
+		return nil
+	}
+}
+
 // WithHealthEnabled enables or disables health checks.
 func WithHealthEnabled(enabled bool) Option {
 	return func(s *Server) error {
@@ -166,6 +297,26 @@ func WithHealthPaths(health, liveness, readiness string) Option {
 	}
 }
 
+// WithHealthCheck adds a named check to the health and readiness endpoints, which report unhealthy
+// with 503 while any check fails. Pass health.WithCacheTTL to reuse results of expensive checks:
+//
+//	server.WithHealthCheck("postgres", health.DatabaseChecker(db), health.WithCacheTTL(5*time.Second))
+func WithHealthCheck(name string, check health.CheckerFunc, opts ...health.CheckOption) Option {
+	return func(s *Server) error {
+		if name == "" {
+			return errors.New("health check name is required")
+		}
+		if check == nil {
+			return fmt.Errorf("health check %q has no check function", name)
+		}
+		if s.healthChecker == nil {
+			s.healthChecker = health.NewChecker()
+		}
+		s.healthChecker.Add(name, check, opts...)
+		return nil
+	}
+}
+
 // WithCompression enables or disables HTTP compression.
 func WithCompression(enabled bool) Option {
 	return func(s *Server) error {
@@ -224,6 +375,38 @@ func WithAuthenticator(auth Authenticator) Option {
 	}
 }
 
+// WithGRPCAuthRules enforces per-method authentication on gRPC calls using the Authenticator.
+// rules maps full method names ("/pkg.Service/Method") to their requirement; methods not listed
+// follow the default policy, which requires a valid token unless changed with WithGRPCAuthDefault.
+// Calls through the gateway are checked too, so list health and reflection methods as AuthPublic
+// if they should stay open. Repeated calls add to the rules.
+func WithGRPCAuthRules(rules map[string]AuthRequirement) Option {
+	return func(s *Server) error {
+		if s.grpcAuthRules == nil {
+			s.grpcAuthRules = make(map[string]AuthRequirement, len(rules))
+		}
+		for method, rule := range rules {
+			if !strings.HasPrefix(method, "/") || strings.Count(method, "/") != 2 {
+				return fmt.Errorf("gRPC auth rule %q is not a full method name like /pkg.Service/Method", method)
+			}
+			s.grpcAuthRules[method] = rule
+		}
+		return nil
+	}
+}
+
+// WithGRPCAuthDefault sets the requirement for gRPC methods not listed in WithGRPCAuthRules and
+// enables per-method authentication. Pass AuthPublic to protect only the listed methods.
+func WithGRPCAuthDefault(rule AuthRequirement) Option {
+	return func(s *Server) error {
+		if s.grpcAuthRules == nil {
+			s.grpcAuthRules = make(map[string]AuthRequirement)
+		}
+		s.grpcAuthDefault = rule
+		return nil
+	}
+}
+
 // --- Shutdown Options ---
 
 // WithShutdownHook adds a shutdown hook to be called during graceful shutdown.
@@ -2,12 +2,17 @@ package server
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/rompi/core-backend/pkg/server/health"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 )
 
 func TestWithConfig(t *testing.T) {
@@ -149,6 +154,91 @@ func TestWithGRPCServerOption(t *testing.T) {
 	}
 }
 
+func TestWithGRPCKeepalive(t *testing.T) {
+	s := &Server{}
+
+	opt := WithGRPCKeepalive(
+		keepalive.ServerParameters{MaxConnectionIdle: time.Hour, Time: time.Minute},
+		keepalive.EnforcementPolicy{MinTime: 30 * time.Second, PermitWithoutStream: true},
+	)
+	if err := opt(s); err != nil {
+		t.Fatalf("WithGRPCKeepalive() error = %v", err)
+	}
+
+	if len(s.grpcServerOptions) != 2 {
+		t.Errorf("grpcServerOptions len = %d, want 2", len(s.grpcServerOptions))
+	}
+}
+
+func TestWithMaxMessageSize(t *testing.T) {
+	tests := []struct {
+		name       string
+		recv, send int
+		wantOpts   int
+		wantErr    bool
+	}{
+		{name: "both limits", recv: 1024, send: 2048, wantOpts: 2},
+		{name: "receive limit only", recv: 1024, wantOpts: 1},
+		{name: "defaults", wantOpts: 0},
+		{name: "negative", recv: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{}
+			err := WithMaxMessageSize(tt.recv, tt.send)(s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WithMaxMessageSize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(s.grpcServerOptions) != tt.wantOpts {
+				t.Errorf("grpcServerOptions len = %d, want %d", len(s.grpcServerOptions), tt.wantOpts)
+			}
+		})
+	}
+}
+
+func TestWithHealthCheck(t *testing.T) {
+	var calls atomic.Int32
+	s := newTestServer(t,
+		WithHealthCheck("postgres", func(ctx context.Context) error {
+			calls.Add(1)
+			return nil
+		}, health.WithCacheTTL(time.Minute)),
+		WithHealthCheck("billing", func(ctx context.Context) error {
+			return errors.New("connection refused")
+		}),
+	)
+
+	for i := 0; i < 3; i++ {
+		rec := serve(s, http.MethodGet, "/health/ready", nil)
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Fatalf("readiness = %d, want 503 with a failing check", rec.Code)
+		}
+
+		var status health.Status
+		if err := json.NewDecoder(rec.Body).Decode(&status); err != nil {
+			t.Fatalf("decode readiness: %v", err)
+		}
+		if got := status.Checks["postgres"]; got.Status != health.StatusHealthy || got.LastChecked.IsZero() {
+			t.Errorf("postgres check = %+v, want healthy with a last-checked time", got)
+		}
+		if got := status.Checks["billing"]; got.Status != health.StatusUnhealthy || got.Error != "connection refused" {
+			t.Errorf("billing check = %+v, want unhealthy with its error", got)
+		}
+	}
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("cached check ran %d times, want 1", got)
+	}
+
+	if err := WithHealthCheck("", func(ctx context.Context) error { return nil })(&Server{}); err == nil {
+		t.Error("WithHealthCheck() without a name should fail")
+	}
+	if err := WithHealthCheck("db", nil)(&Server{}); err == nil {
+		t.Error("WithHealthCheck() without a check function should fail")
+	}
+}
+
 func TestWithHTTPMiddleware(t *testing.T) {
 	s := &Server{}
 
@@ -257,6 +347,41 @@ func TestWithRateLimit(t *testing.T) {
 	}
 }
 
+func TestWithRateLimitKeyFunc(t *testing.T) {
+	s := &Server{}
+
+	opt := WithRateLimitKeyFunc(func(r *http.Request) string { return r.Header.Get("X-API-Key") })
+	if err := opt(s); err != nil {
+		t.Fatalf("WithRateLimitKeyFunc() error = %v", err)
+	}
+	if s.rateLimitKeyFunc == nil {
+		t.Error("rateLimitKeyFunc should be set")
+	}
+
+	opt = WithGRPCRateLimitKeyFunc(func(ctx context.Context, method string) string { return method })
+	if err := opt(s); err != nil {
+		t.Fatalf("WithGRPCRateLimitKeyFunc() error = %v", err)
+	}
+	if s.grpcRateLimitKeyFunc == nil {
+		t.Error("grpcRateLimitKeyFunc should be set")
+	}
+}
+
+func TestWithOpenAPI(t *testing.T) {
+	s := &Server{}
+
+	if err := WithOpenAPI([]byte(`{"openapi":"3.0.0"}`), "")(s); err != nil {
+		t.Fatalf("WithOpenAPI() error = %v", err)
+	}
+	if s.openAPIPath != "/openapi.json" {
+		t.Errorf("openAPIPath = %q, want /openapi.json", s.openAPIPath)
+	}
+
+	if err := WithOpenAPI([]byte("openapi: 3.0.0"), "/openapi.yaml")(s); err == nil {
+		t.Error("WithOpenAPI() should reject a spec that is not JSON")
+	}
+}
+
 func TestWithShutdownTimeout(t *testing.T) {
 	s := &Server{config: DefaultConfig()}
 
@@ -270,6 +395,19 @@ func TestWithShutdownTimeout(t *testing.T) {
 	}
 }
 
+func TestWithShutdownDrainDelay(t *testing.T) {
+	s := &Server{config: DefaultConfig()}
+
+	opt := WithShutdownDrainDelay(5 * time.Second)
+	if err := opt(s); err != nil {
+		t.Fatalf("WithShutdownDrainDelay() error = %v", err)
+	}
+
+	if s.config.ShutdownDrainDelay != 5*time.Second {
+		t.Errorf("ShutdownDrainDelay = %v, want 5s", s.config.ShutdownDrainDelay)
+	}
+}
+
 func TestWithHealthEnabled(t *testing.T) {
 	s := &Server{config: DefaultConfig()}
 
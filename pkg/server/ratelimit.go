@@ -0,0 +1,183 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/rompi/core-backend/pkg/server/internal/ratelimit"
+)
+
+// gatewayTokenMetadataKey carries the per-server token identifying calls from the built-in gateway.
+const gatewayTokenMetadataKey = "x-gateway-token"
+
+// gatewayCredentials attaches the gateway token to every call the gateway forwards, so the gRPC
+// rate limit does not count requests the HTTP middleware already limited.
+type gatewayCredentials struct {
+	token string
+}
+
+func (c gatewayCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{gatewayTokenMetadataKey: c.token}, nil
+}
+
+func (c gatewayCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// parseTrustedProxies parses IP addresses and CIDR ranges.
+func parseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// isTrustedProxy reports whether addr is in RateLimitTrustedProxies.
+func (s *Server) isTrustedProxy(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, ipNet := range s.trustedProxies {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// forwardedClient returns the client address for a connection from remote carrying the given
+// X-Forwarded-For values. The header is only believed when remote is a trusted proxy; the rightmost
+// address that is not itself a trusted proxy is used, since each proxy appends the address it saw.
+func (s *Server) forwardedClient(remote string, forwarded []string) string {
+	if !s.isTrustedProxy(remote) {
+		return remote
+	}
+
+	var hops []string
+	for _, value := range forwarded {
+		for _, hop := range strings.Split(value, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		if !s.isTrustedProxy(hops[i]) {
+			return hops[i]
+		}
+	}
+	if len(hops) > 0 {
+		return hops[0]
+	}
+	return remote
+}
+
+// clientIP returns the request's remote address, or the forwarded client when the request
+// comes from a trusted proxy; see WithRateLimitTrustedProxies.
+func (s *Server) clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return s.forwardedClient(host, r.Header.Values("X-Forwarded-For"))
+}
+
+// rateLimitMiddleware rejects requests over the limit with 429 and a Retry-After header.
+// The health endpoints are never limited so probes keep working under load.
+func (s *Server) rateLimitMiddleware() Middleware {
+	keyFunc := s.rateLimitKeyFunc
+	if keyFunc == nil {
+		keyFunc = s.clientIP
+	}
+	skip := map[string]bool{
+		s.config.HealthHTTPPath:    true,
+		s.config.LivenessHTTPPath:  true,
+		s.config.ReadinessHTTPPath: true,
+	}
+
+	return ratelimit.Middleware(ratelimit.HTTPConfig{
+		Limiter: s.rateLimiter,
+		KeyFunc: keyFunc,
+		SkipFunc: func(r *http.Request) bool {
+			return skip[r.URL.Path]
+		},
+	})
+}
+
+// grpcRateLimitKey returns the peer address of a gRPC call, or the forwarded client when the
+// peer is a trusted proxy.
+func (s *Server) grpcRateLimitKey(ctx context.Context, method string) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	return s.forwardedClient(host, md.Get("x-forwarded-for"))
+}
+
+// fromGateway reports whether a call was forwarded by this server's gateway, whose HTTP request
+// was already rate limited.
+func (s *Server) fromGateway(ctx context.Context, method string) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	for _, token := range md.Get(gatewayTokenMetadataKey) {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.gatewayToken)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) grpcRateLimitConfig() ratelimit.GRPCConfig {
+	keyFunc := s.grpcRateLimitKeyFunc
+	if keyFunc == nil {
+		keyFunc = s.grpcRateLimitKey
+	}
+	return ratelimit.GRPCConfig{
+		Limiter:  s.rateLimiter,
+		KeyFunc:  keyFunc,
+		SkipFunc: s.fromGateway,
+	}
+}
+
+// rateLimitUnaryInterceptor rejects calls over the limit with codes.ResourceExhausted.
+func (s *Server) rateLimitUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return ratelimit.UnaryInterceptor(s.grpcRateLimitConfig())
+}
+
+// rateLimitStreamInterceptor rejects streams over the limit with codes.ResourceExhausted.
+func (s *Server) rateLimitStreamInterceptor() grpc.StreamServerInterceptor {
+	return ratelimit.StreamInterceptor(s.grpcRateLimitConfig())
+}
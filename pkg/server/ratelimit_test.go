@@ -0,0 +1,229 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// fakeClock is a manually advanced time source for the rate limiter.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newRateLimitedServer(t *testing.T, opts ...Option) (*Server, *fakeClock) {
+	t.Helper()
+	s := newTestServer(t, append([]Option{WithRateLimit(1, 3)}, opts...)...)
+	s.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	s.rateLimiter.Now = clock.Now
+	return s, clock
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	s, clock := newRateLimitedServer(t)
+
+	for i := 0; i < 3; i++ {
+		if rec := serve(s, http.MethodGet, "/api", nil); rec.Code != http.StatusOK {
+			t.Fatalf("request %d status = %d, want 200", i+1, rec.Code)
+		}
+	}
+
+	rec := serve(s, http.MethodGet, "/api", nil)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status past burst = %d, want 429", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "1" {
+		t.Errorf("Retry-After = %q, want %q", got, "1")
+	}
+
+	// Rejected requests don't consume tokens, so one refilled token admits exactly one request
+	clock.Advance(time.Second)
+	if rec := serve(s, http.MethodGet, "/api", nil); rec.Code != http.StatusOK {
+		t.Fatalf("status after refill = %d, want 200", rec.Code)
+	}
+	if rec := serve(s, http.MethodGet, "/api", nil); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status after spending refill = %d, want 429", rec.Code)
+	}
+
+	clock.Advance(3 * time.Second)
+	for i := 0; i < 3; i++ {
+		if rec := serve(s, http.MethodGet, "/api", nil); rec.Code != http.StatusOK {
+			t.Fatalf("request %d after full refill status = %d, want 200", i+1, rec.Code)
+		}
+	}
+}
+
+func TestServer_RateLimitPerClient(t *testing.T) {
+	s, _ := newRateLimitedServer(t)
+
+	request := func(remoteAddr string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api", nil)
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	for i := 0; i < 3; i++ {
+		request("10.0.0.1:1234")
+	}
+	if got := request("10.0.0.1:5678"); got != http.StatusTooManyRequests {
+		t.Errorf("same IP, other port status = %d, want 429", got)
+	}
+	if got := request("10.0.0.2:1234"); got != http.StatusOK {
+		t.Errorf("other IP status = %d, want 200", got)
+	}
+}
+
+func TestServer_RateLimitKeyFunc(t *testing.T) {
+	s, _ := newRateLimitedServer(t, WithRateLimitKeyFunc(func(r *http.Request) string {
+		return r.Header.Get("X-API-Key")
+	}))
+
+	keyA := http.Header{"X-Api-Key": {"a"}}
+	for i := 0; i < 3; i++ {
+		serve(s, http.MethodGet, "/api", keyA)
+	}
+	if rec := serve(s, http.MethodGet, "/api", keyA); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("key a status = %d, want 429", rec.Code)
+	}
+	if rec := serve(s, http.MethodGet, "/api", http.Header{"X-Api-Key": {"b"}}); rec.Code != http.StatusOK {
+		t.Errorf("key b status = %d, want 200", rec.Code)
+	}
+}
+
+func TestServer_RateLimitSkipsHealth(t *testing.T) {
+	s, _ := newRateLimitedServer(t)
+
+	for i := 0; i < 5; i++ {
+		if rec := serve(s, http.MethodGet, "/health/live", nil); rec.Code != http.StatusOK {
+			t.Fatalf("liveness request %d status = %d, want 200", i+1, rec.Code)
+		}
+	}
+}
+
+func TestServer_RateLimitDisabled(t *testing.T) {
+	s := newTestServer(t)
+	if s.rateLimiter != nil {
+		t.Fatal("rate limiter should not be created by default")
+	}
+}
+
+func TestServer_RateLimitUnaryInterceptor(t *testing.T) {
+	s, clock := newRateLimitedServer(t)
+	interceptor := s.rateLimitUnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 50000},
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, err := interceptor(ctx, nil, info, handler); err != nil {
+			t.Fatalf("call %d error = %v", i+1, err)
+		}
+	}
+	_, err := interceptor(ctx, nil, info, handler)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("call past burst code = %v, want ResourceExhausted", status.Code(err))
+	}
+
+	clock.Advance(time.Second)
+	if _, err := interceptor(ctx, nil, info, handler); err != nil {
+		t.Fatalf("call after refill error = %v", err)
+	}
+}
+
+func TestServer_RateLimitSkipsGatewayCalls(t *testing.T) {
+	s, _ := newRateLimitedServer(t)
+	interceptor := s.rateLimitUnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 50000},
+	})
+	md, err := gatewayCredentials{token: s.gatewayToken}.GetRequestMetadata(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx = metadata.NewIncomingContext(ctx, metadata.New(md))
+
+	for i := 0; i < 5; i++ {
+		if _, err := interceptor(ctx, nil, info, handler); err != nil {
+			t.Fatalf("gateway call %d error = %v", i+1, err)
+		}
+	}
+}
+
+func TestServer_RateLimitIgnoresUntrustedForwardedFor(t *testing.T) {
+	s, _ := newRateLimitedServer(t)
+	interceptor := s.rateLimitUnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 50000},
+	})
+
+	for i := 0; i < 3; i++ {
+		md := metadata.Pairs("x-forwarded-for", fmt.Sprintf("203.0.113.%d", i), gatewayTokenMetadataKey, "guess")
+		if _, err := interceptor(metadata.NewIncomingContext(ctx, md), nil, info, handler); err != nil {
+			t.Fatalf("call %d error = %v", i+1, err)
+		}
+	}
+	md := metadata.Pairs("x-forwarded-for", "203.0.113.99")
+	_, err := interceptor(metadata.NewIncomingContext(ctx, md), nil, info, handler)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("call past burst code = %v, want ResourceExhausted", status.Code(err))
+	}
+}
+
+func TestServer_RateLimitTrustedProxies(t *testing.T) {
+	s, _ := newRateLimitedServer(t, WithRateLimitTrustedProxies("10.0.0.0/8"))
+
+	request := func(remoteAddr, forwardedFor string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// The proxy appends the client it saw; a spoofed leading entry is ignored
+	for i := 0; i < 3; i++ {
+		request("10.0.0.1:1234", fmt.Sprintf("198.51.100.%d, 203.0.113.7", i))
+	}
+	if got := request("10.0.0.2:1234", "203.0.113.7, 10.0.0.1"); got != http.StatusTooManyRequests {
+		t.Errorf("same forwarded client status = %d, want 429", got)
+	}
+	if got := request("10.0.0.1:1234", "203.0.113.8"); got != http.StatusOK {
+		t.Errorf("other forwarded client status = %d, want 200", got)
+	}
+	if got := request("192.0.2.1:1234", "203.0.113.7"); got != http.StatusOK {
+		t.Errorf("untrusted peer status = %d, want 200", got)
+	}
+}
+
+func TestNewServer_InvalidTrustedProxy(t *testing.T) {
+	_, err := NewServer(WithRateLimit(1, 1), WithRateLimitTrustedProxies("not-an-ip"))
+	if err == nil {
+		t.Fatal("NewServer() error = nil, want invalid trusted proxy error")
+	}
+}
@@ -3,20 +3,24 @@ package server
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/rompi/core-backend/pkg/server/health"
+	"github.com/rompi/core-backend/pkg/server/internal/ratelimit"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 // Server manages both gRPC and HTTP servers.
@@ -33,6 +37,8 @@ type Server struct {
 	grpcServerOptions  []grpc.ServerOption
 	unaryInterceptors  []grpc.UnaryServerInterceptor
 	streamInterceptors []grpc.StreamServerInterceptor
+	maxRecvMsgSize     int
+	maxSendMsgSize     int
 
 	// HTTP/Gateway
 	httpServer     *http.Server
@@ -40,16 +46,40 @@ type Server struct {
 	gatewayMux     *runtime.ServeMux
 	gatewayOptions []runtime.ServeMuxOption
 	httpMiddleware []Middleware
+	// fallbackHandler serves requests no route matches; see Mount.
+	fallbackHandler http.Handler
+
+	// TLS
+	tlsConfig          *tls.Config
+	certReloadInterval time.Duration
+	serverTLS          *tls.Config
+	certReloader       *certReloader
 
 	// Health
 	healthChecker *health.Checker
 
+	// OpenAPI
+	openAPISpec   []byte
+	openAPIPath   string
+	openAPIUIPath string
+
+	// Rate limiting
+	rateLimiter          *ratelimit.Limiter
+	trustedProxies       []*net.IPNet
+	gatewayToken         string
+	rateLimitKeyFunc     func(*http.Request) string
+	grpcRateLimitKeyFunc func(ctx context.Context, method string) string
+
 	// Auth
-	authenticator Authenticator
+	authenticator   Authenticator
+	grpcAuthRules   map[string]AuthRequirement // nil disables per-method gRPC auth
+	grpcAuthDefault AuthRequirement
 
 	// Lifecycle
 	shutdownHooks []ShutdownHook
+	serveErrs     chan error // errors from the running servers, read by Run
 	started       bool
+	draining      atomic.Bool
 	mu            sync.RWMutex
 }
 
@@ -63,6 +93,7 @@ func NewServer(opts ...Option) (*Server, error) {
 		logger:         DefaultLogger(),
 		httpMux:        http.NewServeMux(),
 		gatewayOptions: make([]runtime.ServeMuxOption, 0),
+		openAPIUIPath:  DefaultOpenAPIUIPath,
 	}
 
 	// Apply options
@@ -85,6 +116,20 @@ func NewServer(opts ...Option) (*Server, error) {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
+	if s.config.RateLimitEnabled {
+		trustedProxies, err := parseTrustedProxies(s.config.RateLimitTrustedProxies)
+		if err != nil {
+			return nil, fmt.Errorf("invalid config: %w", err)
+		}
+		s.trustedProxies = trustedProxies
+		s.rateLimiter = ratelimit.New(s.config.RateLimitRate, s.config.RateLimitBurst, s.config.RateLimitExpiry)
+		s.gatewayToken = uuid.NewString()
+	}
+
+	if err := s.initTLS(); err != nil {
+		return nil, fmt.Errorf("failed to initialize TLS: %w", err)
+	}
+
 	// Initialize gateway mux with default options
 	s.initGatewayMux()
 
@@ -101,6 +146,10 @@ func NewServer(opts ...Option) (*Server, error) {
 		s.registerHealthEndpoints()
 	}
 
+	if s.openAPISpec != nil {
+		s.registerOpenAPIEndpoints()
+	}
+
 	return s, nil
 }
 
@@ -109,9 +158,14 @@ func (s *Server) initGatewayMux() {
 	// Add default gateway options
 	defaultOpts := []runtime.ServeMuxOption{
 		runtime.WithErrorHandler(s.gatewayErrorHandler),
+		runtime.WithRoutingErrorHandler(s.gatewayRoutingErrorHandler),
 		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{}),
 	}
 
+	if s.config.RequestIDEnabled {
+		defaultOpts = append(defaultOpts, runtime.WithMetadata(requestIDMetadata))
+	}
+
 	opts := append(defaultOpts, s.gatewayOptions...)
 	s.gatewayMux = runtime.NewServeMux(opts...)
 }
@@ -121,24 +175,29 @@ func (s *Server) initGRPCServer() error {
 	var opts []grpc.ServerOption
 
 	// Add TLS if enabled
-	if s.config.TLSEnabled {
-		cert, err := tls.LoadX509KeyPair(s.config.TLSCertFile, s.config.TLSKeyFile)
-		if err != nil {
-			return fmt.Errorf("failed to load TLS certificates: %w", err)
-		}
-		creds := credentials.NewTLS(&tls.Config{
-			Certificates: []tls.Certificate{cert},
-			MinVersion:   tls.VersionTLS12,
-		})
-		opts = append(opts, grpc.Creds(creds))
+	if s.serverTLS != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(s.serverTLS.Clone())))
 	}
 
-	// Add interceptors
-	if len(s.unaryInterceptors) > 0 {
-		opts = append(opts, grpc.ChainUnaryInterceptor(s.unaryInterceptors...))
+	// Add interceptors, rejecting rate limited and unauthorized calls before user interceptors run
+	unary := s.unaryInterceptors
+	stream := s.streamInterceptors
+	if s.grpcAuthRules != nil {
+		if s.authenticator == nil {
+			return fmt.Errorf("gRPC auth rules require an authenticator")
+		}
+		unary = append([]grpc.UnaryServerInterceptor{s.grpcAuthUnaryInterceptor()}, unary...)
+		stream = append([]grpc.StreamServerInterceptor{s.grpcAuthStreamInterceptor()}, stream...)
+	}
+	if s.rateLimiter != nil {
+		unary = append([]grpc.UnaryServerInterceptor{s.rateLimitUnaryInterceptor()}, unary...)
+		stream = append([]grpc.StreamServerInterceptor{s.rateLimitStreamInterceptor()}, stream...)
 	}
-	if len(s.streamInterceptors) > 0 {
-		opts = append(opts, grpc.ChainStreamInterceptor(s.streamInterceptors...))
+	if len(unary) > 0 {
+		opts = append(opts, grpc.ChainUnaryInterceptor(unary...))
+	}
+	if len(stream) > 0 {
+		opts = append(opts, grpc.ChainStreamInterceptor(stream...))
 	}
 
 	// Add custom server options
@@ -153,10 +212,10 @@ func (s *Server) initHTTPServer() {
 	// Build the handler chain with middleware
 	var handler http.Handler = s.buildHTTPHandler()
 
-	// Apply middleware in reverse order (first middleware wraps outermost)
-	for i := len(s.httpMiddleware) - 1; i >= 0; i-- {
-		handler = s.httpMiddleware[i](handler)
-	}
+	// Built-in middleware wraps user middleware so CORS preflights never reach it
+	middleware := append(s.builtinMiddleware(), s.httpMiddleware...)
+
+	handler = chainMiddleware(handler, middleware)
 
 	s.httpServer = &http.Server{
 		Addr:         s.httpAddr,
@@ -167,10 +226,8 @@ func (s *Server) initHTTPServer() {
 	}
 
 	// Configure TLS if enabled
-	if s.config.TLSEnabled {
-		s.httpServer.TLSConfig = &tls.Config{
-			MinVersion: tls.VersionTLS12,
-		}
+	if s.serverTLS != nil {
+		s.httpServer.TLSConfig = s.serverTLS.Clone()
 	}
 }
 
@@ -199,7 +256,23 @@ func (s *Server) registerHealthEndpoints() {
 
 	// Register readiness endpoint
 	if s.config.ReadinessHTTPPath != "" {
-		s.httpMux.HandleFunc(s.config.ReadinessHTTPPath, health.ReadinessHandler(s.healthChecker))
+		s.httpMux.HandleFunc(s.config.ReadinessHTTPPath, s.readinessHandler(health.ReadinessHandler(s.healthChecker)))
+	}
+}
+
+// readinessHandler reports not-ready once Shutdown has started, regardless of the health checks.
+func (s *Server) readinessHandler(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.draining.Load() {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			json.NewEncoder(w).Encode(map[string]string{
+				"status": health.StatusUnhealthy,
+				"reason": "shutting down",
+			})
+			return
+		}
+		next(w, r)
 	}
 }
 
@@ -262,16 +335,13 @@ func (s *Server) GatewayMux() *runtime.ServeMux {
 // The handler is generated by protoc-gen-grpc-gateway.
 // Example: server.RegisterGateway(ctx, pb.RegisterUserServiceHandlerFromEndpoint)
 func (s *Server) RegisterGateway(ctx context.Context, registerFunc func(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) error) error {
-	var opts []grpc.DialOption
-	if s.config.TLSEnabled {
-		// Use TLS credentials for gateway-to-gRPC connection
-		creds, err := credentials.NewClientTLSFromFile(s.config.TLSCertFile, "")
-		if err != nil {
-			return fmt.Errorf("failed to load TLS credentials: %w", err)
-		}
-		opts = append(opts, grpc.WithTransportCredentials(creds))
-	} else {
-		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	creds, err := s.clientCredentials()
+	if err != nil {
+		return err
+	}
+	opts := append(s.clientDialOptions(), grpc.WithTransportCredentials(creds))
+	if s.gatewayToken != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(gatewayCredentials{token: s.gatewayToken}))
 	}
 
 	return registerFunc(ctx, s.gatewayMux, s.grpcAddr, opts)
@@ -296,6 +366,21 @@ func (s *Server) Handle(pattern string, handler http.Handler) {
 	s.httpMux.Handle(pattern, handler)
 }
 
+// HandleWithMiddleware registers a custom HTTP handler wrapped in middleware that only applies to pattern.
+// The server-wide middleware still wraps the route-scoped middleware.
+// Example: server.HandleWithMiddleware("/admin/", adminHandler, requireAdmin)
+func (s *Server) HandleWithMiddleware(pattern string, handler http.Handler, middleware ...Middleware) {
+	s.httpMux.Handle(pattern, chainMiddleware(handler, middleware))
+}
+
+// chainMiddleware wraps handler so that the first middleware is the outermost.
+func chainMiddleware(handler http.Handler, middleware []Middleware) http.Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+	return handler
+}
+
 // --- Lifecycle ---
 
 // Start starts both gRPC and HTTP servers (non-blocking).
@@ -307,16 +392,26 @@ func (s *Server) Start() error {
 		return fmt.Errorf("server already started")
 	}
 
-	// Start gRPC server
+	// Bind both listeners up front so a port in use is reported here rather than from a goroutine
 	grpcLis, err := net.Listen("tcp", s.grpcAddr)
 	if err != nil {
 		return fmt.Errorf("failed to listen on %s: %w", s.grpcAddr, err)
 	}
+	httpLis, err := net.Listen("tcp", s.httpAddr)
+	if err != nil {
+		grpcLis.Close()
+		return fmt.Errorf("failed to listen on %s: %w", s.httpAddr, err)
+	}
 
+	serveErrs := make(chan error, 2)
+	s.serveErrs = serveErrs
+
+	// Start gRPC server
 	go func() {
 		s.logger.Info("gRPC server starting", "addr", s.grpcAddr)
 		if err := s.grpcServer.Serve(grpcLis); err != nil {
 			s.logger.Error("gRPC server error", "error", err)
+			serveErrs <- fmt.Errorf("gRPC server: %w", err)
 		}
 	}()
 
@@ -324,16 +419,23 @@ func (s *Server) Start() error {
 	go func() {
 		s.logger.Info("HTTP server starting", "addr", s.httpAddr)
 		var err error
-		if s.config.TLSEnabled {
-			err = s.httpServer.ListenAndServeTLS(s.config.TLSCertFile, s.config.TLSKeyFile)
+		if s.httpServer.TLSConfig != nil {
+			// Certificates come from TLSConfig, which also covers WithTLSConfig and WithCertReloader
+			err = s.httpServer.ServeTLS(httpLis, "", "")
 		} else {
-			err = s.httpServer.ListenAndServe()
+			err = s.httpServer.Serve(httpLis)
 		}
 		if err != nil && err != http.ErrServerClosed {
 			s.logger.Error("HTTP server error", "error", err)
+			serveErrs <- fmt.Errorf("HTTP server: %w", err)
 		}
 	}()
 
+	if s.certReloader != nil {
+		s.certReloader.start(s.certReloadInterval)
+	}
+
+	s.draining.Store(false)
 	s.started = true
 	return nil
 }
@@ -341,31 +443,60 @@ func (s *Server) Start() error {
 // ListenAndServe starts the servers and blocks until shutdown.
 // Handles OS signals (SIGINT, SIGTERM) for graceful shutdown.
 func (s *Server) ListenAndServe() error {
+	return s.Run(context.Background())
+}
+
+// Run starts the servers and blocks until ctx is cancelled, SIGINT or SIGTERM arrives, or either
+// server stops with an error. It returns the error from Start immediately, for example when a port
+// is in use. Otherwise the servers are shut down gracefully and Run returns nil after a clean
+// shutdown, or the server and shutdown errors.
+func (s *Server) Run(ctx context.Context) error {
 	if err := s.Start(); err != nil {
 		return err
 	}
+	s.mu.RLock()
+	serveErrs := s.serveErrs
+	s.mu.RUnlock()
 
-	// Wait for interrupt signal
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	var serveErr error
+	select {
+	case <-ctx.Done():
+	case serveErr = <-serveErrs:
+	}
 
 	s.logger.Info("Shutting down servers...")
 
 	// Create shutdown context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), s.config.ShutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.config.ShutdownTimeout)
 	defer cancel()
 
-	return s.Shutdown(ctx)
+	return errors.Join(serveErr, s.Shutdown(shutdownCtx))
 }
 
 // Shutdown gracefully shuts down both servers.
+// Readiness reports not-ready immediately; after ShutdownDrainDelay the listeners are closed
+// and in-flight requests are drained.
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	var errs []error
 
+	// Fail readiness first so load balancers stop routing new traffic
+	s.draining.Store(true)
+	if delay := s.config.ShutdownDrainDelay; delay > 0 {
+		s.logger.Info("draining before shutdown", "delay", delay)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+
 	// Run shutdown hooks
 	for _, hook := range s.shutdownHooks {
 		if err := hook(); err != nil {
@@ -393,6 +524,10 @@ func (s *Server) Shutdown(ctx context.Context) error {
 		// Graceful shutdown completed
 	}
 
+	if s.certReloader != nil {
+		s.certReloader.shutdown()
+	}
+
 	s.started = false
 
 	if len(errs) > 0 {
@@ -463,18 +598,31 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.httpServer.Handler.ServeHTTP(w, r)
 }
 
+// clientDialOptions returns dial options that let clients of this server exchange messages as large
+// as the server accepts and sends; see WithMaxMessageSize.
+func (s *Server) clientDialOptions() []grpc.DialOption {
+	var callOpts []grpc.CallOption
+	if s.maxRecvMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(s.maxRecvMsgSize))
+	}
+	if s.maxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(s.maxSendMsgSize))
+	}
+	if len(callOpts) == 0 {
+		return nil
+	}
+	return []grpc.DialOption{grpc.WithDefaultCallOptions(callOpts...)}
+}
+
 // DialGRPC creates a gRPC client connection to this server.
 // Useful for in-process testing.
 func (s *Server) DialGRPC(ctx context.Context, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
-	if s.config.TLSEnabled {
-		creds, err := credentials.NewClientTLSFromFile(s.config.TLSCertFile, "")
-		if err != nil {
-			return nil, fmt.Errorf("failed to load TLS credentials: %w", err)
-		}
-		opts = append(opts, grpc.WithTransportCredentials(creds))
-	} else {
-		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	creds, err := s.clientCredentials()
+	if err != nil {
+		return nil, err
 	}
+	opts = append(opts, s.clientDialOptions()...)
+	opts = append(opts, grpc.WithTransportCredentials(creds))
 
 	return grpc.DialContext(ctx, s.grpcAddr, opts...)
 }
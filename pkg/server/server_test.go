@@ -0,0 +1,196 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func newTestServer(t *testing.T, opts ...Option) *Server {
+	t.Helper()
+	opts = append([]Option{
+		WithLogger(NoopLogger{}),
+		WithGRPCAddr("127.0.0.1:0"),
+		WithHTTPAddr("127.0.0.1:0"),
+	}, opts...)
+	s, err := NewServer(opts...)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	return s
+}
+
+func serve(s *Server, method, path string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_ShutdownDrainsReadiness(t *testing.T) {
+	s := newTestServer(t, WithShutdownDrainDelay(300*time.Millisecond))
+
+	if rec := serve(s, http.MethodGet, "/health/ready", nil); rec.Code != http.StatusOK {
+		t.Fatalf("readiness before shutdown = %d, want 200", rec.Code)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Shutdown(context.Background())
+	}()
+
+	deadline := time.Now().Add(200 * time.Millisecond)
+	for {
+		if rec := serve(s, http.MethodGet, "/health/ready", nil); rec.Code == http.StatusServiceUnavailable {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("readiness did not flip to 503 during drain")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	select {
+	case err := <-done:
+		t.Fatalf("Shutdown() returned before the drain delay: %v", err)
+	default:
+	}
+
+	if rec := serve(s, http.MethodGet, "/health/live", nil); rec.Code != http.StatusOK {
+		t.Errorf("liveness during drain = %d, want 200", rec.Code)
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+}
+
+func TestServer_RunReturnsBindError(t *testing.T) {
+	taken, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer taken.Close()
+	s := newTestServer(t, WithHTTPAddr(taken.Addr().String()))
+
+	done := make(chan error, 1)
+	go func() { done <- s.Run(context.Background()) }()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("Run() error = nil, want bind error")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run() did not return after the HTTP listener failed to bind")
+	}
+	if s.IsStarted() {
+		t.Error("server reports started after a bind failure")
+	}
+}
+
+func TestServer_RunStopsOnContextCancel(t *testing.T) {
+	s := newTestServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- s.Run(ctx) }()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !s.IsStarted() {
+		if time.Now().After(deadline) {
+			t.Fatal("server did not start")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run() error = %v, want nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run() did not return after the context was cancelled")
+	}
+	if s.IsStarted() {
+		t.Error("server reports started after Run returned")
+	}
+}
+
+func TestServer_GRPCAuthRulesInstalled(t *testing.T) {
+	s := newTestServer(t,
+		WithAuthenticator(NoopAuthenticator{}),
+		WithGRPCAuthRules(map[string]AuthRequirement{healthpb.Health_Check_FullMethodName: AuthPublic}),
+	)
+	s.RegisterService(&healthpb.Health_ServiceDesc, grpchealth.NewServer())
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = s.grpcServer.Serve(lis) }()
+	defer s.grpcServer.Stop()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("Check() on a public method error = %v", err)
+	}
+	if _, err := client.List(ctx, &healthpb.HealthListRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("List() without a token error = %v, want Unauthenticated", err)
+	}
+}
+
+func TestServer_MaxMessageSizeRejectsOversizedMessages(t *testing.T) {
+	s := newTestServer(t, WithMaxMessageSize(1024, 0))
+	s.RegisterService(&healthpb.Health_ServiceDesc, grpchealth.NewServer())
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = s.grpcServer.Serve(lis) }()
+	defer s.grpcServer.Stop()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("Check() with a small message error = %v", err)
+	}
+	_, err = client.Check(ctx, &healthpb.HealthCheckRequest{Service: strings.Repeat("x", 2048)})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Check() with an oversized message error = %v, want ResourceExhausted", err)
+	}
+}
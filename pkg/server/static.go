@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// Mount serves h under prefix, stripping the prefix from the request path.
+// Mounts take precedence over gateway routes below the same prefix. Mounting at "/" instead makes h
+// the fallback for requests that match no custom handler or gateway route.
+// Example: server.Mount("/legacy", legacyProxy)
+func (s *Server) Mount(prefix string, h http.Handler) {
+	prefix = "/" + strings.Trim(prefix, "/")
+	if prefix == "/" {
+		s.fallbackHandler = h
+		return
+	}
+	s.httpMux.Handle(prefix+"/", http.StripPrefix(prefix, h))
+}
+
+// Static serves the files in dir under prefix. Paths without a file extension that match no file
+// are answered with dir/index.html so client-side routes of a single-page app load the app.
+// Example: server.Static("/", "./web/dist")
+func (s *Server) Static(prefix, dir string) {
+	s.Mount(prefix, staticHandler(os.DirFS(dir)))
+}
+
+// gatewayRoutingErrorHandler sends requests the gateway has no route for to the handler mounted at "/".
+func (s *Server) gatewayRoutingErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, httpStatus int) {
+	if httpStatus == http.StatusNotFound && s.fallbackHandler != nil {
+		s.fallbackHandler.ServeHTTP(w, r)
+		return
+	}
+	runtime.DefaultRoutingErrorHandler(ctx, mux, marshaler, w, r, httpStatus)
+}
+
+// staticHandler serves files from fsys with a fallback to index.html for single-page app routes.
+// Directory listings are never served.
+func staticHandler(fsys fs.FS) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+		if name == "" {
+			name = "."
+		}
+
+		info, err := fs.Stat(fsys, name)
+		switch {
+		case err == nil && !info.IsDir():
+			http.ServeFileFS(w, r, fsys, name)
+			return
+		case err == nil:
+			if index := path.Join(name, "index.html"); fileExists(fsys, index) {
+				http.ServeFileFS(w, r, fsys, index)
+				return
+			}
+		case !errors.Is(err, fs.ErrNotExist):
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		// Missing assets such as /app.js are real 404s; anything else is a client-side route
+		if path.Ext(name) == "" && fileExists(fsys, "index.html") {
+			http.ServeFileFS(w, r, fsys, "index.html")
+			return
+		}
+		http.NotFound(w, r)
+	})
+}
+
+func fileExists(fsys fs.FS, name string) bool {
+	info, err := fs.Stat(fsys, name)
+	return err == nil && !info.IsDir()
+}
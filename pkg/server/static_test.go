@@ -0,0 +1,110 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeStaticFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestServer_Static(t *testing.T) {
+	dir := writeStaticFiles(t, map[string]string{
+		"index.html":      "<html>app</html>",
+		"assets/app.js":   "console.log('app')",
+		"docs/index.html": "<html>docs</html>",
+	})
+	s := newTestServer(t)
+	s.Static("/app", dir)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		wantCode int
+		wantBody string
+	}{
+		{name: "file", method: http.MethodGet, path: "/app/assets/app.js", wantCode: http.StatusOK, wantBody: "console.log('app')"},
+		{name: "root index", method: http.MethodGet, path: "/app/", wantCode: http.StatusOK, wantBody: "<html>app</html>"},
+		{name: "directory index", method: http.MethodGet, path: "/app/docs/", wantCode: http.StatusOK, wantBody: "<html>docs</html>"},
+		{name: "missing file", method: http.MethodGet, path: "/app/assets/missing.js", wantCode: http.StatusNotFound},
+		{name: "spa route", method: http.MethodGet, path: "/app/users/42", wantCode: http.StatusOK, wantBody: "<html>app</html>"},
+		{name: "method not allowed", method: http.MethodPost, path: "/app/assets/app.js", wantCode: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(s, tt.method, tt.path, nil)
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestServer_StaticRootFallback(t *testing.T) {
+	dir := writeStaticFiles(t, map[string]string{
+		"index.html": "<html>app</html>",
+		"app.js":     "console.log('app')",
+	})
+	s := newTestServer(t)
+	s.HandleFunc("/api/ping", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "pong")
+	})
+	s.Static("/", dir)
+
+	if rec := serve(s, http.MethodGet, "/api/ping", nil); rec.Body.String() != "pong" {
+		t.Errorf("custom handler body = %q, want %q", rec.Body.String(), "pong")
+	}
+	if rec := serve(s, http.MethodGet, "/health/live", nil); rec.Code != http.StatusOK || strings.Contains(rec.Body.String(), "<html>") {
+		t.Errorf("health endpoint = %d %q, want it served by the health handler", rec.Code, rec.Body.String())
+	}
+	if rec := serve(s, http.MethodGet, "/app.js", nil); rec.Body.String() != "console.log('app')" {
+		t.Errorf("file body = %q", rec.Body.String())
+	}
+	if rec := serve(s, http.MethodGet, "/settings/profile", nil); rec.Code != http.StatusOK || rec.Body.String() != "<html>app</html>" {
+		t.Errorf("spa route = %d %q, want index.html", rec.Code, rec.Body.String())
+	}
+	if rec := serve(s, http.MethodGet, "/missing.css", nil); rec.Code != http.StatusNotFound {
+		t.Errorf("missing file status = %d, want 404", rec.Code)
+	}
+}
+
+func TestServer_Mount(t *testing.T) {
+	s := newTestServer(t)
+	s.Mount("/proxy/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "proxied "+r.URL.Path)
+	}))
+
+	rec := serve(s, http.MethodGet, "/proxy/users/1", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if got := rec.Body.String(); got != "proxied /users/1" {
+		t.Errorf("body = %q, want %q", got, "proxied /users/1")
+	}
+
+	// Unmounted paths still reach the gateway, which has no routes here
+	if rec := serve(s, http.MethodGet, "/other", nil); rec.Code != http.StatusNotFound {
+		t.Errorf("unmounted status = %d, want 404", rec.Code)
+	}
+}
@@ -0,0 +1,239 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTestCert returns a self-signed PEM certificate and key for 127.0.0.1 with the given common name.
+func newTestCert(t *testing.T, commonName string) (certPEM, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeTestCert(t *testing.T, certFile, keyFile, commonName string) {
+	t.Helper()
+	certPEM, keyPEM := newTestCert(t, commonName)
+	if err := os.WriteFile(certFile, certPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, keyPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// serveTLS completes TLS handshakes with cfg on a loopback listener and returns its address.
+func serveTLS(t *testing.T, cfg *tls.Config) string {
+	t.Helper()
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.(*tls.Conn).Handshake()
+			conn.Close()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+// presentedCommonName returns the common name of the certificate served at addr.
+func presentedCommonName(t *testing.T, addr string) string {
+	t.Helper()
+	conn, err := tls.Dial("tcp", addr, &tls.Config{InsecureSkipVerify: true}) // #nosec G402 -- test inspects the certificate only.
+	if err != nil {
+		t.Fatalf("tls.Dial() error = %v", err)
+	}
+	defer conn.Close()
+	return conn.ConnectionState().PeerCertificates[0].Subject.CommonName
+}
+
+func TestServer_WithTLSConfig(t *testing.T) {
+	certPEM, keyPEM := newTestCert(t, "in-memory")
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := newTestServer(t, WithTLSConfig(&tls.Config{Certificates: []tls.Certificate{cert}}))
+	if s.httpServer.TLSConfig == nil {
+		t.Fatal("HTTP server TLS config not set")
+	}
+	if s.httpServer.TLSConfig.MinVersion != tls.VersionTLS12 {
+		t.Errorf("MinVersion = %x, want TLS 1.2", s.httpServer.TLSConfig.MinVersion)
+	}
+	if got := presentedCommonName(t, serveTLS(t, s.httpServer.TLSConfig)); got != "in-memory" {
+		t.Errorf("presented certificate = %q, want in-memory", got)
+	}
+	if !s.config.TLSEnabled {
+		t.Error("TLSEnabled = false, want true")
+	}
+}
+
+func TestServer_WithTLSConfigClientTrustsSelfSignedCertificate(t *testing.T) {
+	certPEM, keyPEM := newTestCert(t, "in-memory")
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newTestServer(t, WithTLSConfig(&tls.Config{Certificates: []tls.Certificate{cert}}))
+
+	creds, err := s.clientCredentials()
+	if err != nil {
+		t.Fatalf("clientCredentials() error = %v", err)
+	}
+	handshake := func(addr string) error {
+		raw, err := net.Dial("tcp", addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		conn, _, err := creds.ClientHandshake(context.Background(), addr, raw)
+		if err == nil {
+			conn.Close()
+		}
+		return err
+	}
+
+	// gRPC requires ALPN, which the gRPC server negotiates itself
+	serverTLS := s.httpServer.TLSConfig.Clone()
+	serverTLS.NextProtos = []string{"h2"}
+	if err := handshake(serveTLS(t, serverTLS)); err != nil {
+		t.Fatalf("handshake with the configured certificate error = %v", err)
+	}
+
+	certPEM, keyPEM = newTestCert(t, "impostor")
+	impostor, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := handshake(serveTLS(t, &tls.Config{Certificates: []tls.Certificate{impostor}, NextProtos: []string{"h2"}})); err == nil {
+		t.Error("handshake with another certificate should fail")
+	}
+}
+
+func TestServer_CertReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	writeTestCert(t, certFile, keyFile, "first")
+
+	s := newTestServer(t, WithCertReloader(certFile, keyFile, 10*time.Millisecond))
+	addr := serveTLS(t, s.httpServer.TLSConfig)
+	if got := presentedCommonName(t, addr); got != "first" {
+		t.Fatalf("presented certificate = %q, want first", got)
+	}
+
+	s.certReloader.start(s.certReloadInterval)
+	defer s.certReloader.shutdown()
+
+	writeTestCert(t, certFile, keyFile, "second")
+	deadline := time.Now().Add(2 * time.Second)
+	for presentedCommonName(t, addr) != "second" {
+		if time.Now().After(deadline) {
+			t.Fatal("server still presents the old certificate after rotation")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestCertReloader_KeepsCertificateOnFailedReload(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	writeTestCert(t, certFile, keyFile, "valid")
+
+	r, err := newCertReloader(certFile, keyFile, NoopLogger{})
+	if err != nil {
+		t.Fatalf("newCertReloader() error = %v", err)
+	}
+	// A half-written file, as seen mid-rotation
+	if err := os.WriteFile(certFile, []byte("-----BEGIN CERTIFICATE-----"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.reload(); err == nil {
+		t.Fatal("reload() should fail for an invalid certificate")
+	}
+	if got := presentedCommonName(t, serveTLS(t, &tls.Config{GetCertificate: r.GetCertificate})); got != "valid" {
+		t.Errorf("presented certificate = %q, want valid", got)
+	}
+}
+
+func TestCertReloader_ClientConfigPinsCurrentCertificate(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	writeTestCert(t, certFile, keyFile, "first")
+
+	r, err := newCertReloader(certFile, keyFile, NoopLogger{})
+	if err != nil {
+		t.Fatalf("newCertReloader() error = %v", err)
+	}
+	addr := serveTLS(t, &tls.Config{GetCertificate: r.GetCertificate})
+
+	dial := func() error {
+		conn, err := tls.Dial("tcp", addr, r.clientConfig())
+		if err == nil {
+			conn.Close()
+		}
+		return err
+	}
+	if err := dial(); err != nil {
+		t.Fatalf("dial before rotation error = %v", err)
+	}
+
+	writeTestCert(t, certFile, keyFile, "second")
+	if err := r.reload(); err != nil {
+		t.Fatalf("reload() error = %v", err)
+	}
+	if err := dial(); err != nil {
+		t.Fatalf("dial after rotation error = %v", err)
+	}
+
+	certPEM, keyPEM := newTestCert(t, "impostor")
+	impostor, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := serveTLS(t, &tls.Config{Certificates: []tls.Certificate{impostor}})
+	if conn, err := tls.Dial("tcp", other, r.clientConfig()); err == nil {
+		conn.Close()
+		t.Error("dial to a server with another certificate should fail")
+	}
+}